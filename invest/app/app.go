@@ -1,37 +1,55 @@
 package app
 
 import (
-	"fmt"
+	"invest/app/dashboard"
 	"invest/app/handler"
+	"invest/app/middleware"
 	"invest/db"
+	"invest/event"
+	m "invest/model"
 	"invest/scrape"
+	"log"
 
 	"github.com/gofiber/fiber/v2"
 )
 
-func Run(stg *db.Storage, scraper *scrape.Scraper) {
+/*
+New
+라우트와 인증 미들웨어가 설정된 서버. 수신 시작(Listen)과 종료는 호출 측에서 관리
+  - secret : JWT 서명 키. 빈 값이면 API 키 인증만 허용
+  - shutdown : /shutdown 요청 시 호출. 서버 종료 절차 시작
+*/
+func New(stg *db.Storage, scraper *scrape.Scraper, event *event.Event, secret []byte, shutdown func()) *fiber.App {
 
-	app := fiber.New()
+	// 라우트 미존재 등 핸들러 밖의 오류도 같은 형식으로 응답
+	app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+	middleware.SetupMiddleware(app)
+	docsRoute(app)
 
-	handler.NewAssetHandler(stg, stg, scraper).InitRoute(app)
-	handler.NewFundHandler(stg, stg, scraper).InitRoute(app)
-	handler.NewInvestHandler(stg, stg, scraper).InitRoute(app)
-	handler.NewMarketHandler(stg, stg).InitRoute(app)
+	// 대시보드는 인증 실패, 오류를 HTML 화면으로 응답. API 인증 미들웨어보다 먼저 등록
+	authn := middleware.NewAuthenticator(stg, secret)
+	dashboard.NewDashboardHandler(stg, event, authn).InitRoute(app)
+	app.Use(authn.Handler())
 
-	app.Get("/shutdown", func(c *fiber.Ctx) error {
+	handler.NewAssetHandler(stg, stg, event).InitRoute(app)
+	handler.NewFundHandler(stg, stg, scraper, event).InitRoute(app)
+	handler.NewInvestHandler(stg, event, stg).InitRoute(app)
+	handler.NewMarketHandler(stg, stg).InitRoute(app)
+	handler.NewIndicatorHandler(stg, stg).InitRoute(app)
+	handler.NewMonitorHandler(stg, stg, event).InitRoute(app)
 
-		fmt.Println("Shutting Down")
-		panic("SHUTDOWN")
-		// go func() {
-		// 	if err := app.Shutdown(); err != nil {
-		// 		panic("SHUTDOWN ERROR")
-		// 	}
-		// }()
-		// return c.SendString("Shutting Down")
-	})
+	app.Post("/shutdown", middleware.Require(m.AdminScope), shutdownHandler(shutdown))
 
-	app.Listen(":3000")
+	return app
+}
 
+// 응답 후 종료 절차 진행. 진행 중 요청은 완료까지 대기
+func shutdownHandler(shutdown func()) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		log.Printf("[API] 종료 요청. %s", c.IP())
+		shutdown()
+		return c.Status(fiber.StatusAccepted).JSON(shutdownResponse{Message: "Shutting Down"})
+	}
 }
 
 /*
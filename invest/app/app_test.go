@@ -0,0 +1,104 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"invest/client"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// 선택 경로 파라미터는 파라미터 유무 두 경로로 확장. /market/:date? -> /market, /market/{date}
+func openapiPaths(path string) []string {
+
+	param := regexp.MustCompile(`:(\w+)(<[^>]*>)?`)
+	path = strings.TrimSuffix(path, "/")
+	if path == "" {
+		path = "/"
+	}
+
+	if strings.HasSuffix(path, "?") {
+		base := path[:strings.LastIndex(path, "/")]
+		full := strings.TrimSuffix(path, "?")
+		return []string{param.ReplaceAllString(base, "{$1}"), param.ReplaceAllString(full, "{$1}")}
+	}
+	return []string{param.ReplaceAllString(path, "{$1}")}
+}
+
+func TestOpenAPI(t *testing.T) {
+
+	server := New(nil, nil, nil, nil, func() {})
+
+	t.Run("등록 라우트와 명세 일치", func(t *testing.T) {
+		registered := make(map[string]bool)
+		for _, r := range server.GetRoutes(true) {
+			if r.Method == fiber.MethodHead || r.Path == "/openapi.json" || r.Path == "/docs" || strings.HasPrefix(r.Path, "/dashboard") {
+				continue // 화면 라우트는 API 명세 제외
+			}
+			for _, p := range openapiPaths(r.Path) {
+				registered[strings.ToLower(r.Method)+" "+p] = true
+			}
+		}
+
+		documented := make(map[string]bool)
+		for _, e := range OpenAPI().Operations() {
+			documented[e.Method+" "+e.Path] = true
+			assert.True(t, registered[e.Method+" "+e.Path], "라우트 미존재. %s %s", e.Method, e.Path)
+			assert.NotEmpty(t, e.Scope, "권한 범위 미설정. %s %s", e.Method, e.Path)
+		}
+		for r := range registered {
+			assert.True(t, documented[r], "명세 미존재. %s. handler.Routes 수정 필요", r)
+		}
+	})
+
+	t.Run("명세 문서는 인증 없이 조회", func(t *testing.T) {
+		resp, err := server.Test(httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		body, _ := io.ReadAll(resp.Body)
+		assert.Contains(t, string(body), `"operationId":"listInvests"`)
+
+		resp, err = server.Test(httptest.NewRequest(http.MethodGet, "/docs", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), "text/html")
+	})
+
+	t.Run("대시보드 미인증 시 로그인 화면, API는 JSON 오류", func(t *testing.T) {
+		resp, err := server.Test(httptest.NewRequest(http.MethodGet, "/dashboard", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/dashboard/login", resp.Header.Get(fiber.HeaderLocation))
+
+		resp, err = server.Test(httptest.NewRequest(http.MethodGet, "/assets", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), fiber.MIMEApplicationJSON)
+	})
+
+	t.Run("생성 클라이언트로 요청", func(t *testing.T) {
+		api := client.New("http://invest", client.WithHTTPClient(&http.Client{Transport: fiberTransport{server}}))
+
+		_, err := api.ListInvests(context.Background(), client.ListInvestsParams{Limit: 10})
+		var e *client.Error
+		assert.True(t, errors.As(err, &e))
+		assert.Equal(t, fiber.StatusUnauthorized, e.Status)
+		assert.Equal(t, "UNAUTHORIZED", e.Code)
+	})
+}
+
+// 서버 수신 없이 fiber 앱으로 요청 전달
+type fiberTransport struct {
+	app *fiber.App
+}
+
+func (f fiberTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f.app.Test(req, -1)
+}
@@ -0,0 +1,637 @@
+package dashboard
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html/template"
+	"invest/app/middleware"
+	m "invest/model"
+	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Storage interface {
+	RetrieveFunds() ([]m.Fund, error)
+	RetrieveAssetList() ([]m.Asset, error)
+	RetrieveAsset(id uint) (*m.Asset, error)
+	RetrieveDailyPrices(assetId uint, from string, to string) ([]m.DailyPrice, error)
+	RetrieveInvests(q m.InvestQuery) ([]m.Invest, error)
+	RetrieveMarketStatus(date string) (*m.Market, error)
+	RetrieveMarketStatuses(from string, to string) ([]m.Market, error)
+	RetrieveAlertLogs(from time.Time, to time.Time) ([]m.AlertLog, error)
+	SaveFund(name string) error
+	SaveMarketStatus(status uint) error
+	UpdateAssetInfo(id uint, name string, category m.Category, code string, currency string, top float64, bottom float64, selPrice float64, buyPrice float64) error
+}
+
+type Service interface {
+	Allocations() ([]m.Allocation, error)
+	PriceChart(assetId uint, days int) (*m.Chart, error)
+	RecordInvest(fundId uint, assetId uint, price float64, count float64) error
+	AddAsset(asset m.Asset, averages []m.AssetAverage) (uint, error)
+}
+
+type Authenticator interface {
+	Handler() fiber.Handler
+	Verify(credential string) (*middleware.Principal, error)
+}
+
+const (
+	basePath    = "/dashboard"
+	priceDays   = 30  // 자산 상세 시세 표 기간
+	chartDays   = 120 // 자산 상세 시세 차트 기간 (영업일)
+	investLimit = 50  // 투자 이력 페이지 크기
+	marketYears = 1   // 시장 단계 이력 기간
+	alertDays   = 7   // 최근 알림 기본 기간
+)
+
+/*
+DashboardHandler
+서버 렌더링 대시보드. 조회 화면과 저장/변경 양식
+  - 인증 쿠키(middleware.CookieName) 혹은 API 인증 헤더 필요. 미인증 시 로그인 화면으로 이동
+  - 양식 요청은 write 권한 필요. 처리 후 결과 메시지와 함께 화면으로 이동
+*/
+type DashboardHandler struct {
+	s    Storage
+	svc  Service
+	auth Authenticator
+	now  func() time.Time
+}
+
+func NewDashboardHandler(s Storage, svc Service, auth Authenticator) *DashboardHandler {
+	return &DashboardHandler{
+		s:    s,
+		svc:  svc,
+		auth: auth,
+		now:  time.Now,
+	}
+}
+
+// API 인증 미들웨어보다 먼저 등록. 로그인 화면은 인증 없이 조회
+func (h *DashboardHandler) InitRoute(app *fiber.App) {
+
+	app.Get(basePath+"/login", h.LoginPage)
+	app.Post(basePath+"/login", h.Login)
+
+	router := app.Group(basePath, h.pageError, h.auth.Handler())
+
+	router.Post("/logout", h.Logout)
+	router.Get("/", h.Overview)
+	router.Post("/funds", h.AddFund)
+	router.Get("/assets", h.Assets)
+	router.Post("/assets", h.AddAsset)
+	router.Get("/assets/:id<\\d+>", h.Asset)
+	router.Post("/assets/:id<\\d+>", h.UpdateAsset)
+	router.Get("/invests", h.Invests)
+	router.Post("/invests", h.RecordInvest)
+	router.Get("/market", h.Market)
+	router.Post("/market", h.SaveMarket)
+	router.Get("/alerts", h.Alerts)
+}
+
+/*
+오류 화면
+  - 미인증 : 로그인 화면으로 이동
+  - 그 외 : 상태 코드와 오류 메시지 화면
+*/
+func (h *DashboardHandler) pageError(c *fiber.Ctx) error {
+
+	err := c.Next()
+	if err == nil {
+		return nil
+	}
+
+	if errors.Is(err, m.ErrUnauthorized) {
+		return c.Redirect(basePath + "/login")
+	}
+
+	status, body := middleware.Classify(err)
+	if status == fiber.StatusInternalServerError {
+		log.Printf("[Dashboard] %s %s. %s", c.Method(), c.Path(), err)
+	}
+	return h.render(c.Status(status), "error", "오류", "", body)
+}
+
+func (h *DashboardHandler) LoginPage(c *fiber.Ctx) error {
+	return h.render(c, "login", "로그인", "", nil)
+}
+
+// API 키 혹은 JWT 확인 후 인증 쿠키 발급. 같은 사이트 요청에만 전송(SameSite=Strict)
+func (h *DashboardHandler) Login(c *fiber.Ctx) error {
+
+	credential := strings.TrimSpace(c.FormValue("key"))
+	_, err := h.auth.Verify(credential)
+	if errors.Is(err, m.ErrUnauthorized) {
+		c.Locals(errorKey, err.Error())
+		return h.render(c.Status(fiber.StatusUnauthorized), "login", "로그인", "", nil)
+	} else if err != nil {
+		return fmt.Errorf("Verify 시 오류 발생. %w", err)
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     middleware.CookieName,
+		Value:    credential,
+		Path:     "/",
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteStrictMode,
+	})
+	return c.Redirect(basePath)
+}
+
+func (h *DashboardHandler) Logout(c *fiber.Ctx) error {
+	c.ClearCookie(middleware.CookieName)
+	return c.Redirect(basePath + "/login")
+}
+
+type overviewData struct {
+	Market      *m.MarketLevel
+	Allocations []m.Allocation
+	Empty       []m.Fund // 평가 금액 없는 자금
+}
+
+// 자금별 평가 금액, 변동 자산 비율과 허용 범위, 자산 비중
+func (h *DashboardHandler) Overview(c *fiber.Ctx) error {
+
+	funds, err := h.s.RetrieveFunds()
+	if err != nil {
+		return fmt.Errorf("RetrieveFunds 시 오류 발생. %w", err)
+	}
+
+	data := overviewData{}
+	market, err := h.s.RetrieveMarketStatus("")
+	if err == nil {
+		level := m.MarketLevel(market.Status)
+		data.Market = &level
+
+		data.Allocations, err = h.svc.Allocations()
+		if err != nil {
+			return fmt.Errorf("Allocations 시 오류 발생. %w", err)
+		}
+	} else if !errors.Is(err, m.ErrNotFound) {
+		return fmt.Errorf("RetrieveMarketStatus 시 오류 발생. %w", err)
+	}
+
+	names := make(map[uint]string)
+	for _, f := range funds {
+		names[f.ID] = f.Name
+	}
+	listed := make(map[uint]bool)
+	for i, a := range data.Allocations {
+		listed[a.Fund.ID] = true
+		if data.Allocations[i].Fund.Name == "" {
+			data.Allocations[i].Fund.Name = names[a.Fund.ID]
+		}
+	}
+	for _, f := range funds {
+		if !listed[f.ID] {
+			data.Empty = append(data.Empty, f)
+		}
+	}
+
+	return h.render(c, "overview", "자금 현황", "funds", data)
+}
+
+func (h *DashboardHandler) AddFund(c *fiber.Ctx) error {
+
+	name := strings.TrimSpace(c.FormValue("name"))
+	if name == "" {
+		return h.redirect(c, basePath, invalid("자금 이름 필수"))
+	}
+
+	err := h.s.SaveFund(name)
+	if err != nil {
+		return h.redirect(c, basePath, fmt.Errorf("SaveFund 시 오류 발생. %w", err))
+	}
+	return h.redirect(c, basePath, nil, "자금 추가 성공. "+name)
+}
+
+type assetsData struct {
+	Assets     []m.Asset
+	Categories []m.Category
+}
+
+func (h *DashboardHandler) Assets(c *fiber.Ctx) error {
+
+	assets, err := h.s.RetrieveAssetList()
+	if err != nil {
+		return fmt.Errorf("RetrieveAssetList 시 오류 발생. %w", err)
+	}
+	return h.render(c, "assets", "자산", "assets", assetsData{Assets: assets, Categories: categories()})
+}
+
+// 자산 추가. 이동평균은 쉼표 구분. ex) EMA20,EMA200
+func (h *DashboardHandler) AddAsset(c *fiber.Ctx) error {
+
+	back := basePath + "/assets"
+	f := form{c: c}
+	asset := m.Asset{
+		Name:      f.str("name", "이름", true),
+		Category:  m.Category(f.uint("category", "카테고리", true)),
+		Code:      f.str("code", "코드", false),
+		Currency:  f.str("currency", "통화", true),
+		Top:       f.float("top", "최고가"),
+		Bottom:    f.float("bottom", "최저가"),
+		SellPrice: f.float("sell", "매도 기준"),
+		BuyPrice:  f.float("buy", "매수 기준"),
+	}
+	averages := f.averages("averages")
+	if f.err != nil {
+		return h.redirect(c, back, f.err)
+	}
+
+	id, err := h.svc.AddAsset(asset, averages)
+	if err != nil {
+		return h.redirect(c, back, fmt.Errorf("AddAsset 시 오류 발생. %w", err))
+	}
+	return h.redirect(c, fmt.Sprintf("%s/assets/%d", basePath, id), nil, "자산 추가 성공. "+asset.Name)
+}
+
+type assetData struct {
+	Asset      m.Asset
+	Last       *m.DailyPrice
+	Prices     []m.DailyPrice // 최근 일자 순
+	Chart      template.URL
+	Caption    string
+	ChartError string
+	Categories []m.Category
+}
+
+/*
+매도/매수 기준 대비 최근 종가 구간
+  - sell : 매도 기준 이상
+  - buy : 매수 기준 이하
+*/
+func (d assetData) Zone() string {
+	if d.Last == nil {
+		return ""
+	}
+	switch {
+	case d.Asset.SellPrice != 0 && d.Last.Close >= d.Asset.SellPrice:
+		return "sell"
+	case d.Asset.BuyPrice != 0 && d.Last.Close <= d.Asset.BuyPrice:
+		return "buy"
+	}
+	return ""
+}
+
+// 자산 정보, 매도/매수 기준, 최근 시세와 시세 차트
+func (h *DashboardHandler) Asset(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("자산 ID 오류. %s", err)
+	}
+
+	asset, err := h.s.RetrieveAsset(uint(id))
+	if err != nil {
+		return fmt.Errorf("RetrieveAsset 시 오류 발생. %w", err)
+	}
+
+	from := h.now().AddDate(0, 0, -priceDays).Format("2006-01-02")
+	prices, err := h.s.RetrieveDailyPrices(asset.ID, from, "")
+	if err != nil {
+		return fmt.Errorf("RetrieveDailyPrices 시 오류 발생. %w", err)
+	}
+	sort.SliceStable(prices, func(i, j int) bool {
+		return time.Time(prices[i].Date).After(time.Time(prices[j].Date))
+	})
+
+	data := assetData{Asset: *asset, Prices: prices, Categories: categories()}
+	if len(prices) > 0 {
+		data.Last = &prices[0]
+	}
+
+	ct, err := h.svc.PriceChart(asset.ID, chartDays)
+	if err != nil {
+		data.ChartError = err.Error()
+	} else {
+		data.Chart = template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(ct.Image))
+		data.Caption = ct.Caption
+	}
+
+	return h.render(c, "asset", asset.Name, "assets", data)
+}
+
+// 이름, 카테고리, 매도/매수 기준 등 갱신. 빈 값은 기존 값 유지
+func (h *DashboardHandler) UpdateAsset(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("자산 ID 오류. %s", err)
+	}
+	back := fmt.Sprintf("%s/assets/%d", basePath, id)
+
+	f := form{c: c}
+	name := f.str("name", "이름", false)
+	category := m.Category(f.uint("category", "카테고리", false))
+	code := f.str("code", "코드", false)
+	currency := f.str("currency", "통화", false)
+	top, bottom := f.float("top", "최고가"), f.float("bottom", "최저가")
+	sell, buy := f.float("sell", "매도 기준"), f.float("buy", "매수 기준")
+	if f.err != nil {
+		return h.redirect(c, back, f.err)
+	}
+
+	err = h.s.UpdateAssetInfo(uint(id), name, category, code, currency, top, bottom, sell, buy)
+	if err != nil {
+		return h.redirect(c, back, fmt.Errorf("UpdateAssetInfo 시 오류 발생. %w", err))
+	}
+	return h.redirect(c, back, nil, "자산 정보 갱신 성공")
+}
+
+type investsData struct {
+	Invests []m.Invest
+	Funds   []m.Fund
+	Assets  []m.Asset
+	Filter  investFilter
+	NextURL string
+}
+
+// 조회 조건. 화면 양식 값 유지용
+type investFilter struct {
+	Fund  uint
+	Asset uint
+	Side  string
+	From  string
+	To    string
+}
+
+/*
+투자 이력. 최근 이력부터 investLimit 건
+  - cursor : 다음 페이지. {RFC3339Nano 시각}_{ID}
+*/
+func (h *DashboardHandler) Invests(c *fiber.Ctx) error {
+
+	q, filter, err := h.investQuery(c)
+	if err != nil {
+		return err
+	}
+
+	invests, err := h.s.RetrieveInvests(q)
+	if err != nil {
+		return fmt.Errorf("RetrieveInvests 시 오류 발생. %w", err)
+	}
+
+	data := investsData{Filter: filter}
+	if len(invests) > investLimit {
+		invests = invests[:investLimit]
+		last := invests[len(invests)-1]
+
+		next := url.Values{}
+		for k, v := range c.Queries() {
+			if k != "cursor" && k != "ok" && k != "error" {
+				next.Set(k, v)
+			}
+		}
+		next.Set("cursor", fmt.Sprintf("%s_%d", last.CreatedAt.Format(time.RFC3339Nano), last.ID))
+		data.NextURL = basePath + "/invests?" + next.Encode()
+	}
+	data.Invests = invests
+
+	data.Funds, err = h.s.RetrieveFunds()
+	if err != nil {
+		return fmt.Errorf("RetrieveFunds 시 오류 발생. %w", err)
+	}
+	data.Assets, err = h.s.RetrieveAssetList()
+	if err != nil {
+		return fmt.Errorf("RetrieveAssetList 시 오류 발생. %w", err)
+	}
+
+	return h.render(c, "invests", "투자 이력", "invests", data)
+}
+
+func (h *DashboardHandler) investQuery(c *fiber.Ctx) (m.InvestQuery, investFilter, error) {
+
+	q := m.InvestQuery{Sort: m.SortCreatedAt, Desc: true, Limit: investLimit + 1}
+	var filter investFilter
+
+	for key, dst := range map[string]*uint{"fund": &q.FundID, "asset": &q.AssetID} {
+		if v := c.Query(key); v != "" {
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return q, filter, invalid("%s 조회 조건 오류. 양의 정수. 입력 값 : %s", key, v)
+			}
+			*dst = uint(n)
+		}
+	}
+	filter.Fund, filter.Asset = q.FundID, q.AssetID
+
+	var err error
+	q.Side, err = m.ToInvestSide(c.Query("side"))
+	if err != nil {
+		return q, filter, invalid("%w", err)
+	}
+	filter.Side = string(q.Side)
+
+	for _, v := range []struct {
+		key  string
+		dst  *time.Time
+		days int
+	}{{"from", &q.From, 0}, {"to", &q.To, 1}} {
+		s := c.Query(v.key)
+		if s == "" {
+			continue
+		}
+		t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+		if err != nil {
+			return q, filter, invalid("%s 조회 조건 오류. YYYY-MM-DD. 입력 값 : %s", v.key, s)
+		}
+		*v.dst = t.AddDate(0, 0, v.days) // to는 해당 일 포함
+	}
+	filter.From, filter.To = c.Query("from"), c.Query("to")
+
+	if s := c.Query("cursor"); s != "" {
+		i := strings.LastIndex(s, "_")
+		at, err := time.Parse(time.RFC3339Nano, s[:max(i, 0)])
+		id, idErr := strconv.ParseUint(s[i+1:], 10, 64)
+		if i < 0 || err != nil || idErr != nil {
+			return q, filter, invalid("cursor 형식 오류. 입력 값 : %s", s)
+		}
+		q.After = &m.InvestCursor{Value: at, ID: uint(id)}
+	}
+
+	return q, filter, nil
+}
+
+// 매수/매도 이력 저장. 자금 자산 요약, 현금 갱신
+func (h *DashboardHandler) RecordInvest(c *fiber.Ctx) error {
+
+	back := basePath + "/invests"
+	f := form{c: c}
+	fundId := f.uint("fund", "자금", true)
+	assetId := f.uint("asset", "자산", true)
+	price := f.float("price", "가격")
+	count := f.float("count", "수량")
+	side, err := m.ToInvestSide(c.FormValue("side"))
+	if f.err == nil && (err != nil || side == "") {
+		f.err = invalid("매수/매도 구분 오류. buy, sell")
+	}
+	if f.err == nil && (price <= 0 || count <= 0) {
+		f.err = invalid("가격과 수량은 0 초과")
+	}
+	if f.err != nil {
+		return h.redirect(c, back, f.err)
+	}
+
+	label := "매수"
+	if side == m.SellSide {
+		count, label = -count, "매도"
+	}
+	err = h.svc.RecordInvest(fundId, assetId, price, count)
+	if err != nil {
+		return h.redirect(c, back, fmt.Errorf("RecordInvest 시 오류 발생. %w", err))
+	}
+	return h.redirect(c, back, nil, label+" 이력 저장 성공")
+}
+
+type marketData struct {
+	Current *m.MarketLevel
+	Periods []marketPeriod
+	Levels  []m.MarketLevel
+}
+
+// 같은 시장 단계가 이어진 기간
+type marketPeriod struct {
+	From  time.Time
+	To    time.Time
+	Level m.MarketLevel
+	Days  int
+}
+
+// 시장 단계 이력. 최근 marketYears 년, 단계 변경 기준 기간별
+func (h *DashboardHandler) Market(c *fiber.Ctx) error {
+
+	from := h.now().AddDate(-marketYears, 0, 0).Format("2006-01-02")
+	markets, err := h.s.RetrieveMarketStatuses(from, "")
+	if err != nil {
+		return fmt.Errorf("RetrieveMarketStatuses 시 오류 발생. %w", err)
+	}
+
+	data := marketData{Periods: periods(markets), Levels: levels()}
+	if len(data.Periods) > 0 {
+		data.Current = &data.Periods[0].Level
+	}
+	return h.render(c, "market", "시장 단계", "market", data)
+}
+
+// 일자 순 시장 단계를 단계 변경 기간으로 묶음. 최근 기간부터
+func periods(markets []m.Market) []marketPeriod {
+
+	rtn := make([]marketPeriod, 0)
+	for _, mk := range markets {
+		at := time.Time(mk.CreatedAt)
+		level := m.MarketLevel(mk.Status)
+		if n := len(rtn); n > 0 && rtn[n-1].Level == level {
+			rtn[n-1].To = at
+			continue
+		}
+		rtn = append(rtn, marketPeriod{From: at, To: at, Level: level})
+	}
+
+	for i := range rtn {
+		rtn[i].Days = int(rtn[i].To.Sub(rtn[i].From).Hours()/24) + 1
+	}
+	for i, j := 0, len(rtn)-1; i < j; i, j = i+1, j-1 {
+		rtn[i], rtn[j] = rtn[j], rtn[i]
+	}
+	return rtn
+}
+
+func (h *DashboardHandler) SaveMarket(c *fiber.Ctx) error {
+
+	back := basePath + "/market"
+	f := form{c: c}
+	status := f.uint("status", "시장 단계", true)
+	if f.err == nil && (status < 1 || int(status) > len(levels())) {
+		f.err = invalid("시장 단계는 1~%d. 입력 값 : %d", len(levels()), status)
+	}
+	if f.err != nil {
+		return h.redirect(c, back, f.err)
+	}
+
+	err := h.s.SaveMarketStatus(status)
+	if err != nil {
+		return h.redirect(c, back, fmt.Errorf("SaveMarketStatus 시 오류 발생. %w", err))
+	}
+	return h.redirect(c, back, nil, "시장 단계 저장 성공. "+m.MarketLevel(status).String())
+}
+
+type alertsData struct {
+	Days   int
+	Alerts []m.AlertLog // 최근 순
+}
+
+// 최근 발송 알림. days 미입력 시 alertDays 일
+func (h *DashboardHandler) Alerts(c *fiber.Ctx) error {
+
+	days := c.QueryInt("days", alertDays)
+	if days <= 0 {
+		return invalid("days는 양의 정수. 입력 값 : %s", c.Query("days"))
+	}
+
+	now := h.now()
+	logs, err := h.s.RetrieveAlertLogs(now.AddDate(0, 0, -days), now)
+	if err != nil {
+		return fmt.Errorf("RetrieveAlertLogs 시 오류 발생. %w", err)
+	}
+	for i, j := 0, len(logs)-1; i < j; i, j = i+1, j-1 {
+		logs[i], logs[j] = logs[j], logs[i]
+	}
+
+	return h.render(c, "alerts", "최근 알림", "alerts", alertsData{Days: days, Alerts: logs})
+}
+
+/*
+양식 처리 후 화면 이동. 결과는 ok, error 쿼리로 전달
+  - 유효성 검사 실패(m.ErrInvalid), 중복(m.ErrConflict), 미존재(m.ErrNotFound)는 이동할 화면에 메시지 표시
+  - 그 외 오류는 오류 화면
+*/
+func (h *DashboardHandler) redirect(c *fiber.Ctx, path string, err error, ok ...string) error {
+
+	q := url.Values{}
+	if err != nil {
+		if !errors.Is(err, m.ErrInvalid) && !errors.Is(err, m.ErrConflict) && !errors.Is(err, m.ErrNotFound) {
+			return err
+		}
+		q.Set("error", err.Error())
+	} else if len(ok) > 0 {
+		q.Set("ok", ok[0])
+	}
+
+	if len(q) == 0 {
+		return c.Redirect(path, fiber.StatusSeeOther)
+	}
+	return c.Redirect(path+"?"+q.Encode(), fiber.StatusSeeOther)
+}
+
+// 입력 값 오류. 메시지는 그대로 표시하고 m.ErrInvalid로 구분
+func invalid(format string, args ...any) error {
+	return invalidError{fmt.Errorf(format, args...)}
+}
+
+type invalidError struct {
+	err error
+}
+
+func (e invalidError) Error() string   { return e.err.Error() }
+func (e invalidError) Unwrap() []error { return []error{m.ErrInvalid, e.err} }
+
+func categories() []m.Category {
+	rtn := make([]m.Category, m.CategoryLength())
+	for i := range rtn {
+		rtn[i] = m.Category(i + 1)
+	}
+	return rtn
+}
+
+func levels() []m.MarketLevel {
+	return []m.MarketLevel{m.MAJOR_BEAR, m.BEAR, m.VOLATILIY, m.BULL, m.MAJOR_BULL}
+}
@@ -0,0 +1,297 @@
+package dashboard
+
+import (
+	"errors"
+	"invest/app/middleware"
+	"invest/auth"
+	m "invest/model"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestDashboard(t *testing.T) {
+
+	secret := []byte("secret")
+	now := time.Now()
+	read, _ := auth.Issue(secret, "viewer", m.ReadScope, time.Hour, now)
+	write, _ := auth.Issue(secret, "owner", m.WriteScope, time.Hour, now)
+
+	setup := func(s *StorageMock, svc *ServiceMock) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: middleware.ErrorHandler})
+		NewDashboardHandler(s, svc, middleware.NewAuthenticator(nil, secret)).InitRoute(app)
+		return app
+	}
+
+	request := func(app *fiber.App, method string, path string, token string, form url.Values) (*http.Response, string) {
+		var body io.Reader
+		if form != nil {
+			body = strings.NewReader(form.Encode())
+		}
+		req := httptest.NewRequest(method, path, body)
+		if form != nil {
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationForm)
+		}
+		if token != "" {
+			req.AddCookie(&http.Cookie{Name: middleware.CookieName, Value: token})
+		}
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		return resp, string(b)
+	}
+
+	t.Run("미인증 시 로그인 화면으로 이동", func(t *testing.T) {
+		app := setup(&StorageMock{}, &ServiceMock{})
+
+		resp, _ := request(app, http.MethodGet, "/dashboard/assets", "", nil)
+		assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/dashboard/login", resp.Header.Get(fiber.HeaderLocation))
+
+		resp, body := request(app, http.MethodGet, "/dashboard/login", "", nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, body, `action="/dashboard/login"`)
+	})
+
+	t.Run("로그인", func(t *testing.T) {
+		app := setup(&StorageMock{}, &ServiceMock{})
+
+		resp, _ := request(app, http.MethodPost, "/dashboard/login", "", url.Values{"key": {write}})
+		assert.Equal(t, fiber.StatusFound, resp.StatusCode)
+		assert.Equal(t, "/dashboard", resp.Header.Get(fiber.HeaderLocation))
+
+		cookie := resp.Header.Get(fiber.HeaderSetCookie)
+		assert.Contains(t, cookie, middleware.CookieName+"="+write)
+		assert.Contains(t, strings.ToLower(cookie), "httponly")
+		assert.Contains(t, strings.ToLower(cookie), "samesite=strict")
+
+		expired, _ := auth.Issue(secret, "owner", m.WriteScope, time.Minute, now.Add(-time.Hour))
+		resp, body := request(app, http.MethodPost, "/dashboard/login", "", url.Values{"key": {expired}})
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+		assert.Empty(t, resp.Header.Get(fiber.HeaderSetCookie))
+		assert.Contains(t, body, `class="flash error"`)
+	})
+
+	t.Run("자금 현황", func(t *testing.T) {
+		s := &StorageMock{
+			funds:   []m.Fund{{ID: 1, Name: "개인"}, {ID: 2, Name: "<비상금>"}},
+			markets: []m.Market{{Status: uint(m.VOLATILIY)}},
+		}
+		app := setup(s, &ServiceMock{})
+
+		resp, body := request(app, http.MethodGet, "/dashboard", write, nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), "text/html")
+		assert.Contains(t, body, "VOLATILIY(3)")
+		assert.Contains(t, body, "10,000,000원")
+		assert.Contains(t, body, "변동 자산 비율 <strong>40.0%</strong> / 허용 범위 30.0% ~ 35.0%")
+		assert.Contains(t, body, `<span class="sell">초과</span>`)
+		assert.Contains(t, body, `style="left: 30.0%; width: 5.0%"`)
+		assert.Contains(t, body, "&lt;비상금&gt;") // 평가 금액 없는 자금, escape
+		assert.Contains(t, body, `action="/dashboard/funds"`)
+		assert.NotContains(t, body, "http://")
+		assert.NotContains(t, body, "https://")
+
+		resp, body = request(app, http.MethodGet, "/dashboard", read, nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.NotContains(t, body, `action="/dashboard/funds"`) // read 권한은 양식 미표시
+	})
+
+	t.Run("시장 단계 미저장", func(t *testing.T) {
+		app := setup(&StorageMock{funds: []m.Fund{{ID: 1, Name: "개인"}}}, &ServiceMock{err: errors.New("호출되지 않아야 함")})
+
+		resp, body := request(app, http.MethodGet, "/dashboard", read, nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, body, "미저장")
+	})
+
+	t.Run("read 권한 양식 요청", func(t *testing.T) {
+		s := &StorageMock{}
+		app := setup(s, &ServiceMock{})
+
+		resp, body := request(app, http.MethodPost, "/dashboard/funds", read, url.Values{"name": {"개인"}})
+		assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+		assert.Contains(t, body, middleware.CodeForbidden)
+		assert.Empty(t, s.saved)
+	})
+
+	t.Run("자금 추가", func(t *testing.T) {
+		s := &StorageMock{}
+		app := setup(s, &ServiceMock{})
+
+		resp, _ := request(app, http.MethodPost, "/dashboard/funds", write, url.Values{"name": {"개인"}})
+		assert.Equal(t, fiber.StatusSeeOther, resp.StatusCode)
+		assert.Equal(t, "/dashboard?ok="+url.QueryEscape("자금 추가 성공. 개인"), resp.Header.Get(fiber.HeaderLocation))
+		assert.Equal(t, []string{"fund:개인"}, s.saved)
+
+		resp, _ = request(app, http.MethodPost, "/dashboard/funds", write, url.Values{"name": {"중복"}})
+		assert.Equal(t, fiber.StatusSeeOther, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderLocation), "/dashboard?error=")
+
+		s.err = errors.New("DB 오류")
+		resp, body := request(app, http.MethodPost, "/dashboard/funds", write, url.Values{"name": {"가족"}})
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+		assert.Contains(t, body, "DB 오류")
+	})
+
+	t.Run("자산 추가", func(t *testing.T) {
+		svc := &ServiceMock{}
+		app := setup(&StorageMock{}, svc)
+
+		form := url.Values{"name": {"애플"}, "category": {"8"}, "currency": {"USD"}, "sell": {"1,200.5"}, "averages": {"EMA20, EMA200"}}
+		resp, _ := request(app, http.MethodPost, "/dashboard/assets", write, form)
+		assert.Equal(t, fiber.StatusSeeOther, resp.StatusCode)
+		assert.True(t, strings.HasPrefix(resp.Header.Get(fiber.HeaderLocation), "/dashboard/assets/3?ok="))
+		assert.Equal(t, []m.AssetAverage{{Kind: m.EMA, Period: 20}, {Kind: m.EMA, Period: 200, Reference: true}}, svc.averages)
+
+		form.Set("sell", "abc")
+		resp, _ = request(app, http.MethodPost, "/dashboard/assets", write, form)
+		loc, _ := url.Parse(resp.Header.Get(fiber.HeaderLocation))
+		assert.Equal(t, "/dashboard/assets", loc.Path)
+		assert.Equal(t, "매도 기준 형식 오류. 숫자. 입력 값 : abc", loc.Query().Get("error"))
+
+		resp, body := request(app, http.MethodGet, loc.String(), write, nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, body, "매도 기준 형식 오류. 숫자. 입력 값 : abc")
+	})
+
+	t.Run("자산 상세", func(t *testing.T) {
+		svc := &ServiceMock{}
+		app := setup(&StorageMock{}, svc)
+
+		resp, body := request(app, http.MethodGet, "/dashboard/assets/1", read, nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, body, `src="data:image/png;base64,cG5n"`)
+		assert.Contains(t, body, "삼성전자 120일 시세")
+		assert.Contains(t, body, "59,500 <span class=\"muted\">(2024-12-03)</span>")
+		assert.Contains(t, body, "매수 기준 이하")
+		assert.Less(t, strings.Index(body, "2024-12-03"), strings.Index(body, "2024-12-02")) // 최근 일자 순
+
+		svc.chartErr = errors.New("시세 미존재")
+		_, body = request(app, http.MethodGet, "/dashboard/assets/1", read, nil)
+		assert.Contains(t, body, "차트 작성 실패. 시세 미존재")
+
+		resp, body = request(app, http.MethodGet, "/dashboard/assets/9", read, nil)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+		assert.Contains(t, body, middleware.CodeNotFound)
+	})
+
+	t.Run("투자 이력", func(t *testing.T) {
+		invests := make([]m.Invest, investLimit+1)
+		for i := range invests {
+			invests[i] = m.Invest{ID: uint(100 - i), Fund: m.Fund{Name: "개인"}, AssetID: 1, Asset: m.Asset{Name: "삼성전자"}, Price: 70000, Count: -2}
+			invests[i].CreatedAt = time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+		}
+		s := &StorageMock{invests: invests}
+		app := setup(s, &ServiceMock{})
+
+		resp, body := request(app, http.MethodGet, "/dashboard/invests?fund=1&side=sell", read, nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, uint(1), s.query.FundID)
+		assert.Equal(t, m.SellSide, s.query.Side)
+		assert.Equal(t, investLimit+1, s.query.Limit)
+		assert.Contains(t, body, "-140,000")
+		assert.Equal(t, investLimit, strings.Count(body, `<span class="sell">매도</span>`))
+		assert.Contains(t, body, "cursor=2024-12-01T09%3A00%3A00Z_51")
+
+		_, _ = request(app, http.MethodGet, "/dashboard/invests?cursor=2024-12-01T09:00:00Z_51", read, nil)
+		assert.Equal(t, &m.InvestCursor{Value: time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC), ID: 51}, s.query.After)
+
+		resp, _ = request(app, http.MethodGet, "/dashboard/invests?cursor=abc", read, nil)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("매수/매도 기록", func(t *testing.T) {
+		svc := &ServiceMock{}
+		app := setup(&StorageMock{}, svc)
+
+		form := url.Values{"fund": {"1"}, "asset": {"2"}, "side": {"sell"}, "price": {"70,000"}, "count": {"3"}}
+		resp, _ := request(app, http.MethodPost, "/dashboard/invests", write, form)
+		assert.Equal(t, fiber.StatusSeeOther, resp.StatusCode)
+		assert.Equal(t, []float64{1, 2, 70000, -3}, svc.invest) // 매도는 음수 수량
+
+		form.Set("side", "buy")
+		request(app, http.MethodPost, "/dashboard/invests", write, form)
+		assert.Equal(t, []float64{1, 2, 70000, 3}, svc.invest)
+
+		form.Set("count", "0")
+		resp, _ = request(app, http.MethodPost, "/dashboard/invests", write, form)
+		loc, _ := url.Parse(resp.Header.Get(fiber.HeaderLocation))
+		assert.Equal(t, "가격과 수량은 0 초과", loc.Query().Get("error"))
+	})
+
+	t.Run("시장 단계", func(t *testing.T) {
+		day := func(d int) datatypes.Date { return datatypes.Date(time.Date(2024, 12, d, 0, 0, 0, 0, time.Local)) }
+		s := &StorageMock{markets: []m.Market{{CreatedAt: day(1), Status: 2}, {CreatedAt: day(5), Status: 4}}}
+		app := setup(s, &ServiceMock{})
+
+		resp, body := request(app, http.MethodGet, "/dashboard/market", write, nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, body, "현재 시장 단계 : <strong>BULL(4)</strong>")
+		assert.Contains(t, body, `<option value="4" selected>`)
+
+		resp, _ = request(app, http.MethodPost, "/dashboard/market", write, url.Values{"status": {"6"}})
+		loc, _ := url.Parse(resp.Header.Get(fiber.HeaderLocation))
+		assert.Equal(t, "시장 단계는 1~5. 입력 값 : 6", loc.Query().Get("error"))
+
+		request(app, http.MethodPost, "/dashboard/market", write, url.Values{"status": {"1"}})
+		assert.Equal(t, []string{"market:MAJOR_BEAR"}, s.saved)
+	})
+
+	t.Run("최근 알림", func(t *testing.T) {
+		s := &StorageMock{alerts: []m.AlertLog{
+			{ID: 1, Source: m.PriceSource, Message: "첫 알림", CreatedAt: time.Date(2024, 12, 1, 9, 0, 0, 0, time.Local)},
+			{ID: 2, Source: m.PriceSource, Message: "<b>둘째</b>", CreatedAt: time.Date(2024, 12, 2, 9, 0, 0, 0, time.Local)},
+		}}
+		app := setup(s, &ServiceMock{})
+
+		resp, body := request(app, http.MethodGet, "/dashboard/alerts?days=3", read, nil)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Less(t, strings.Index(body, "둘째"), strings.Index(body, "첫 알림"))
+		assert.Contains(t, body, "&lt;b&gt;둘째&lt;/b&gt;")
+
+		resp, _ = request(app, http.MethodGet, "/dashboard/alerts?days=0", read, nil)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestPeriods(t *testing.T) {
+
+	day := func(d int) datatypes.Date { return datatypes.Date(time.Date(2024, 12, d, 0, 0, 0, 0, time.Local)) }
+	at := func(d int) time.Time { return time.Time(day(d)) }
+
+	t.Run("단계 변경 기준 기간", func(t *testing.T) {
+		markets := []m.Market{
+			{CreatedAt: day(1), Status: 3},
+			{CreatedAt: day(2), Status: 3},
+			{CreatedAt: day(4), Status: 3},
+			{CreatedAt: day(5), Status: 5},
+			{CreatedAt: day(6), Status: 3},
+		}
+		assert.Equal(t, []marketPeriod{
+			{From: at(6), To: at(6), Level: m.VOLATILIY, Days: 1},
+			{From: at(5), To: at(5), Level: m.MAJOR_BULL, Days: 1},
+			{From: at(1), To: at(4), Level: m.VOLATILIY, Days: 4},
+		}, periods(markets))
+	})
+
+	t.Run("이력 미존재", func(t *testing.T) {
+		assert.Empty(t, periods(nil))
+	})
+}
+
+func TestNum(t *testing.T) {
+	assert.Equal(t, "0", num(0))
+	assert.Equal(t, "1,234,567", num(1234567))
+	assert.Equal(t, "-1,000.50", num(-1000.5))
+	assert.Equal(t, "999.12", num(999.123))
+}
@@ -0,0 +1,161 @@
+package dashboard
+
+import (
+	"errors"
+	m "invest/model"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+/***************************** Storage ***********************************/
+type StorageMock struct {
+	err     error
+	funds   []m.Fund
+	markets []m.Market
+	alerts  []m.AlertLog
+	invests []m.Invest
+
+	query *m.InvestQuery
+	saved []string
+}
+
+func (mock *StorageMock) RetrieveFunds() ([]m.Fund, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return mock.funds, nil
+}
+
+func (mock *StorageMock) RetrieveAssetList() ([]m.Asset, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return []m.Asset{
+		{ID: 1, Name: "삼성전자", Category: m.DomesticStock, Code: "005930", Currency: "WON", SellPrice: 90000, BuyPrice: 60000},
+		{ID: 2, Name: "S&P500", Category: m.ForeignETF, Code: "SPY", Currency: "USD"},
+	}, nil
+}
+
+func (mock *StorageMock) RetrieveAsset(id uint) (*m.Asset, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	if id != 1 {
+		return nil, m.ErrNotFound
+	}
+	return &m.Asset{ID: 1, Name: "삼성전자", Category: m.DomesticStock, Code: "005930", Currency: "WON", SellPrice: 90000, BuyPrice: 60000}, nil
+}
+
+func (mock *StorageMock) RetrieveDailyPrices(assetId uint, from string, to string) ([]m.DailyPrice, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	day := func(d int) datatypes.Date {
+		return datatypes.Date(time.Date(2024, 12, d, 0, 0, 0, 0, time.Local))
+	}
+	return []m.DailyPrice{
+		{AssetID: assetId, Date: day(2), Close: 61000},
+		{AssetID: assetId, Date: day(3), Close: 59500},
+	}, nil
+}
+
+func (mock *StorageMock) RetrieveInvests(q m.InvestQuery) ([]m.Invest, error) {
+	mock.query = &q
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return mock.invests, nil
+}
+
+func (mock *StorageMock) RetrieveMarketStatus(date string) (*m.Market, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	if len(mock.markets) == 0 {
+		return nil, m.ErrNotFound
+	}
+	market := mock.markets[len(mock.markets)-1]
+	return &market, nil
+}
+
+func (mock *StorageMock) RetrieveMarketStatuses(from string, to string) ([]m.Market, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return mock.markets, nil
+}
+
+func (mock *StorageMock) RetrieveAlertLogs(from time.Time, to time.Time) ([]m.AlertLog, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return mock.alerts, nil
+}
+
+func (mock *StorageMock) SaveFund(name string) error {
+	if name == "중복" {
+		return errors.Join(m.ErrConflict, errors.New("이미 존재하는 자금. 중복"))
+	}
+	mock.saved = append(mock.saved, "fund:"+name)
+	return mock.err
+}
+
+func (mock *StorageMock) SaveMarketStatus(status uint) error {
+	mock.saved = append(mock.saved, "market:"+m.MarketLevel(status).String())
+	return mock.err
+}
+
+func (mock *StorageMock) UpdateAssetInfo(id uint, name string, category m.Category, code string, currency string, top float64, bottom float64, selPrice float64, buyPrice float64) error {
+	mock.saved = append(mock.saved, "asset:"+name)
+	return mock.err
+}
+
+/***************************** Service ***********************************/
+type ServiceMock struct {
+	err      error
+	chartErr error
+
+	invest   []float64 // fundId, assetId, price, count
+	averages []m.AssetAverage
+}
+
+func (mock *ServiceMock) Allocations() ([]m.Allocation, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return []m.Allocation{
+		{
+			Fund:     m.Fund{ID: 1, Name: "개인"},
+			Market:   m.VOLATILIY,
+			Stable:   6000000,
+			Volatile: 4000000,
+			Min:      0.3,
+			Max:      0.35,
+			Assets: []m.AssetAllocation{
+				{Asset: m.Asset{ID: 3, Name: "현금", Category: m.Won}, Count: 6000000, Value: 6000000},
+				{Asset: m.Asset{ID: 1, Name: "삼성전자", Category: m.DomesticStock}, Count: 50, Value: 4000000},
+			},
+		},
+	}, nil
+}
+
+func (mock *ServiceMock) PriceChart(assetId uint, days int) (*m.Chart, error) {
+	if mock.chartErr != nil {
+		return nil, mock.chartErr
+	}
+	return &m.Chart{Image: []byte("png"), Caption: "삼성전자 120일 시세"}, nil
+}
+
+func (mock *ServiceMock) RecordInvest(fundId uint, assetId uint, price float64, count float64) error {
+	mock.invest = []float64{float64(fundId), float64(assetId), price, count}
+	return mock.err
+}
+
+func (mock *ServiceMock) AddAsset(asset m.Asset, averages []m.AssetAverage) (uint, error) {
+	mock.averages = averages
+	if mock.err != nil {
+		return 0, mock.err
+	}
+	return 3, nil
+}
@@ -0,0 +1,209 @@
+package dashboard
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"invest/app/middleware"
+	m "invest/model"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/datatypes"
+)
+
+//go:embed templates
+var files embed.FS
+
+// 로그인 실패 등 쿼리 외 오류 메시지
+const errorKey = "dashboardError"
+
+var pages = parsePages("overview", "assets", "asset", "invests", "market", "alerts", "login", "error")
+
+// 화면별 템플릿. 공통 레이아웃(layout.html)과 화면 파일(content define)
+func parsePages(names ...string) map[string]*template.Template {
+	rtn := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		rtn[name] = template.Must(template.New(name).Funcs(funcs).ParseFS(files, "templates/layout.html", "templates/"+name+".html"))
+	}
+	return rtn
+}
+
+/*
+화면 공통 값
+  - CanWrite : 저장/변경 양식 표시 여부
+  - OK, Error : 양식 처리 결과 메시지
+*/
+type page struct {
+	Title     string
+	Nav       string
+	Principal *middleware.Principal
+	CanWrite  bool
+	OK        string
+	Error     string
+	Data      any
+}
+
+func (h *DashboardHandler) render(c *fiber.Ctx, name string, title string, nav string, data any) error {
+
+	p := page{
+		Title:     title,
+		Nav:       nav,
+		Principal: middleware.PrincipalOf(c),
+		OK:        c.Query("ok"),
+		Error:     c.Query("error"),
+		Data:      data,
+	}
+	if msg, ok := c.Locals(errorKey).(string); ok {
+		p.Error = msg
+	}
+	p.CanWrite = p.Principal != nil && p.Principal.Scope.Allows(m.WriteScope)
+
+	var buf bytes.Buffer
+	err := pages[name].ExecuteTemplate(&buf, "layout", p)
+	if err != nil {
+		return fmt.Errorf("%s 화면 작성 시 오류 발생. %w", name, err)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return c.Send(buf.Bytes())
+}
+
+var funcs = template.FuncMap{
+	"num":   num,
+	"pct":   func(v float64) string { return strconv.FormatFloat(v*100, 'f', 1, 64) + "%" },
+	"date":  date,
+	"level": func(v any) string { return levelName(v) },
+	"sub":   func(a, b float64) float64 { return a - b },
+	"mul":   func(a, b float64) float64 { return a * b },
+	"bar":   func(l m.MarketLevel) string { return strconv.Itoa(int(l)*20) + "px" }, // 시장 단계 막대 길이
+}
+
+// 천 단위 구분. 소수점 이하는 있을 때만 2자리
+func num(v float64) string {
+
+	s := strconv.FormatFloat(math.Abs(v), 'f', 2, 64)
+	s = strings.TrimSuffix(s, ".00")
+	intPart, frac, _ := strings.Cut(s, ".")
+
+	var sb strings.Builder
+	if v < 0 {
+		sb.WriteByte('-')
+	}
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(r)
+	}
+	if frac != "" {
+		sb.WriteString("." + frac)
+	}
+	return sb.String()
+}
+
+func date(layout string, v any) string {
+	switch d := v.(type) {
+	case time.Time:
+		return d.Format(layout)
+	case datatypes.Date:
+		return time.Time(d).Format(layout)
+	}
+	return ""
+}
+
+func levelName(v any) string {
+	var level m.MarketLevel
+	switch l := v.(type) {
+	case m.MarketLevel:
+		level = l
+	case *m.MarketLevel:
+		if l == nil {
+			return ""
+		}
+		level = *l
+	case uint:
+		level = m.MarketLevel(l)
+	}
+	if level < m.MAJOR_BEAR || level > m.MAJOR_BULL {
+		return ""
+	}
+	return fmt.Sprintf("%s(%d)", level, level)
+}
+
+/*
+양식 값 조회. 첫 오류만 보관
+  - 숫자는 쉼표 허용. ex) 12,500
+*/
+type form struct {
+	c   *fiber.Ctx
+	err error
+}
+
+func (f *form) str(key string, label string, required bool) string {
+	v := strings.TrimSpace(f.c.FormValue(key))
+	if v == "" && required && f.err == nil {
+		f.err = invalid("%s 필수", label)
+	}
+	return v
+}
+
+func (f *form) uint(key string, label string, required bool) uint {
+	v := f.str(key, label, required)
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.ParseUint(strings.ReplaceAll(v, ",", ""), 10, 64)
+	if err != nil && f.err == nil {
+		f.err = invalid("%s 형식 오류. 양의 정수. 입력 값 : %s", label, v)
+	}
+	return uint(n)
+}
+
+func (f *form) float(key string, label string) float64 {
+	v := f.str(key, label, false)
+	if v == "" {
+		return 0
+	}
+	n, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", ""), 64)
+	if err != nil && f.err == nil {
+		f.err = invalid("%s 형식 오류. 숫자. 입력 값 : %s", label, v)
+	}
+	return n
+}
+
+// 쉼표 구분 이동평균. 기본 이동평균(m.DefaultAverage) 포함 시 기준, 미포함 시 첫 번째가 기준
+func (f *form) averages(key string) []m.AssetAverage {
+
+	v := f.str(key, "이동평균", false)
+	if v == "" {
+		return nil
+	}
+
+	rtn := make([]m.AssetAverage, 0)
+	seen := make(map[m.AverageSpec]bool)
+	hasRef := false
+	for _, s := range strings.Split(v, ",") {
+		spec, err := m.ToAverageSpec(strings.TrimSpace(s))
+		if err != nil {
+			if f.err == nil {
+				f.err = invalid("%w", err)
+			}
+			return nil
+		}
+		if seen[spec] {
+			continue
+		}
+		seen[spec] = true
+		hasRef = hasRef || spec == m.DefaultAverage
+		rtn = append(rtn, m.AssetAverage{Kind: spec.Kind, Period: spec.Period, Reference: spec == m.DefaultAverage})
+	}
+	if !hasRef {
+		rtn[0].Reference = true
+	}
+	return rtn
+}
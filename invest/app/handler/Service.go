@@ -12,6 +12,11 @@ type FundRetriever interface {
 
 type FundWriter interface {
 	SaveFund(name string) error
+	SaveScoreWeights(fundId uint, weights []m.ScoreWeight) error
+}
+
+type PriorityRanker interface {
+	FundPriorities(fundId uint, buy bool) ([]m.AssetPriority, error)
 }
 
 type AssetRetriever interface {
@@ -20,37 +25,71 @@ type AssetRetriever interface {
 	RetrieveAssetHist(id uint) ([]m.Invest, error)
 	RetrieveAssetIdByName(name string) uint
 	RetrieveAssetIdByCode(code string) uint
+	RetrieveDailyPrices(assetId uint, from string, to string) ([]m.DailyPrice, error)
+	RetrieveAssetAverages(assetId uint) ([]m.AssetAverage, error)
+	RetrieveLatestAverage(assetId uint, spec m.AverageSpec) (*m.AverageHist, error)
 }
 
 type AssetInfoSaver interface {
-	SaveAssetInfo(name string, category m.Category, code string, currency string, top float64, bottom float64, selPrice float64, buyPrice float64) (uint, error)
 	UpdateAssetInfo(id uint, name string, category m.Category, code string, currency string, top float64, bottom float64, selPrice float64, buyPrice float64) error
 	DeleteAssetInfo(id uint) error
-	SaveEmaHist(assetId uint, price float64) error
+	SaveAssetAverages(assetId uint, averages []m.AssetAverage) error
 }
 
-type TopBottomPriceGetter interface {
-	TopBottomPrice(category m.Category, code string) (float64, float64, error)
+type AssetRegistrar interface {
+	AddAsset(asset m.Asset, averages []m.AssetAverage) (uint, error)
+	RecomputeAverages(assetId uint) error
 }
 
 type MaketRetriever interface {
 	RetrieveMarketStatus(date string) (*m.Market, error)
-	RetrieveMarketIndicator(date string) (*m.DailyIndex, *m.CliIndex, error)
+	RetrieveMarketIndicator(date string) ([]m.IndicatorChange, *m.CliIndex, error)
 }
 
 type MarketSaver interface {
 	SaveMarketStatus(status uint) error
 }
 
+type IndicatorRetriever interface {
+	RetrieveIndicators() ([]m.Indicator, error)
+	RetrieveIndicator(id uint) (*m.Indicator, error)
+	RetrieveIndicatorValues(id uint, from string, to string) ([]m.IndicatorValue, error)
+	RetrieveIndicatorAlerts(indicatorId uint) ([]m.IndicatorAlert, error)
+}
+
+type IndicatorSaver interface {
+	SaveIndicator(indicator m.Indicator) (uint, error)
+	UpdateIndicator(indicator m.Indicator) error
+	DeleteIndicator(id uint) error
+	SaveIndicatorAlert(alert m.IndicatorAlert) (uint, error)
+	UpdateIndicatorAlert(alert m.IndicatorAlert) error
+	DeleteIndicatorAlert(indicatorId uint, id uint) error
+}
+
 type InvestRetriever interface {
-	RetrieveInvestHist(fundId uint, assetId uint, start string, end string) ([]m.Invest, error)
+	RetrieveInvests(q m.InvestQuery) ([]m.Invest, error)
 }
 
-type InvestSaver interface {
-	SaveInvest(fundId uint, assetId uint, price float64, count float64) error
-	UpdateInvestSummary(fundId uint, assetId uint, change float64, price float64) error
+type InvestRecorder interface {
+	RecordInvest(fundId uint, assetId uint, price float64, count float64) error
 }
 
 type ExchageRateGetter interface {
 	ExchageRate() float64
 }
+
+type MonitorRetriever interface {
+	RetrieveMonitors() ([]m.Monitor, error)
+	RetrieveMonitor(id uint) (*m.Monitor, error)
+	RetrieveMonitorHist(id uint, limit int) ([]m.MonitorHist, error)
+}
+
+type MonitorSaver interface {
+	SaveMonitor(monitor m.Monitor) (uint, error)
+	UpdateMonitor(monitor m.Monitor) error
+	DeleteMonitor(id uint) error
+}
+
+type MonitorChecker interface {
+	CheckMonitor(id uint) (*m.MonitorHist, string, error)
+}
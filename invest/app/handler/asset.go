@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	m "invest/model"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -10,14 +11,14 @@ import (
 type AssetHandler struct {
 	r AssetRetriever
 	w AssetInfoSaver
-	p TopBottomPriceGetter
+	a AssetRegistrar
 }
 
-func NewAssetHandler(r AssetRetriever, w AssetInfoSaver, p TopBottomPriceGetter) *AssetHandler {
+func NewAssetHandler(r AssetRetriever, w AssetInfoSaver, a AssetRegistrar) *AssetHandler {
 	return &AssetHandler{
 		r: r,
 		w: w,
-		p: p,
+		a: a,
 	}
 }
 
@@ -31,6 +32,10 @@ func (h *AssetHandler) InitRoute(app *fiber.App) {
 	router.Get("/list", h.AssetList)
 	router.Get("/:id<\\d+>", h.Asset)
 	router.Get("/:id<\\d+>/hist", h.AssetHist)
+	router.Get("/:id<\\d+>/prices", h.AssetPrices)
+	router.Get("/:id<\\d+>/averages", h.AssetAverages)
+	router.Post("/:id<\\d+>/averages", h.SaveAssetAverages)
+	router.Post("/:id<\\d+>/averages/recompute", h.RecomputeAverages)
 }
 
 func (h *AssetHandler) AddAsset(c *fiber.Ctx) error {
@@ -38,40 +43,31 @@ func (h *AssetHandler) AddAsset(c *fiber.Ctx) error {
 	var param AddAssetReq
 	err := c.BodyParser(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 BodyParse 시 오류 발생. %w", err)
+		return invalid("파라미터 BodyParse 시 오류 발생. %w", err)
 	}
 
 	err = validCheck(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. %w", err)
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
 	}
 
-	top, bottom := param.Top, param.Bottom
-	if top == 0 || bottom == 0 {
-		_top, _bottom, err := h.p.TopBottomPrice(m.Category(param.Category), param.Code)
-		if err != nil {
-			return fmt.Errorf("TopBottomPrice 시 오류 발생. %w", err)
-		}
-		if top == 0 {
-			top = _top
-		}
-		if bottom == 0 {
-			bottom = _bottom
-		}
-	}
-
-	if param.BuyPrice == 0 {
-		param.BuyPrice = bottom
-	}
-
-	id, err := h.w.SaveAssetInfo(param.Name, m.Category(param.Category), param.Code, param.Currency, top, bottom, param.SellPrice, param.BuyPrice)
+	averages, err := toAssetAverages(param.Averages, "")
 	if err != nil {
-		return fmt.Errorf("SaveAssetInfo 시 오류 발생. %w", err)
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
 	}
 
-	err = h.w.SaveEmaHist(id, param.Ema)
+	_, err = h.a.AddAsset(m.Asset{
+		Name:      param.Name,
+		Category:  m.Category(param.Category),
+		Code:      param.Code,
+		Currency:  param.Currency,
+		Top:       param.Top,
+		Bottom:    param.Bottom,
+		SellPrice: param.SellPrice,
+		BuyPrice:  param.BuyPrice,
+	}, averages)
 	if err != nil {
-		return fmt.Errorf("SaveEmaHist 시 오류 발생. %w", err)
+		return fmt.Errorf("AddAsset 시 오류 발생. %w", err)
 	}
 
 	return c.Status(fiber.StatusOK).SendString("자산 정보 저장 성공")
@@ -82,12 +78,12 @@ func (h *AssetHandler) UpdateAsset(c *fiber.Ctx) error {
 	var param UpdateAssetReq
 	err := c.BodyParser(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 BodyParse 시 오류 발생. %w", err)
+		return invalid("파라미터 BodyParse 시 오류 발생. %w", err)
 	}
 
 	err = validCheck(&param) // 포인터로 들어가도 validation 체크 되는지 확인
 	if err != nil {
-		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. %w", err)
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
 	}
 
 	err = h.w.UpdateAssetInfo(param.ID, param.Name, m.Category(param.Category), param.Code, param.Currency, param.Top, param.Bottom, param.SellPrice, param.BuyPrice)
@@ -103,12 +99,12 @@ func (h *AssetHandler) DeleteAsset(c *fiber.Ctx) error {
 	var param DeleteAssetReq
 	err := c.BodyParser(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 BodyParse 시 오류 발생. %w", err)
+		return invalid("파라미터 BodyParse 시 오류 발생. %w", err)
 	}
 
 	err = validCheck(&param) // 포인터로 들어가도 validation 체크 되는지 확인
 	if err != nil {
-		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. %w", err)
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
 	}
 
 	err = h.w.DeleteAssetInfo(param.ID)
@@ -123,7 +119,7 @@ func (h *AssetHandler) Asset(c *fiber.Ctx) error {
 
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return fmt.Errorf("파라미터 id 조회 시 오류 발생. %w", err)
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
 	}
 
 	asset, err := h.r.RetrieveAsset(uint(id))
@@ -163,7 +159,7 @@ func (h *AssetHandler) AssetList(c *fiber.Ctx) error {
 func (h *AssetHandler) AssetHist(c *fiber.Ctx) error {
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return fmt.Errorf("파라미터 id 조회 시 오류 발생. %w", err)
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
 	}
 
 	hist, err := h.r.RetrieveAssetHist(uint(id))
@@ -186,3 +182,165 @@ func (h *AssetHandler) AssetHist(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(resp)
 
 }
+
+// 종목 일별 시세. from, to 미입력 시 전체 기간
+func (h *AssetHandler) AssetPrices(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	from, to := c.Query("from"), c.Query("to")
+	if !dateCheck(from) || !dateCheck(to) {
+		return invalid("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 date 포맷. %s ~ %s", from, to)
+	}
+
+	prices, err := h.r.RetrieveDailyPrices(uint(id), from, to)
+	if err != nil {
+		return fmt.Errorf("RetrieveDailyPrices 오류 발생. %w", err)
+	}
+
+	resp := make([]priceResponse, len(prices))
+	for i, p := range prices {
+		resp[i] = priceResponse{
+			Date:   time.Time(p.Date).Format("2006-01-02"),
+			Open:   p.Open,
+			High:   p.High,
+			Low:    p.Low,
+			Close:  p.Close,
+			Volume: p.Volume,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// 종목 이동평균 설정 및 최근 값. 설정이 없으면 기본 이동평균
+func (h *AssetHandler) AssetAverages(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	averages, err := h.r.RetrieveAssetAverages(uint(id))
+	if err != nil {
+		return fmt.Errorf("RetrieveAssetAverages 오류 발생. %w", err)
+	}
+	if len(averages) == 0 {
+		averages = []m.AssetAverage{{AssetID: uint(id), Kind: m.DefaultAverage.Kind, Period: m.DefaultAverage.Period, Reference: true}}
+	}
+
+	resp := make([]averageResponse, len(averages))
+	for i, a := range averages {
+		resp[i] = averageResponse{
+			Average:   a.Spec().String(),
+			Reference: a.Reference,
+		}
+
+		hist, err := h.r.RetrieveLatestAverage(uint(id), a.Spec())
+		if err != nil {
+			return fmt.Errorf("RetrieveLatestAverage 오류 발생. %w", err)
+		}
+		if hist != nil {
+			resp[i].Date = time.Time(hist.Date).Format("2006-01-02")
+			resp[i].Value = hist.Value
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// 종목 이동평균 설정 교체 후 재산출
+func (h *AssetHandler) SaveAssetAverages(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	var param SaveAveragesReq
+	err = c.BodyParser(&param)
+	if err != nil {
+		return invalid("파라미터 BodyParse 시 오류 발생. %w", err)
+	}
+
+	err = validCheck(&param)
+	if err != nil {
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+
+	averages, err := toAssetAverages(param.Averages, param.Reference)
+	if err != nil {
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+
+	err = h.w.SaveAssetAverages(uint(id), averages)
+	if err != nil {
+		return fmt.Errorf("SaveAssetAverages 시 오류 발생. %w", err)
+	}
+
+	err = h.a.RecomputeAverages(uint(id))
+	if err != nil {
+		return fmt.Errorf("RecomputeAverages 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("이동평균 설정 저장 성공")
+}
+
+func (h *AssetHandler) RecomputeAverages(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	err = h.a.RecomputeAverages(uint(id))
+	if err != nil {
+		return fmt.Errorf("RecomputeAverages 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("이동평균 재산출 성공")
+}
+
+/*
+ex) ["EMA20", "SMA60"], "SMA60"
+reference 미입력 시 기본 이동평균이 포함되어 있으면 기본 이동평균, 아니면 첫 번째 이동평균을 기준으로 지정
+*/
+func toAssetAverages(names []string, reference string) ([]m.AssetAverage, error) {
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	ref := m.DefaultAverage
+	if reference != "" {
+		spec, err := m.ToAverageSpec(reference)
+		if err != nil {
+			return nil, err
+		}
+		ref = spec
+	}
+
+	rtn := make([]m.AssetAverage, 0, len(names))
+	seen := make(map[m.AverageSpec]bool)
+	hasRef := false
+	for _, n := range names {
+		spec, err := m.ToAverageSpec(n)
+		if err != nil {
+			return nil, err
+		}
+		if seen[spec] {
+			continue
+		}
+		seen[spec] = true
+		hasRef = hasRef || spec == ref
+		rtn = append(rtn, m.AssetAverage{Kind: spec.Kind, Period: spec.Period, Reference: spec == ref})
+	}
+
+	if !hasRef {
+		if reference != "" {
+			return nil, fmt.Errorf("기준 이동평균이 목록에 미존재. %s", reference)
+		}
+		rtn[0].Reference = true
+	}
+
+	return rtn, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"invest/app/middleware"
+	m "invest/model"
 	"io"
 	"net/http"
 	"testing"
@@ -24,9 +25,9 @@ func TestAssetHandler(t *testing.T) {
 
 	readerMock := AssetRetrieverMock{}
 	writerMock := AssetInfoSaverMock{}
-	topBottomPriceGetterMock := TopBottomPriceGetterMock{}
+	assetRegistrarMock := AssetRegistrarMock{}
 
-	f := NewAssetHandler(readerMock, writerMock, topBottomPriceGetterMock)
+	f := NewAssetHandler(readerMock, writerMock, assetRegistrarMock)
 	f.InitRoute(app)
 	go func() {
 		app.Listen(":3000")
@@ -53,6 +54,68 @@ func TestAssetHandler(t *testing.T) {
 		})
 	})
 
+	t.Run("종목 일별 시세 조회 테스트", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp []priceResponse
+			err := sendReqeust(app, "/assets/1/prices?from=2024-09-01&to=2024-09-30", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 2)
+			assert.Equal(t, "2024-09-02", resp[0].Date)
+		})
+
+		t.Run("실패 테스트 - 잘못된 날짜", func(t *testing.T) {
+			err := sendReqeust(app, "/assets/1/prices?from=20240901", "GET", nil, nil)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("종목 이동평균 조회 테스트", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp []averageResponse
+			err := sendReqeust(app, "/assets/1/averages", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 2)
+			assert.Equal(t, "SMA60", resp[1].Average)
+			assert.True(t, resp[1].Reference)
+			assert.Equal(t, "2024-09-03", resp[1].Date)
+		})
+	})
+
+	t.Run("종목 이동평균 설정 테스트", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			param := SaveAveragesReq{
+				Averages:  []string{"EMA20", "sma60", "EMA200"},
+				Reference: "SMA60",
+			}
+			err := sendReqeust(app, "/assets/1/averages", "POST", param, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("실패 테스트 - 미지원 기간", func(t *testing.T) {
+			param := SaveAveragesReq{
+				Averages: []string{"EMA30"},
+			}
+			err := sendReqeust(app, "/assets/1/averages", "POST", param, nil)
+			assert.Error(t, err)
+		})
+
+		t.Run("실패 테스트 - 기준 이동평균 미포함", func(t *testing.T) {
+			param := SaveAveragesReq{
+				Averages:  []string{"EMA20"},
+				Reference: "SMA60",
+			}
+			err := sendReqeust(app, "/assets/1/averages", "POST", param, nil)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("종목 이동평균 재산출 테스트", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			err := sendReqeust(app, "/assets/1/averages/recompute", "POST", nil, nil)
+			assert.NoError(t, err)
+		})
+	})
+
 	t.Run("종목 추가 테스트", func(t *testing.T) {
 		t.Run("성공 테스트", func(t *testing.T) {
 			param := AddAssetReq{
@@ -139,6 +202,43 @@ func TestAssetHandler(t *testing.T) {
 	app.Shutdown()
 }
 
+func TestErrorResponse(t *testing.T) {
+
+	app := fiber.New()
+	middleware.SetupMiddleware(app)
+	NewAssetHandler(AssetRetrieverMock{err: fmt.Errorf("RetrieveAsset 시 오류 발생. %w", m.ErrNotFound)}, AssetInfoSaverMock{}, AssetRegistrarMock{}).InitRoute(app)
+
+	request := func(method string, url string, reqBody any) (int, middleware.ErrorBody) {
+		b, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequest(method, url, bytes.NewBuffer(b))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		var body middleware.ErrorBody
+		json.NewDecoder(resp.Body).Decode(&body)
+		return resp.StatusCode, body
+	}
+
+	t.Run("유효성 검사 실패 시 400과 필드 내역", func(t *testing.T) {
+		status, body := request("POST", "/assets/", AddAssetReq{Currency: "WON"})
+		assert.Equal(t, fiber.StatusBadRequest, status)
+		assert.Equal(t, middleware.CodeInvalid, body.Code)
+		assert.Len(t, body.Fields, 2)
+		assert.Equal(t, "Name", body.Fields[0].Field)
+		assert.Equal(t, "required", body.Fields[0].Tag)
+		assert.Equal(t, "Category", body.Fields[1].Field)
+	})
+
+	t.Run("대상 미존재 시 404", func(t *testing.T) {
+		status, body := request("GET", "/assets/1", nil)
+		assert.Equal(t, fiber.StatusNotFound, status)
+		assert.Equal(t, middleware.CodeNotFound, body.Code)
+	})
+}
+
 /*
 ************************************************
 Inner Function
@@ -148,12 +248,12 @@ func sendReqeust(app *fiber.App, url string, method string, reqBody any, respons
 
 	var req *http.Request
 	switch method {
-	case "POST":
+	case "POST", "PUT", "DELETE":
 		bodyBytes, err := json.Marshal(reqBody)
 		if err != nil {
 			return fmt.Errorf("Error Occurred %w", err)
 		}
-		req, _ = http.NewRequest(http.MethodPost, url, bytes.NewBuffer(bodyBytes))
+		req, _ = http.NewRequest(method, url, bytes.NewBuffer(bodyBytes))
 	default:
 		req, _ = http.NewRequest(http.MethodGet, url, nil)
 	}
@@ -11,13 +11,15 @@ type FundHandler struct {
 	r FundRetriever
 	w FundWriter
 	e ExchageRateGetter
+	p PriorityRanker
 }
 
-func NewFundHandler(r FundRetriever, w FundWriter, e ExchageRateGetter) *FundHandler {
+func NewFundHandler(r FundRetriever, w FundWriter, e ExchageRateGetter, p PriorityRanker) *FundHandler {
 	return &FundHandler{
 		r: r,
 		w: w,
 		e: e,
+		p: p,
 	}
 }
 
@@ -28,6 +30,8 @@ func (h *FundHandler) InitRoute(app *fiber.App) {
 	router.Post("/", h.AddFund)
 	router.Get("/:id/hist", h.FundHist)
 	router.Get("/:id/assets", h.FundAssets)
+	router.Get("/:id/priorities", h.FundPriorities)
+	router.Post("/:id/weights", h.SaveScoreWeights)
 }
 
 // 총 자금 금액
@@ -65,12 +69,12 @@ func (h *FundHandler) AddFund(c *fiber.Ctx) error {
 	var param AddFundReq
 	err := c.BodyParser(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 BodyParse 시 오류 발생. %w", err)
+		return invalid("파라미터 BodyParse 시 오류 발생. %w", err)
 	}
 
 	err = validCheck(param) // 포인터로 들어가도 validation 체크 되는지 확인
 	if err != nil {
-		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. %w", err)
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
 	}
 
 	err = h.w.SaveFund(param.Name)
@@ -86,7 +90,7 @@ func (h *FundHandler) FundAssets(c *fiber.Ctx) error {
 
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return fmt.Errorf("파라미터 id 조회 시 오류 발생. %w", err)
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
 	}
 
 	funds, err := h.r.RetreiveFundSummaryByFundId(uint(id))
@@ -114,7 +118,7 @@ func (h *FundHandler) FundHist(c *fiber.Ctx) error {
 
 	id, err := c.ParamsInt("id")
 	if err != nil {
-		return fmt.Errorf("파라미터 id 조회 시 오류 발생. %w", err)
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
 	}
 
 	invests, err := h.r.RetreiveAFundInvestsById(uint(id))
@@ -135,3 +139,81 @@ func (h *FundHandler) FundHist(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(fundHists)
 }
+
+// 자금별 매도/매수 우선순위. side=buy 시 매수 우선순위, 미입력 시 매도 우선순위
+func (h *FundHandler) FundPriorities(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	side := c.Query("side", "sell")
+	if side != "sell" && side != "buy" {
+		return invalid("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 side. %s", side)
+	}
+
+	priorities, err := h.p.FundPriorities(uint(id), side == "buy")
+	if err != nil {
+		return fmt.Errorf("FundPriorities 시 오류 발생. %w", err)
+	}
+
+	resp := make([]priorityResponse, len(priorities))
+	for i, p := range priorities {
+		breakdown := make(map[string]float64, len(p.Breakdown))
+		for st, s := range p.Breakdown {
+			breakdown[string(st)] = s
+		}
+		resp[i] = priorityResponse{
+			Rank:         i + 1,
+			AssetId:      p.Asset.ID,
+			AssetName:    p.Asset.Name,
+			PresentPrice: p.Present,
+			AveragePrice: p.Average,
+			HighestPrice: p.Highest,
+			Score:        p.Score,
+			Breakdown:    breakdown,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// 자금별 우선순위 점수 산정 방식 가중치 저장
+func (h *FundHandler) SaveScoreWeights(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	var param SaveScoreWeightsReq
+	err = c.BodyParser(&param)
+	if err != nil {
+		return invalid("파라미터 BodyParse 시 오류 발생. %w", err)
+	}
+
+	err = validCheck(&param)
+	if err != nil {
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+
+	weights := make([]model.ScoreWeight, 0, len(param.Weights))
+	for k, w := range param.Weights {
+		st, err := model.ToScoreStrategy(k)
+		if err != nil {
+			return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+		}
+		if w < 0 {
+			return invalid("파라미터 유효성 검사 시 오류 발생. 음수 가중치. %s", k)
+		}
+		weights = append(weights, model.ScoreWeight{Strategy: st, Weight: w})
+	}
+
+	err = h.w.SaveScoreWeights(uint(id), weights)
+	if err != nil {
+		return fmt.Errorf("SaveScoreWeights 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("가중치 저장 성공")
+}
@@ -17,7 +17,8 @@ func TestFundHandler(t *testing.T) {
 	readerMock := &FundRetrieverMock{}
 	writerMock := &FundWriterMock{}
 	exGetterMock := &ExchageRateGetterMock{}
-	f := NewFundHandler(readerMock, writerMock, exGetterMock)
+	rankerMock := &PriorityRankerMock{}
+	f := NewFundHandler(readerMock, writerMock, exGetterMock, rankerMock)
 	f.InitRoute(app)
 
 	go func() {
@@ -103,5 +104,47 @@ func TestFundHandler(t *testing.T) {
 
 	})
 
+	t.Run("자금별 우선순위 조회", func(t *testing.T) {
+		t.Run("성공 테스트 - 매도", func(t *testing.T) {
+			var resp []priorityResponse
+			err := sendReqeust(app, "/funds/1/priorities", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 2)
+			assert.Equal(t, 1, resp[0].Rank)
+			assert.Equal(t, uint(2), resp[0].AssetId)
+			assert.Equal(t, 0.06, resp[0].Breakdown["deviation"])
+		})
+
+		t.Run("성공 테스트 - 매수", func(t *testing.T) {
+			var resp []priorityResponse
+			err := sendReqeust(app, "/funds/1/priorities?side=buy", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), resp[0].AssetId)
+		})
+
+		t.Run("실패 테스트 - 잘못된 side", func(t *testing.T) {
+			err := sendReqeust(app, "/funds/1/priorities?side=hold", "GET", nil, nil)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("자금별 가중치 저장", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			param := SaveScoreWeightsReq{
+				Weights: map[string]float64{"deviation": 0.5, "rsi": 0.5},
+			}
+			err := sendReqeust(app, "/funds/1/weights", "POST", param, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("실패 테스트 - 미지원 방식", func(t *testing.T) {
+			param := SaveScoreWeightsReq{
+				Weights: map[string]float64{"macd": 1},
+			}
+			err := sendReqeust(app, "/funds/1/weights", "POST", param, nil)
+			assert.Error(t, err)
+		})
+	})
+
 	app.Shutdown()
 }
@@ -0,0 +1,365 @@
+package handler
+
+import (
+	"fmt"
+	m "invest/model"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/robfig/cron"
+)
+
+// 수집 주기 미입력 시 기본 값. 평일 9시 3분
+const defaultIndicatorSpec = "0 3 9 * * 1-5"
+
+type IndicatorHandler struct {
+	r IndicatorRetriever
+	w IndicatorSaver
+}
+
+func NewIndicatorHandler(r IndicatorRetriever, w IndicatorSaver) *IndicatorHandler {
+	return &IndicatorHandler{
+		r: r,
+		w: w,
+	}
+}
+
+func (h *IndicatorHandler) InitRoute(app *fiber.App) {
+
+	router := app.Group("/indicators")
+
+	router.Get("/", h.Indicators)
+	router.Post("/", h.AddIndicator)
+	router.Get("/:id<\\d+>", h.Indicator)
+	router.Put("/:id<\\d+>", h.UpdateIndicator)
+	router.Delete("/:id<\\d+>", h.DeleteIndicator)
+	router.Get("/:id<\\d+>/values", h.IndicatorValues)
+	router.Get("/:id<\\d+>/alerts", h.IndicatorAlerts)
+	router.Post("/:id<\\d+>/alerts", h.AddIndicatorAlert)
+	router.Put("/:id<\\d+>/alerts/:alertId<\\d+>", h.UpdateIndicatorAlert)
+	router.Delete("/:id<\\d+>/alerts/:alertId<\\d+>", h.DeleteIndicatorAlert)
+}
+
+func (h *IndicatorHandler) Indicators(c *fiber.Ctx) error {
+
+	indicators, err := h.r.RetrieveIndicators()
+	if err != nil {
+		return fmt.Errorf("RetrieveIndicators 오류 발생. %w", err)
+	}
+
+	resp := make([]indicatorResponse, len(indicators))
+	for i, indicator := range indicators {
+		resp[i] = toIndicatorResponse(indicator)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *IndicatorHandler) Indicator(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	indicator, err := h.r.RetrieveIndicator(uint(id))
+	if err != nil {
+		return fmt.Errorf("RetrieveIndicator 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toIndicatorResponse(*indicator))
+}
+
+func (h *IndicatorHandler) AddIndicator(c *fiber.Ctx) error {
+
+	indicator, err := parseIndicatorReq(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := h.w.SaveIndicator(indicator)
+	if err != nil {
+		return fmt.Errorf("SaveIndicator 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString(fmt.Sprintf("시장 지표 저장 성공. ID : %d", id))
+}
+
+func (h *IndicatorHandler) UpdateIndicator(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	indicator, err := parseIndicatorReq(c)
+	if err != nil {
+		return err
+	}
+	indicator.ID = uint(id)
+
+	err = h.w.UpdateIndicator(indicator)
+	if err != nil {
+		return fmt.Errorf("UpdateIndicator 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("시장 지표 갱신 성공")
+}
+
+func (h *IndicatorHandler) DeleteIndicator(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	err = h.w.DeleteIndicator(uint(id))
+	if err != nil {
+		return fmt.Errorf("DeleteIndicator 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("시장 지표 삭제 성공")
+}
+
+// 기간별 지표 값. 날짜 오름차순
+func (h *IndicatorHandler) IndicatorValues(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	from, to := c.Query("from"), c.Query("to")
+	if !dateCheck(from) || !dateCheck(to) {
+		return invalid("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 date 포맷. %s ~ %s", from, to)
+	}
+
+	values, err := h.r.RetrieveIndicatorValues(uint(id), from, to)
+	if err != nil {
+		return fmt.Errorf("RetrieveIndicatorValues 오류 발생. %w", err)
+	}
+
+	resp := make([]indicatorValueResponse, len(values))
+	for i, v := range values {
+		resp[i] = indicatorValueResponse{
+			Date:  time.Time(v.Date).Format("2006-01-02"),
+			Value: v.Value,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *IndicatorHandler) IndicatorAlerts(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	alerts, err := h.r.RetrieveIndicatorAlerts(uint(id))
+	if err != nil {
+		return fmt.Errorf("RetrieveIndicatorAlerts 오류 발생. %w", err)
+	}
+
+	resp := make([]indicatorAlertResponse, len(alerts))
+	for i, a := range alerts {
+		resp[i] = toIndicatorAlertResponse(a)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *IndicatorHandler) AddIndicatorAlert(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	alert, err := parseIndicatorAlertReq(c)
+	if err != nil {
+		return err
+	}
+	alert.IndicatorID = uint(id)
+
+	alertId, err := h.w.SaveIndicatorAlert(alert)
+	if err != nil {
+		return fmt.Errorf("SaveIndicatorAlert 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString(fmt.Sprintf("지표 알림 저장 성공. ID : %d", alertId))
+}
+
+func (h *IndicatorHandler) UpdateIndicatorAlert(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	alertId, err := c.ParamsInt("alertId")
+	if err != nil {
+		return invalid("파라미터 alertId 조회 시 오류 발생. %w", err)
+	}
+
+	alert, err := parseIndicatorAlertReq(c)
+	if err != nil {
+		return err
+	}
+	alert.ID = uint(alertId)
+	alert.IndicatorID = uint(id)
+
+	err = h.w.UpdateIndicatorAlert(alert)
+	if err != nil {
+		return fmt.Errorf("UpdateIndicatorAlert 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("지표 알림 갱신 성공")
+}
+
+func (h *IndicatorHandler) DeleteIndicatorAlert(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	alertId, err := c.ParamsInt("alertId")
+	if err != nil {
+		return invalid("파라미터 alertId 조회 시 오류 발생. %w", err)
+	}
+
+	err = h.w.DeleteIndicatorAlert(uint(id), uint(alertId))
+	if err != nil {
+		return fmt.Errorf("DeleteIndicatorAlert 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("지표 알림 삭제 성공")
+}
+
+func parseIndicatorReq(c *fiber.Ctx) (m.Indicator, error) {
+
+	var param IndicatorReq
+	err := c.BodyParser(&param)
+	if err != nil {
+		return m.Indicator{}, invalid("파라미터 BodyParse 시 오류 발생. %w", err)
+	}
+
+	err = validCheck(&param)
+	if err != nil {
+		return m.Indicator{}, invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+
+	provider, err := m.ToIndicatorProvider(param.Provider)
+	if err != nil {
+		return m.Indicator{}, invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+	if provider.NeedCode() && param.Code == "" {
+		return m.Indicator{}, invalid("파라미터 유효성 검사 시 오류 발생. %s 수집처는 code 필수", provider)
+	}
+
+	if param.Spec == "" {
+		param.Spec = defaultIndicatorSpec
+	}
+	_, err = cron.Parse(param.Spec)
+	if err != nil {
+		return m.Indicator{}, invalid("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 spec. %w", err)
+	}
+
+	active := true
+	if param.Active != nil {
+		active = *param.Active
+	}
+
+	return m.Indicator{
+		Name:     param.Name,
+		Provider: provider,
+		Code:     param.Code,
+		Spec:     param.Spec,
+		Unit:     param.Unit,
+		Active:   active,
+	}, nil
+}
+
+func toIndicatorResponse(indicator m.Indicator) indicatorResponse {
+
+	var collectedAt string
+	if !indicator.LastCollectedAt.IsZero() {
+		collectedAt = indicator.LastCollectedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return indicatorResponse{
+		ID:              indicator.ID,
+		Name:            indicator.Name,
+		Provider:        string(indicator.Provider),
+		Code:            indicator.Code,
+		Spec:            indicator.Spec,
+		Unit:            indicator.Unit,
+		Active:          indicator.Active,
+		LastCollectedAt: collectedAt,
+	}
+}
+
+func parseIndicatorAlertReq(c *fiber.Ctx) (m.IndicatorAlert, error) {
+
+	var param IndicatorAlertReq
+	err := c.BodyParser(&param)
+	if err != nil {
+		return m.IndicatorAlert{}, invalid("파라미터 BodyParse 시 오류 발생. %w", err)
+	}
+
+	err = validCheck(&param)
+	if err != nil {
+		return m.IndicatorAlert{}, invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+
+	kind, err := m.ToAlertKind(param.Kind)
+	if err != nil {
+		return m.IndicatorAlert{}, invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+
+	switch kind {
+	case m.ChangeAlert:
+		if param.Days <= 0 || param.Threshold == 0 {
+			return m.IndicatorAlert{}, invalid("파라미터 유효성 검사 시 오류 발생. change 조건은 days, threshold(0 제외) 필수")
+		}
+	case m.CrossAboveAlert, m.CrossBelowAlert:
+		if param.Period < 2 {
+			return m.IndicatorAlert{}, invalid("파라미터 유효성 검사 시 오류 발생. cross 조건은 period(2 이상) 필수")
+		}
+	}
+
+	active := true
+	if param.Active != nil {
+		active = *param.Active
+	}
+
+	return m.IndicatorAlert{
+		Kind:      kind,
+		Threshold: param.Threshold,
+		Days:      param.Days,
+		Period:    param.Period,
+		Active:    active,
+	}, nil
+}
+
+func toIndicatorAlertResponse(a m.IndicatorAlert) indicatorAlertResponse {
+
+	var triggeredAt string
+	if !a.LastTriggeredAt.IsZero() {
+		triggeredAt = a.LastTriggeredAt.Format("2006-01-02 15:04:05")
+	}
+
+	return indicatorAlertResponse{
+		ID:              a.ID,
+		Kind:            string(a.Kind),
+		Threshold:       a.Threshold,
+		Days:            a.Days,
+		Period:          a.Period,
+		Description:     a.String(),
+		Active:          a.Active,
+		Triggered:       a.Triggered,
+		LastTriggeredAt: triggeredAt,
+	}
+}
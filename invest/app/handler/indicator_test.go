@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"invest/app/middleware"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndicatorHandler(t *testing.T) {
+
+	app := fiber.New()
+	middleware.SetupMiddleware(app)
+
+	f := NewIndicatorHandler(IndicatorRetrieverMock{}, IndicatorSaverMock{})
+	f.InitRoute(app)
+
+	t.Run("지표 목록 조회", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp []indicatorResponse
+			err := sendReqeust(app, "/indicators", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 2)
+			assert.Equal(t, "kis_overseas", resp[1].Provider)
+		})
+	})
+
+	t.Run("지표 조회", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp indicatorResponse
+			err := sendReqeust(app, "/indicators/2", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Equal(t, "2024-09-03 09:03:00", resp.LastCollectedAt)
+		})
+	})
+
+	t.Run("지표 추가", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			param := IndicatorReq{
+				Name:     "미국 10년물",
+				Provider: "kis_treasury",
+				Code:     "Y0202",
+				Unit:     "%",
+			}
+			err := sendReqeust(app, "/indicators", "POST", param, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("성공 테스트 - code 불필요 수집처", func(t *testing.T) {
+			param := IndicatorReq{
+				Name:     "원/달러",
+				Provider: "exchange_rate",
+			}
+			err := sendReqeust(app, "/indicators", "POST", param, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("실패 테스트 - code 미존재", func(t *testing.T) {
+			param := IndicatorReq{
+				Name:     "VIX",
+				Provider: "kis_overseas",
+			}
+			err := sendReqeust(app, "/indicators", "POST", param, nil)
+			assert.Error(t, err)
+		})
+
+		t.Run("실패 테스트 - 잘못된 수집처", func(t *testing.T) {
+			param := IndicatorReq{
+				Name:     "VIX",
+				Provider: "yahoo",
+				Code:     "^VIX",
+			}
+			err := sendReqeust(app, "/indicators", "POST", param, nil)
+			assert.Error(t, err)
+		})
+
+		t.Run("실패 테스트 - 잘못된 주기", func(t *testing.T) {
+			param := IndicatorReq{
+				Name:     "공포 탐욕 지수",
+				Provider: "fear_greed",
+				Spec:     "매일",
+			}
+			err := sendReqeust(app, "/indicators", "POST", param, nil)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("지표 갱신", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			active := false
+			param := IndicatorReq{
+				Name:     "VIX",
+				Provider: "kis_overseas",
+				Code:     "VIX",
+				Active:   &active,
+			}
+			err := sendReqeust(app, "/indicators/2", "PUT", param, nil)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("지표 삭제", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			err := sendReqeust(app, "/indicators/2", "DELETE", nil, nil)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("지표 값 조회", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp []indicatorValueResponse
+			err := sendReqeust(app, "/indicators/2/values?from=2024-09-01&to=2024-09-03", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 2)
+			assert.Equal(t, "2024-09-02", resp[0].Date)
+		})
+
+		t.Run("실패 테스트 - 잘못된 날짜", func(t *testing.T) {
+			err := sendReqeust(app, "/indicators/2/values?from=20240901", "GET", nil, nil)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("지표 알림 조회", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp []indicatorAlertResponse
+			err := sendReqeust(app, "/indicators/1/alerts", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 2)
+			assert.Equal(t, "20.00 이하", resp[0].Description)
+			assert.Equal(t, "1일 변동률 -3.00% 이하", resp[1].Description)
+		})
+	})
+
+	t.Run("지표 알림 추가", func(t *testing.T) {
+		t.Run("성공 테스트 - 임계값", func(t *testing.T) {
+			param := IndicatorAlertReq{Kind: "below", Threshold: 20}
+			err := sendReqeust(app, "/indicators/1/alerts", "POST", param, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("성공 테스트 - 이동평균 돌파", func(t *testing.T) {
+			param := IndicatorAlertReq{Kind: "cross_below", Period: 20}
+			err := sendReqeust(app, "/indicators/2/alerts", "POST", param, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("실패 테스트 - 변동률 기간 미존재", func(t *testing.T) {
+			param := IndicatorAlertReq{Kind: "change", Threshold: -3}
+			err := sendReqeust(app, "/indicators/2/alerts", "POST", param, nil)
+			assert.Error(t, err)
+		})
+
+		t.Run("실패 테스트 - 잘못된 조건", func(t *testing.T) {
+			param := IndicatorAlertReq{Kind: "equal", Threshold: 20}
+			err := sendReqeust(app, "/indicators/1/alerts", "POST", param, nil)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("지표 알림 갱신/삭제", func(t *testing.T) {
+		t.Run("갱신 성공 테스트", func(t *testing.T) {
+			param := IndicatorAlertReq{Kind: "change", Threshold: -5, Days: 3}
+			err := sendReqeust(app, "/indicators/2/alerts/2", "PUT", param, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("삭제 성공 테스트", func(t *testing.T) {
+			err := sendReqeust(app, "/indicators/2/alerts/2", "DELETE", nil, nil)
+			assert.NoError(t, err)
+		})
+	})
+}
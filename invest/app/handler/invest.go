@@ -1,46 +1,35 @@
 package handler
 
 import (
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"invest/model"
+	m "invest/model"
+	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
 
 type InvestHandler struct {
-	r  AssetRetriever
-	w  InvestSaver
-	e  ExchageRateGetter
-	cm map[model.Currency]uint
+	r AssetRetriever
+	i InvestRecorder
+	q InvestRetriever
 }
 
 func (h *InvestHandler) InitRoute(app *fiber.App) {
 	router := app.Group("/invest")
 	router.Post("/", h.SaveInvest)
-}
-
-func NewInvestHandler(r AssetRetriever, w InvestSaver, e ExchageRateGetter) *InvestHandler {
-
-	cm := make(map[model.Currency]uint)
-	li, err := r.RetrieveAssetList()
-	if err != nil {
-		panic("InvestHandler 기동시 오류. Shutdown")
-	}
 
-	for _, a := range li {
-		if a.Name == model.KRW.String() {
-			cm[model.KRW] = a.ID
-		} else if a.Name == model.USD.String() {
-			cm[model.USD] = a.ID
-		}
-	}
+	app.Get("/invests", h.Invests)
+}
 
+func NewInvestHandler(r AssetRetriever, i InvestRecorder, q InvestRetriever) *InvestHandler {
 	return &InvestHandler{
-		r:  r,
-		w:  w,
-		e:  e,
-		cm: cm,
+		r: r,
+		i: i,
+		q: q,
 	}
 }
 
@@ -49,12 +38,12 @@ func (h *InvestHandler) SaveInvest(c *fiber.Ctx) error {
 	param := SaveInvestParam{}
 	err := c.BodyParser(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 BodyParse 시 오류 발생. %w", err)
+		return invalid("파라미터 BodyParse 시 오류 발생. %w", err)
 	}
 
 	err = validCheck(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. %w", err)
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
 	}
 
 	var assetId uint
@@ -65,62 +54,207 @@ func (h *InvestHandler) SaveInvest(c *fiber.Ctx) error {
 	} else if param.AssetName != "" {
 		assetId = h.r.RetrieveAssetIdByName(param.AssetName)
 	} else if param.AssetCode != "" {
-		assetId = h.r.RetrieveAssetIdByCode(param.AssetName)
+		assetId = h.r.RetrieveAssetIdByCode(param.AssetCode)
+	}
+	if param.AssetId == 0 && param.AssetName == "" && param.AssetCode == "" {
+		return invalid("파라미터 유효성 검사 시 오류 발생. asset_id, name, code 중 하나 필수")
 	}
 	if assetId == 0 {
-		return errors.New("parameter asset 정보 없음")
+		return fmt.Errorf("자산 %s%s. %w", param.AssetName, param.AssetCode, m.ErrNotFound)
 	}
 
-	// 투자 이력 저장
-	err = h.w.SaveInvest(param.FundId, assetId, param.Price, param.Count)
+	// 투자 이력 저장 및 투자 요약, 현금/달러 갱신
+	err = h.i.RecordInvest(param.FundId, assetId, param.Price, param.Count)
 	if err != nil {
-		return fmt.Errorf("SaveInvest 오류 발생. %w", err)
+		return fmt.Errorf("RecordInvest 오류 발생. %w", err)
 	}
 
-	// 투자 요약 갱신
-	err = h.w.UpdateInvestSummary(param.FundId, assetId, param.Count, param.Price)
+	return c.Status(fiber.StatusOK).SendString("Invest 이력 저장 성공")
+}
+
+// 투자 이력 페이지 크기
+const (
+	defaultInvestLimit = 50
+	maxInvestLimit     = 200
+)
+
+/*
+조건별 투자 이력
+  - fund, asset : ID. category : 번호 혹은 이름. side : buy, sell
+  - from, to : 2006-01-02 혹은 RFC3339. from 이상, to 이하 (날짜만 입력 시 해당일 포함)
+  - sort : created_at(기본), price, amount. order : desc(기본), asc
+  - limit : 기본 50, 최대 200. cursor : 이전 응답의 next_cursor
+*/
+func (h *InvestHandler) Invests(c *fiber.Ctx) error {
+
+	q, err := investQuery(c)
 	if err != nil {
-		return fmt.Errorf("UpdateInvestSummaryCount 오류 발생. %w", err)
+		return err
 	}
+	limit := q.Limit
+	q.Limit++ // 다음 페이지 존재 확인용 1건 추가 조회
 
-	// 현금/달러 갱신
-	asset, err := h.r.RetrieveAsset(assetId)
+	invests, err := h.q.RetrieveInvests(q)
 	if err != nil {
-		return fmt.Errorf("RetrieveAsset 오류 발생. %w", err)
+		return fmt.Errorf("RetrieveInvests 오류 발생. %w", err)
 	}
 
-	if assetId == h.cm[model.USD] { // 달러 충전
-		exRate := h.e.ExchageRate()
-		err = h.w.UpdateInvestSummary(param.FundId, h.cm[model.KRW], -1*exRate*param.Count, 1)
-	} else if asset.Currency == model.KRW.String() && asset.Name != model.KRW.String() { // 원화 자산
-		err = h.w.UpdateInvestSummary(param.FundId, h.cm[model.KRW], -1*param.Price*param.Count, 1)
-	} else if asset.Currency == model.USD.String() && asset.Name != model.USD.String() { // 달러 자산
-		err = h.w.UpdateInvestSummary(param.FundId, h.cm[model.USD], -1*param.Price*param.Count, 1)
+	resp := investPageResponse{Items: make([]investResponse, 0, limit)}
+	if len(invests) > limit {
+		invests = invests[:limit]
+		last := invests[limit-1]
+		resp.NextCursor = encodeCursor(q.Sort, m.InvestCursor{Value: q.Sort.Value(last), ID: last.ID})
 	}
+	for _, iv := range invests {
+		side := m.BuySide
+		if iv.Count < 0 {
+			side = m.SellSide
+		}
+		resp.Items = append(resp.Items, investResponse{
+			ID:        iv.ID,
+			FundId:    iv.FundID,
+			AssetId:   iv.AssetID,
+			AssetName: iv.Asset.Name,
+			Category:  iv.Asset.Category.String(),
+			Side:      string(side),
+			Price:     iv.Price,
+			Count:     iv.Count,
+			Amount:    iv.Price * iv.Count,
+			CreatedAt: iv.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func investQuery(c *fiber.Ctx) (m.InvestQuery, error) {
+
+	var q m.InvestQuery
+	var err error
+
+	for key, dst := range map[string]*uint{"fund": &q.FundID, "asset": &q.AssetID} {
+		v := c.Query(key)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return q, invalid("파라미터 %s 조회 시 오류 발생. 양의 정수. 입력 값 : %s", key, v)
+		}
+		*dst = uint(n)
+	}
+
+	if v := c.Query("category"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err == nil && n >= 1 && n <= m.CategoryLength() {
+			q.Category = m.Category(n)
+		} else if q.Category, err = m.ToCategory(v); err != nil {
+			return q, invalid("파라미터 category 조회 시 오류 발생. %w", err)
+		}
+	}
+
+	q.Side, err = m.ToInvestSide(c.Query("side"))
 	if err != nil {
-		return fmt.Errorf("UpdateInvestSummaryCount 오류 발생. %w", err)
+		return q, invalid("파라미터 side 조회 시 오류 발생. %w", err)
 	}
 
-	return c.Status(fiber.StatusOK).SendString("Invest 이력 저장 성공")
+	q.From, _, err = queryTime(c.Query("from"))
+	if err != nil {
+		return q, invalid("파라미터 from 조회 시 오류 발생. %w", err)
+	}
+	to, dateOnly, err := queryTime(c.Query("to"))
+	if err != nil {
+		return q, invalid("파라미터 to 조회 시 오류 발생. %w", err)
+	}
+	if dateOnly {
+		q.To = to.AddDate(0, 0, 1)
+	} else if !to.IsZero() {
+		q.To = to.Add(time.Second) // RFC3339 초 단위. 해당 초 포함
+	}
+
+	q.Sort, err = m.ToInvestSort(c.Query("sort"))
+	if err != nil {
+		return q, invalid("파라미터 sort 조회 시 오류 발생. %w", err)
+	}
+	switch order := c.Query("order", "desc"); order {
+	case "desc":
+		q.Desc = true
+	case "asc":
+	default:
+		return q, invalid("파라미터 order 조회 시 오류 발생. desc 혹은 asc. 입력 값 : %s", order)
+	}
+
+	q.Limit = c.QueryInt("limit", defaultInvestLimit)
+	if q.Limit < 1 || q.Limit > maxInvestLimit {
+		return q, invalid("파라미터 limit 조회 시 오류 발생. 1 ~ %d. 입력 값 : %s", maxInvestLimit, c.Query("limit"))
+	}
+
+	if v := c.Query("cursor"); v != "" {
+		q.After, err = decodeCursor(q.Sort, v)
+		if err != nil {
+			return q, invalid("파라미터 cursor 조회 시 오류 발생. %w", err)
+		}
+	}
+
+	return q, nil
+}
+
+// 2006-01-02 혹은 RFC3339. dateOnly는 날짜만 입력 여부
+func queryTime(s string) (t time.Time, dateOnly bool, err error) {
+	if s == "" {
+		return time.Time{}, false, nil
+	}
+	t, err = time.ParseInLocation("2006-01-02", s, time.Local)
+	if err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("2006-01-02 혹은 RFC3339 형식. 입력 값 : %s", s)
+	}
+	return t, false, nil
 }
 
-// func (h *InvestHandler) InvestHist(c *fiber.Ctx) error {
+// 페이지 위치. 정렬 기준이 다른 요청에 사용하지 않도록 기준 포함
+type cursorBody struct {
+	Sort  m.InvestSort    `json:"s"`
+	Value json.RawMessage `json:"v"`
+	ID    uint            `json:"id"`
+}
 
-// 	var param model.GetInvestHistParam
-// 	err := c.BodyParser(&param)
-// 	if err != nil {
-// 		return fmt.Errorf("파라미터 BodyParse 시 오류 발생. %w", err)
-// 	}
+func encodeCursor(sort m.InvestSort, cur m.InvestCursor) string {
+	v, _ := json.Marshal(cur.Value)
+	b, _ := json.Marshal(cursorBody{Sort: sort, Value: v, ID: cur.ID})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
 
-// 	err = validCheck(&param)
-// 	if err != nil {
-// 		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. %w", err)
-// 	}
+func decodeCursor(sort m.InvestSort, s string) (*m.InvestCursor, error) {
 
-// 	investHist, err := h.r.RetrieveInvestHist(param.FundId, param.AssetId, param.StartDate, param.EndDate)
-// 	if err != nil {
-// 		return fmt.Errorf("RetrieveMarketSituation 오류 발생. %w", err)
-// 	}
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("올바르지 않은 cursor")
+	}
+	var body cursorBody
+	err = json.Unmarshal(b, &body)
+	if err != nil || body.ID == 0 {
+		return nil, errors.New("올바르지 않은 cursor")
+	}
+	if body.Sort != sort {
+		return nil, fmt.Errorf("정렬 기준 불일치. cursor : %s, sort : %s", body.Sort, sort)
+	}
 
-// 	return c.Status(fiber.StatusOK).JSON(investHist)
-// }
+	cur := &m.InvestCursor{ID: body.ID}
+	if sort == m.SortCreatedAt {
+		var t time.Time
+		err = json.Unmarshal(body.Value, &t)
+		cur.Value = t
+	} else {
+		var f float64
+		err = json.Unmarshal(body.Value, &f)
+		cur.Value = f
+	}
+	if err != nil {
+		return nil, errors.New("올바르지 않은 cursor")
+	}
+	return cur, nil
+}
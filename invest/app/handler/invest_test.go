@@ -2,7 +2,9 @@ package handler
 
 import (
 	"invest/app/middleware"
+	m "invest/model"
 	"testing"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
@@ -14,9 +16,10 @@ func TestInvestGetHandler(t *testing.T) {
 	middleware.SetupMiddleware(app)
 
 	readerMock := AssetRetrieverMock{}
-	writerMock := InvestSaverMock{}
-	exMock := ExchageRateGetterMock{}
-	f := NewInvestHandler(readerMock, writerMock, exMock)
+	recorderMock := InvestRecorderMock{}
+	query := &m.InvestQuery{}
+	retrieverMock := InvestRetrieverMock{query: query}
+	f := NewInvestHandler(readerMock, recorderMock, retrieverMock)
 	f.InitRoute(app)
 	go func() {
 		app.Listen(":3000")
@@ -36,5 +39,63 @@ func TestInvestGetHandler(t *testing.T) {
 
 	})
 
+	t.Run("투자 이력 조회", func(t *testing.T) {
+		t.Run("조건, ISO 시각", func(t *testing.T) {
+			var resp investPageResponse
+			err := sendReqeust(app, "/invests?fund=1&asset=2&category=국내ETF&side=sell&from=2024-12-01&to=2024-12-03&sort=amount&order=asc", "GET", nil, &resp)
+			assert.NoError(t, err)
+
+			assert.Equal(t, m.InvestQuery{
+				FundID:   1,
+				AssetID:  2,
+				Category: m.DomesticETF,
+				Side:     m.SellSide,
+				From:     time.Date(2024, 12, 1, 0, 0, 0, 0, time.Local),
+				To:       time.Date(2024, 12, 4, 0, 0, 0, 0, time.Local), // 종료일 포함
+				Sort:     m.SortAmount,
+				Limit:    defaultInvestLimit + 1,
+			}, *query)
+			assert.Empty(t, resp.NextCursor)
+			assert.Equal(t, investResponse{
+				ID: 3, FundId: 1, AssetId: 1, AssetName: "비트코인", Category: "국내코인", Side: "sell",
+				Price: 7800, Count: -5, Amount: -39000, CreatedAt: "2024-12-04T09:30:00Z",
+			}, resp.Items[0])
+		})
+
+		t.Run("cursor 페이지", func(t *testing.T) {
+			var first investPageResponse
+			err := sendReqeust(app, "/invests?limit=2", "GET", nil, &first)
+			assert.NoError(t, err)
+			assert.Len(t, first.Items, 2)
+			assert.NotEmpty(t, first.NextCursor)
+			assert.True(t, query.Desc)
+
+			var next investPageResponse
+			err = sendReqeust(app, "/invests?limit=2&cursor="+first.NextCursor, "GET", nil, &next)
+			assert.NoError(t, err)
+			assert.Equal(t, &m.InvestCursor{Value: time.Date(2024, 12, 3, 9, 30, 0, 0, time.UTC), ID: 2}, query.After)
+		})
+
+		t.Run("가격 정렬 cursor", func(t *testing.T) {
+			var first investPageResponse
+			err := sendReqeust(app, "/invests?sort=price&limit=1", "GET", nil, &first)
+			assert.NoError(t, err)
+
+			err = sendReqeust(app, "/invests?sort=price&limit=1&cursor="+first.NextCursor, "GET", nil, nil)
+			assert.NoError(t, err)
+			assert.Equal(t, &m.InvestCursor{Value: float64(7800), ID: 3}, query.After)
+
+			err = sendReqeust(app, "/invests?sort=amount&cursor="+first.NextCursor, "GET", nil, nil)
+			assert.ErrorContains(t, err, "정렬 기준 불일치")
+		})
+
+		t.Run("잘못된 조건", func(t *testing.T) {
+			for _, q := range []string{"fund=a", "category=채권", "side=hold", "from=2024/12/01", "sort=name", "order=up", "limit=500", "cursor=abc"} {
+				err := sendReqeust(app, "/invests?"+q, "GET", nil, nil)
+				assert.ErrorContains(t, err, "Status: 400", q)
+			}
+		})
+	})
+
 	app.Shutdown()
 }
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -21,8 +22,8 @@ func NewMarketHandler(r MaketRetriever, w MarketSaver) *MarketHandler {
 func (h *MarketHandler) InitRoute(app *fiber.App) {
 
 	router := app.Group("/market")
+	router.Get("/indicators/:date?", h.MarketIndicator) // /:date? 보다 먼저 등록해야 날짜 미지정 조회 가능
 	router.Get("/:date?", h.Market)
-	router.Get("/indicators/:date?", h.MarketIndicator)
 	router.Post("/", h.ChangeMarketStatus)
 }
 
@@ -32,7 +33,7 @@ func (h *MarketHandler) Market(c *fiber.Ctx) error {
 
 	isDateFormat := dateCheck(date)
 	if !isDateFormat {
-		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 date 포맷. %s", date)
+		return invalid("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 date 포맷. %s", date)
 	}
 
 	assets, err := h.r.RetrieveMarketStatus(date)
@@ -49,15 +50,40 @@ func (h *MarketHandler) MarketIndicator(c *fiber.Ctx) error {
 
 	isDateFormat := dateCheck(date)
 	if !isDateFormat {
-		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 date 포맷. %s", date)
+		return invalid("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 date 포맷. %s", date)
 	}
 
-	dailyIdx, cliIdx, err := h.r.RetrieveMarketIndicator(date)
+	changes, cliIdx, err := h.r.RetrieveMarketIndicator(date)
 	if err != nil {
 		return fmt.Errorf("RetrieveMarketIndicator 오류 발생. %w", err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON([]any{dailyIdx, cliIdx})
+	resp := marketIndicatorResponse{
+		Indicators: make([]indicatorChangeResponse, len(changes)),
+	}
+	for i, change := range changes {
+		resp.Indicators[i] = indicatorChangeResponse{
+			ID:       change.Indicator.ID,
+			Name:     change.Indicator.Name,
+			Unit:     change.Indicator.Unit,
+			Date:     change.Date,
+			Value:    change.Value,
+			Previous: change.Previous,
+		}
+		if change.Previous != nil {
+			diff, rate := change.Diff(), change.Rate()
+			resp.Indicators[i].Diff = &diff
+			resp.Indicators[i].Rate = &rate
+		}
+	}
+	if cliIdx != nil {
+		resp.Cli = &cliResponse{
+			Month: time.Time(cliIdx.CreatedAt).Format("2006-01"),
+			Index: cliIdx.Index,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
 }
 
 func (h *MarketHandler) ChangeMarketStatus(c *fiber.Ctx) error {
@@ -65,12 +91,12 @@ func (h *MarketHandler) ChangeMarketStatus(c *fiber.Ctx) error {
 	var param SaveMarketStatusParam
 	err := c.BodyParser(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 BodyParse 시 오류 발생. %w", err)
+		return invalid("파라미터 BodyParse 시 오류 발생. %w", err)
 	}
 
 	err = validCheck(&param)
 	if err != nil {
-		return fmt.Errorf("파라미터 유효성 검사 시 오류 발생. %w", err)
+		return invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
 	}
 
 	err = h.w.SaveMarketStatus(param.Status)
@@ -77,6 +77,17 @@ func TestMarketHandler(t *testing.T) {
 			assert.NoError(t, err)
 		})
 
+		t.Run("성공테스트-전일대비", func(t *testing.T) {
+			var resp marketIndicatorResponse
+			err := sendReqeust(app, "/market/indicators", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp.Indicators, 2)
+			assert.Equal(t, 3.0, *resp.Indicators[0].Diff)
+			assert.Equal(t, 15.0, *resp.Indicators[0].Rate)
+			assert.Nil(t, resp.Indicators[1].Previous)
+			assert.NotNil(t, resp.Cli)
+		})
+
 	})
 
 	app.Shutdown()
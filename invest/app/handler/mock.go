@@ -73,6 +73,39 @@ func (mock AssetRetrieverMock) RetrieveAssetIdByCode(code string) uint {
 	return 1
 }
 
+func (mock AssetRetrieverMock) RetrieveDailyPrices(assetId uint, from string, to string) ([]m.DailyPrice, error) {
+	fmt.Println("RetrieveDailyPrices Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return []m.DailyPrice{
+		{AssetID: assetId, Date: datatypes.Date(time.Date(2024, 9, 2, 0, 0, 0, 0, time.Local)), Open: 7700, High: 7900, Low: 7600, Close: 7800, Volume: 1000},
+		{AssetID: assetId, Date: datatypes.Date(time.Date(2024, 9, 3, 0, 0, 0, 0, time.Local)), Open: 7800, High: 8000, Low: 7700, Close: 7950, Volume: 1200},
+	}, nil
+}
+
+func (mock AssetRetrieverMock) RetrieveAssetAverages(assetId uint) ([]m.AssetAverage, error) {
+	fmt.Println("RetrieveAssetAverages Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return []m.AssetAverage{
+		{AssetID: assetId, Kind: m.EMA, Period: 20},
+		{AssetID: assetId, Kind: m.SMA, Period: 60, Reference: true},
+	}, nil
+}
+
+func (mock AssetRetrieverMock) RetrieveLatestAverage(assetId uint, spec m.AverageSpec) (*m.AverageHist, error) {
+	fmt.Println("RetrieveLatestAverage Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return &m.AverageHist{AssetID: assetId, Kind: spec.Kind, Period: spec.Period, Date: datatypes.Date(time.Date(2024, 9, 3, 0, 0, 0, 0, time.Local)), Value: 7800}, nil
+}
+
 type AssetInfoSaverMock struct {
 	err error
 }
@@ -102,24 +135,35 @@ func (mock AssetInfoSaverMock) DeleteAssetInfo(id uint) error {
 	return nil
 }
 
-func (mock AssetInfoSaverMock) SaveEmaHist(assetId uint, price float64) error {
+func (mock AssetInfoSaverMock) SaveAssetAverages(assetId uint, averages []m.AssetAverage) error {
+	fmt.Println("SaveAssetAverages Called")
+
 	if mock.err != nil {
 		return mock.err
 	}
 	return nil
 }
 
-type TopBottomPriceGetterMock struct {
+type AssetRegistrarMock struct {
 	err error
 }
 
-func (mock TopBottomPriceGetterMock) TopBottomPrice(category m.Category, code string) (float64, float64, error) {
-	fmt.Println("TopBottomPrice Called")
+func (mock AssetRegistrarMock) AddAsset(asset m.Asset, averages []m.AssetAverage) (uint, error) {
+	fmt.Println("AddAsset Called")
+
+	if mock.err != nil {
+		return 0, mock.err
+	}
+	return 1, nil
+}
+
+func (mock AssetRegistrarMock) RecomputeAverages(assetId uint) error {
+	fmt.Println("RecomputeAverages Called")
 
 	if mock.err != nil {
-		return 0, 0, mock.err
+		return mock.err
 	}
-	return 1000, 100, nil
+	return nil
 }
 
 /***************************** Fund ***********************************/
@@ -175,6 +219,35 @@ func (mock FundWriterMock) SaveFund(name string) error {
 	return nil
 }
 
+func (mock FundWriterMock) SaveScoreWeights(fundId uint, weights []m.ScoreWeight) error {
+	fmt.Println("SaveScoreWeights Called")
+
+	if mock.err != nil {
+		return mock.err
+	}
+	return nil
+}
+
+type PriorityRankerMock struct {
+	err error
+}
+
+func (mock PriorityRankerMock) FundPriorities(fundId uint, buy bool) ([]m.AssetPriority, error) {
+	fmt.Println("FundPriorities Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	rtn := []m.AssetPriority{
+		{Asset: m.Asset{ID: 2, Name: "비트코인"}, Present: 1000, Average: 900, Highest: 1000, Score: 0.06, Breakdown: map[m.ScoreStrategy]float64{m.Deviation: 0.06}},
+		{Asset: m.Asset{ID: 1, Name: "삼성전자"}, Present: 1000, Average: 1000, Highest: 1100, Score: -0.04, Breakdown: map[m.ScoreStrategy]float64{m.Deviation: -0.04}},
+	}
+	if buy {
+		rtn[0], rtn[1] = rtn[1], rtn[0]
+	}
+	return rtn, nil
+}
+
 type ExchageRateGetterMock struct {
 }
 
@@ -201,16 +274,16 @@ func (mock MaketRetrieverMock) RetrieveMarketStatus(date string) (*m.Market, err
 	}, nil
 }
 
-func (mock MaketRetrieverMock) RetrieveMarketIndicator(date string) (*m.DailyIndex, *m.CliIndex, error) {
+func (mock MaketRetrieverMock) RetrieveMarketIndicator(date string) ([]m.IndicatorChange, *m.CliIndex, error) {
 	fmt.Println("RetrieveMarketIndicator Called")
 
 	if mock.err != nil {
 		return nil, nil, mock.err
 	}
-	return &m.DailyIndex{
-			CreatedAt:      datatypes.Date(time.Now()),
-			FearGreedIndex: 23,
-			NasDaq:         17556.03,
+	prev := 20.0
+	return []m.IndicatorChange{
+			{Indicator: m.Indicator{ID: 1, Name: "공포 탐욕 지수", Provider: m.FearGreedProvider}, Date: "2024-08-29", Value: 23, Previous: &prev},
+			{Indicator: m.Indicator{ID: 2, Name: "Nasdaq", Provider: m.KisOverseasIdxProvider, Code: "COMP"}, Date: "2024-08-29", Value: 17556.03},
 		}, &m.CliIndex{
 			CreatedAt: datatypes.Date(time.Now()),
 			Index:     102,
@@ -232,32 +305,219 @@ func (mock MarketSaverMock) SaveMarketStatus(status uint) error {
 
 /***************************** Invest ***********************************/
 type InvestRetrieverMock struct {
+	query *m.InvestQuery // 마지막 조회 조건
+	err   error
+}
+
+func (mock InvestRetrieverMock) RetrieveInvests(q m.InvestQuery) ([]m.Invest, error) {
+	fmt.Println("RetrieveInvests Called")
+
+	if mock.query != nil {
+		*mock.query = q
+	}
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	invests := []m.Invest{
+		{ID: 3, FundID: 1, AssetID: 1, Asset: m.Asset{ID: 1, Name: "비트코인", Category: m.DomesticCoin}, Price: 7800, Count: -5},
+		{ID: 2, FundID: 1, AssetID: 2, Asset: m.Asset{ID: 2, Name: "TigerS&P500", Category: m.DomesticETF}, Price: 12500, Count: 10},
+		{ID: 1, FundID: 1, AssetID: 2, Asset: m.Asset{ID: 2, Name: "TigerS&P500", Category: m.DomesticETF}, Price: 12000, Count: 3},
+	}
+	for i := range invests {
+		invests[i].CreatedAt = time.Date(2024, 12, 4-i, 9, 30, 0, 0, time.UTC)
+	}
+	if q.Limit > 0 && q.Limit < len(invests) {
+		invests = invests[:q.Limit]
+	}
+	return invests, nil
+}
+
+type InvestRecorderMock struct {
+	err error
+}
+
+func (mock InvestRecorderMock) RecordInvest(fundId uint, assetId uint, price float64, count float64) error {
+	fmt.Println("RecordInvest Called")
+
+	if mock.err != nil {
+		return mock.err
+	}
+	return nil
+}
+
+/***************************** Monitor ***********************************/
+type MonitorRetrieverMock struct {
 	err error
 }
 
-func (mock InvestRetrieverMock) RetrieveInvestHist(fundId uint, assetId uint, start string, end string) ([]m.Invest, error) {
-	fmt.Println("RetrieveInvestHist Called")
+func (mock MonitorRetrieverMock) RetrieveMonitors() ([]m.Monitor, error) {
+	fmt.Println("RetrieveMonitors Called")
 
 	if mock.err != nil {
 		return nil, mock.err
 	}
-	return []m.Invest{
-		{
-			ID:      1,
-			FundID:  fundId,
-			AssetID: assetId,
-			Price:   7800,
-			Count:   5,
-		},
+	return []m.Monitor{
+		{ID: 1, Name: "재개발", Url: "https://example.com", Kind: m.CssMonitor, Selector: "div.step", Spec: "0 */15 * * * *", Expected: "예정지구 지정", Active: true},
+	}, nil
+}
+
+func (mock MonitorRetrieverMock) RetrieveMonitor(id uint) (*m.Monitor, error) {
+	fmt.Println("RetrieveMonitor Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return &m.Monitor{ID: id, Name: "재개발", Url: "https://example.com", Kind: m.CssMonitor, Selector: "div.step", Spec: "0 */15 * * * *", Active: true, LastValue: "예정지구 지정", LastCheckedAt: time.Date(2024, 9, 3, 9, 0, 0, 0, time.Local)}, nil
+}
+
+func (mock MonitorRetrieverMock) RetrieveMonitorHist(id uint, limit int) ([]m.MonitorHist, error) {
+	fmt.Println("RetrieveMonitorHist Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return []m.MonitorHist{
+		{MonitorID: id, Value: "구역 지정", Changed: true, CheckedAt: time.Date(2024, 9, 3, 9, 15, 0, 0, time.Local)},
+		{MonitorID: id, Value: "예정지구 지정", CheckedAt: time.Date(2024, 9, 3, 9, 0, 0, 0, time.Local)},
+	}, nil
+}
+
+type MonitorSaverMock struct {
+	err error
+}
+
+func (mock MonitorSaverMock) SaveMonitor(monitor m.Monitor) (uint, error) {
+	fmt.Println("SaveMonitor Called")
+
+	if mock.err != nil {
+		return 0, mock.err
+	}
+	return 1, nil
+}
+
+func (mock MonitorSaverMock) UpdateMonitor(monitor m.Monitor) error {
+	fmt.Println("UpdateMonitor Called")
+
+	if mock.err != nil {
+		return mock.err
+	}
+	return nil
+}
+
+func (mock MonitorSaverMock) DeleteMonitor(id uint) error {
+	fmt.Println("DeleteMonitor Called")
+
+	if mock.err != nil {
+		return mock.err
+	}
+	return nil
+}
+
+type MonitorCheckerMock struct {
+	err error
+}
+
+func (mock MonitorCheckerMock) CheckMonitor(id uint) (*m.MonitorHist, string, error) {
+	fmt.Println("CheckMonitor Called")
+
+	if mock.err != nil {
+		return nil, "", mock.err
+	}
+	return &m.MonitorHist{MonitorID: id, Value: "구역 지정", Changed: true, CheckedAt: time.Now()}, "[재개발] 기대 값과 다름. 예정지구 지정 => 구역 지정", nil
+}
+
+/***************************** Indicator ***********************************/
+type IndicatorRetrieverMock struct {
+	err error
+}
+
+func (mock IndicatorRetrieverMock) RetrieveIndicators() ([]m.Indicator, error) {
+	fmt.Println("RetrieveIndicators Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return []m.Indicator{
+		{ID: 1, Name: "공포 탐욕 지수", Provider: m.FearGreedProvider, Spec: "0 3 9 * * 1-5", Active: true},
+		{ID: 2, Name: "VIX", Provider: m.KisOverseasIdxProvider, Code: "VIX", Spec: "0 3 9 * * 1-5", Active: true},
+	}, nil
+}
+
+func (mock IndicatorRetrieverMock) RetrieveIndicator(id uint) (*m.Indicator, error) {
+	fmt.Println("RetrieveIndicator Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return &m.Indicator{ID: id, Name: "VIX", Provider: m.KisOverseasIdxProvider, Code: "VIX", Spec: "0 3 9 * * 1-5", Active: true, LastCollectedAt: time.Date(2024, 9, 3, 9, 3, 0, 0, time.Local)}, nil
+}
+
+func (mock IndicatorRetrieverMock) RetrieveIndicatorValues(id uint, from string, to string) ([]m.IndicatorValue, error) {
+	fmt.Println("RetrieveIndicatorValues Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return []m.IndicatorValue{
+		{IndicatorID: id, Date: datatypes.Date(time.Date(2024, 9, 2, 0, 0, 0, 0, time.Local)), Value: 15.55},
+		{IndicatorID: id, Date: datatypes.Date(time.Date(2024, 9, 3, 0, 0, 0, 0, time.Local)), Value: 20.72},
 	}, nil
 }
 
-type InvestSaverMock struct {
+func (mock IndicatorRetrieverMock) RetrieveIndicatorAlerts(indicatorId uint) ([]m.IndicatorAlert, error) {
+	fmt.Println("RetrieveIndicatorAlerts Called")
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return []m.IndicatorAlert{
+		{ID: 1, IndicatorID: indicatorId, Kind: m.BelowAlert, Threshold: 20, Active: true, Triggered: true, LastTriggeredAt: time.Date(2024, 9, 3, 9, 3, 0, 0, time.Local)},
+		{ID: 2, IndicatorID: indicatorId, Kind: m.ChangeAlert, Threshold: -3, Days: 1, Active: true},
+	}, nil
+}
+
+type IndicatorSaverMock struct {
 	err error
 }
 
-func (mock InvestSaverMock) SaveInvest(fundId uint, assetId uint, price float64, count float64) error {
-	fmt.Println("SaveInvest Called")
+func (mock IndicatorSaverMock) SaveIndicator(indicator m.Indicator) (uint, error) {
+	fmt.Println("SaveIndicator Called")
+
+	if mock.err != nil {
+		return 0, mock.err
+	}
+	return 1, nil
+}
+
+func (mock IndicatorSaverMock) UpdateIndicator(indicator m.Indicator) error {
+	fmt.Println("UpdateIndicator Called")
+
+	if mock.err != nil {
+		return mock.err
+	}
+	return nil
+}
+
+func (mock IndicatorSaverMock) DeleteIndicator(id uint) error {
+	fmt.Println("DeleteIndicator Called")
+
+	if mock.err != nil {
+		return mock.err
+	}
+	return nil
+}
+
+func (mock IndicatorSaverMock) SaveIndicatorAlert(alert m.IndicatorAlert) (uint, error) {
+	fmt.Println("SaveIndicatorAlert Called")
+
+	if mock.err != nil {
+		return 0, mock.err
+	}
+	return 1, nil
+}
+
+func (mock IndicatorSaverMock) UpdateIndicatorAlert(alert m.IndicatorAlert) error {
+	fmt.Println("UpdateIndicatorAlert Called")
 
 	if mock.err != nil {
 		return mock.err
@@ -265,8 +525,8 @@ func (mock InvestSaverMock) SaveInvest(fundId uint, assetId uint, price float64,
 	return nil
 }
 
-func (mock InvestSaverMock) UpdateInvestSummary(fundId uint, assetId uint, change float64, price float64) error {
-	fmt.Println("UpdateInvestSummaryCount Called")
+func (mock IndicatorSaverMock) DeleteIndicatorAlert(indicatorId uint, id uint) error {
+	fmt.Println("DeleteIndicatorAlert Called")
 
 	if mock.err != nil {
 		return mock.err
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"fmt"
+	m "invest/model"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/robfig/cron"
+)
+
+// 확인 주기 미입력 시 기본 값
+const defaultMonitorSpec = "0 */15 * * * *"
+
+type MonitorHandler struct {
+	r MonitorRetriever
+	w MonitorSaver
+	c MonitorChecker
+}
+
+func NewMonitorHandler(r MonitorRetriever, w MonitorSaver, c MonitorChecker) *MonitorHandler {
+	return &MonitorHandler{
+		r: r,
+		w: w,
+		c: c,
+	}
+}
+
+func (h *MonitorHandler) InitRoute(app *fiber.App) {
+
+	router := app.Group("/monitors")
+
+	router.Get("/", h.Monitors)
+	router.Post("/", h.AddMonitor)
+	router.Get("/:id<\\d+>", h.Monitor)
+	router.Put("/:id<\\d+>", h.UpdateMonitor)
+	router.Delete("/:id<\\d+>", h.DeleteMonitor)
+	router.Get("/:id<\\d+>/hist", h.MonitorHist)
+	router.Post("/:id<\\d+>/check", h.CheckMonitor)
+}
+
+func (h *MonitorHandler) Monitors(c *fiber.Ctx) error {
+
+	monitors, err := h.r.RetrieveMonitors()
+	if err != nil {
+		return fmt.Errorf("RetrieveMonitors 오류 발생. %w", err)
+	}
+
+	resp := make([]monitorResponse, len(monitors))
+	for i, mo := range monitors {
+		resp[i] = toMonitorResponse(mo)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *MonitorHandler) Monitor(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	mo, err := h.r.RetrieveMonitor(uint(id))
+	if err != nil {
+		return fmt.Errorf("RetrieveMonitor 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toMonitorResponse(*mo))
+}
+
+func (h *MonitorHandler) AddMonitor(c *fiber.Ctx) error {
+
+	mo, err := parseMonitorReq(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := h.w.SaveMonitor(mo)
+	if err != nil {
+		return fmt.Errorf("SaveMonitor 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString(fmt.Sprintf("감시 대상 저장 성공. ID : %d", id))
+}
+
+func (h *MonitorHandler) UpdateMonitor(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	mo, err := parseMonitorReq(c)
+	if err != nil {
+		return err
+	}
+	mo.ID = uint(id)
+
+	err = h.w.UpdateMonitor(mo)
+	if err != nil {
+		return fmt.Errorf("UpdateMonitor 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("감시 대상 갱신 성공")
+}
+
+func (h *MonitorHandler) DeleteMonitor(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	err = h.w.DeleteMonitor(uint(id))
+	if err != nil {
+		return fmt.Errorf("DeleteMonitor 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).SendString("감시 대상 삭제 성공")
+}
+
+// 확인 이력. 최근 순 limit 건 (기본 20건)
+func (h *MonitorHandler) MonitorHist(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	limit := c.QueryInt("limit", 20)
+	if limit <= 0 {
+		return invalid("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 limit. %d", limit)
+	}
+
+	hist, err := h.r.RetrieveMonitorHist(uint(id), limit)
+	if err != nil {
+		return fmt.Errorf("RetrieveMonitorHist 오류 발생. %w", err)
+	}
+
+	resp := make([]monitorHistResponse, len(hist))
+	for i, mh := range hist {
+		resp[i] = monitorHistResponse{
+			Value:     mh.Value,
+			Changed:   mh.Changed,
+			Error:     mh.Error,
+			CheckedAt: mh.CheckedAt.Format("2006-01-02 15:04:05"),
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+// 즉시 확인. 확인 결과와 알림 메시지 반환
+func (h *MonitorHandler) CheckMonitor(c *fiber.Ctx) error {
+
+	id, err := c.ParamsInt("id")
+	if err != nil {
+		return invalid("파라미터 id 조회 시 오류 발생. %w", err)
+	}
+
+	hist, alert, err := h.c.CheckMonitor(uint(id))
+	if err != nil {
+		return fmt.Errorf("CheckMonitor 시 오류 발생. %w", err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(monitorHistResponse{
+		Value:     hist.Value,
+		Changed:   hist.Changed,
+		CheckedAt: hist.CheckedAt.Format("2006-01-02 15:04:05"),
+		Alert:     alert,
+	})
+}
+
+func parseMonitorReq(c *fiber.Ctx) (m.Monitor, error) {
+
+	var param MonitorReq
+	err := c.BodyParser(&param)
+	if err != nil {
+		return m.Monitor{}, invalid("파라미터 BodyParse 시 오류 발생. %w", err)
+	}
+
+	err = validCheck(&param)
+	if err != nil {
+		return m.Monitor{}, invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+
+	kind, err := m.ToMonitorKind(param.Kind)
+	if err != nil {
+		return m.Monitor{}, invalid("파라미터 유효성 검사 시 오류 발생. %w", err)
+	}
+
+	if param.Spec == "" {
+		param.Spec = defaultMonitorSpec
+	}
+	_, err = cron.Parse(param.Spec)
+	if err != nil {
+		return m.Monitor{}, invalid("파라미터 유효성 검사 시 오류 발생. 올바르지 않은 spec. %w", err)
+	}
+
+	active := true
+	if param.Active != nil {
+		active = *param.Active
+	}
+
+	return m.Monitor{
+		Name:     param.Name,
+		Url:      param.Url,
+		Kind:     kind,
+		Selector: param.Selector,
+		Spec:     param.Spec,
+		Expected: param.Expected,
+		Active:   active,
+	}, nil
+}
+
+func toMonitorResponse(mo m.Monitor) monitorResponse {
+
+	var checkedAt string
+	if !mo.LastCheckedAt.IsZero() {
+		checkedAt = mo.LastCheckedAt.Format("2006-01-02 15:04:05")
+	}
+
+	return monitorResponse{
+		ID:            mo.ID,
+		Name:          mo.Name,
+		Url:           mo.Url,
+		Kind:          string(mo.Kind),
+		Selector:      mo.Selector,
+		Spec:          mo.Spec,
+		Expected:      mo.Expected,
+		Active:        mo.Active,
+		LastValue:     mo.LastValue,
+		LastCheckedAt: checkedAt,
+	}
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"invest/app/middleware"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitorHandler(t *testing.T) {
+
+	app := fiber.New()
+	middleware.SetupMiddleware(app)
+
+	f := NewMonitorHandler(MonitorRetrieverMock{}, MonitorSaverMock{}, MonitorCheckerMock{})
+	f.InitRoute(app)
+
+	t.Run("감시 대상 목록 조회", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp []monitorResponse
+			err := sendReqeust(app, "/monitors", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 1)
+			assert.Equal(t, "css", resp[0].Kind)
+		})
+	})
+
+	t.Run("감시 대상 조회", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp monitorResponse
+			err := sendReqeust(app, "/monitors/1", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Equal(t, "2024-09-03 09:00:00", resp.LastCheckedAt)
+		})
+	})
+
+	t.Run("감시 대상 추가", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			param := MonitorReq{
+				Name:     "공지",
+				Url:      "https://example.com/api",
+				Kind:     "json",
+				Selector: "data.items[0].title",
+			}
+			err := sendReqeust(app, "/monitors", "POST", param, nil)
+			assert.NoError(t, err)
+		})
+
+		t.Run("실패 테스트 - 잘못된 주기", func(t *testing.T) {
+			param := MonitorReq{
+				Name:     "공지",
+				Url:      "https://example.com/api",
+				Selector: "div",
+				Spec:     "매일",
+			}
+			err := sendReqeust(app, "/monitors", "POST", param, nil)
+			assert.Error(t, err)
+		})
+
+		t.Run("실패 테스트 - 잘못된 방식", func(t *testing.T) {
+			param := MonitorReq{
+				Name:     "공지",
+				Url:      "https://example.com/api",
+				Kind:     "xpath",
+				Selector: "div",
+			}
+			err := sendReqeust(app, "/monitors", "POST", param, nil)
+			assert.Error(t, err)
+		})
+
+		t.Run("실패 테스트 - 필수 파라미터 미존재", func(t *testing.T) {
+			param := MonitorReq{
+				Name: "공지",
+				Url:  "https://example.com/api",
+			}
+			err := sendReqeust(app, "/monitors", "POST", param, nil)
+			assert.Error(t, err)
+		})
+	})
+
+	t.Run("감시 대상 갱신", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			active := false
+			param := MonitorReq{
+				Name:     "재개발",
+				Url:      "https://example.com",
+				Selector: "div.step",
+				Expected: "구역 지정",
+				Active:   &active,
+			}
+			err := sendReqeust(app, "/monitors/1", "PUT", param, nil)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("감시 대상 삭제", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			err := sendReqeust(app, "/monitors/1", "DELETE", nil, nil)
+			assert.NoError(t, err)
+		})
+	})
+
+	t.Run("감시 이력 조회", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp []monitorHistResponse
+			err := sendReqeust(app, "/monitors/1/hist?limit=5", "GET", nil, &resp)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 2)
+			assert.True(t, resp[0].Changed)
+		})
+	})
+
+	t.Run("즉시 확인", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			var resp monitorHistResponse
+			err := sendReqeust(app, "/monitors/1/check", "POST", nil, &resp)
+			assert.NoError(t, err)
+			assert.Equal(t, "구역 지정", resp.Value)
+			assert.NotEmpty(t, resp.Alert)
+		})
+	})
+}
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	m "invest/model"
+	"invest/openapi"
+)
+
+const textResponse = "" // text/plain 응답
+
+var (
+	dateFrom = openapi.Param{Name: "from", Type: "", Description: "시작일. YYYY-MM-DD"}
+	dateTo   = openapi.Param{Name: "to", Type: "", Description: "종료일. YYYY-MM-DD"}
+)
+
+/*
+Routes
+핸들러 라우트 API 명세. InitRoute 변경 시 함께 수정
+  - 선택 경로 파라미터(/market/:date?)는 경로별로 분리
+  - Scope 미입력 시 GET은 read, 그 외 write
+*/
+func Routes() []openapi.Route {
+
+	routes := []openapi.Route{
+		// 자산
+		{Method: "POST", Path: "/assets", ID: "addAsset", Summary: "자산 추가", Tag: "assets", Body: AddAssetReq{}, Response: textResponse},
+		{Method: "PUT", Path: "/assets", ID: "updateAsset", Summary: "자산 정보 갱신", Tag: "assets", Body: UpdateAssetReq{}, Response: textResponse},
+		{Method: "DELETE", Path: "/assets", ID: "deleteAsset", Summary: "자산 삭제", Tag: "assets", Body: DeleteAssetReq{}, Response: textResponse},
+		{Method: "GET", Path: "/assets/list", ID: "listAssets", Summary: "자산 목록", Tag: "assets", Response: []assetListResponse{}},
+		{Method: "GET", Path: "/assets/{id}", ID: "getAsset", Summary: "자산 정보", Tag: "assets", Response: assetResponse{}},
+		{Method: "GET", Path: "/assets/{id}/hist", ID: "getAssetHist", Summary: "자산 투자 이력", Tag: "assets", Response: []HistResponse{}},
+		{Method: "GET", Path: "/assets/{id}/prices", ID: "getAssetPrices", Summary: "종목 일별 시세. from, to 미입력 시 전체 기간", Tag: "assets",
+			Query: []openapi.Param{dateFrom, dateTo}, Response: []priceResponse{}},
+		{Method: "GET", Path: "/assets/{id}/averages", ID: "getAssetAverages", Summary: "종목 이동평균", Tag: "assets", Response: []averageResponse{}},
+		{Method: "POST", Path: "/assets/{id}/averages", ID: "saveAssetAverages", Summary: "이동평균 설정 저장", Tag: "assets", Body: SaveAveragesReq{}, Response: textResponse},
+		{Method: "POST", Path: "/assets/{id}/averages/recompute", ID: "recomputeAverages", Summary: "이동평균 재산출", Tag: "assets", Response: textResponse},
+
+		// 자금
+		{Method: "GET", Path: "/funds", ID: "totalStatus", Summary: "자금별 평가 금액. 자금 ID별", Tag: "funds", Response: map[string]*TotalStatusResp{}},
+		{Method: "POST", Path: "/funds", ID: "addFund", Summary: "자금 추가", Tag: "funds", Body: AddFundReq{}, Response: textResponse},
+		{Method: "GET", Path: "/funds/{id}/hist", ID: "getFundHist", Summary: "자금 투자 이력", Tag: "funds", Response: []HistResponse{}},
+		{Method: "GET", Path: "/funds/{id}/assets", ID: "getFundAssets", Summary: "자금별 보유 자산", Tag: "funds", Response: []fundAssetsResponse{}},
+		{Method: "GET", Path: "/funds/{id}/priorities", ID: "getFundPriorities", Summary: "자금별 매도/매수 우선순위", Tag: "funds",
+			Query:    []openapi.Param{{Name: "side", Type: "", Description: "sell(기본), buy"}},
+			Response: []priorityResponse{}},
+		{Method: "POST", Path: "/funds/{id}/weights", ID: "saveScoreWeights", Summary: "우선순위 점수 가중치 저장", Tag: "funds", Body: SaveScoreWeightsReq{}, Response: textResponse},
+
+		// 투자
+		{Method: "POST", Path: "/invest", ID: "saveInvest", Summary: "투자 이력 저장", Tag: "invests", Body: SaveInvestParam{}, Response: textResponse},
+		{Method: "GET", Path: "/invests", ID: "listInvests", Summary: "투자 이력 조회. 조건, 정렬, 커서 기반 페이지", Tag: "invests",
+			Query: []openapi.Param{
+				{Name: "fund", Type: 0, Description: "자금 ID"},
+				{Name: "asset", Type: 0, Description: "자산 ID"},
+				{Name: "category", Type: "", Description: "자산 분류. 번호 혹은 이름"},
+				{Name: "side", Type: "", Description: "buy, sell"},
+				{Name: "from", Type: "", Description: "시작 시각(이상). YYYY-MM-DD 혹은 RFC3339"},
+				{Name: "to", Type: "", Description: "종료 시각(미만). YYYY-MM-DD는 해당 일 포함"},
+				{Name: "sort", Type: "", Description: "created_at(기본), price, amount"},
+				{Name: "order", Type: "", Description: "desc(기본), asc"},
+				{Name: "limit", Type: 0, Description: "1 ~ 200. 기본 50"},
+				{Name: "cursor", Type: "", Description: "이전 응답의 next_cursor"},
+			},
+			Response: investPageResponse{}},
+
+		// 시장
+		{Method: "GET", Path: "/market", ID: "getMarket", Summary: "최근 시장 상태", Tag: "market", Response: m.Market{}},
+		{Method: "GET", Path: "/market/{date}", ID: "getMarketByDate", Summary: "일자별 시장 상태. YYYY-MM-DD", Tag: "market", Response: m.Market{}},
+		{Method: "POST", Path: "/market", ID: "changeMarketStatus", Summary: "시장 상태 저장", Tag: "market", Body: SaveMarketStatusParam{}, Response: textResponse},
+		{Method: "GET", Path: "/market/indicators", ID: "getMarketIndicators", Summary: "최근 시장 지표", Tag: "market", Response: marketIndicatorResponse{}},
+		{Method: "GET", Path: "/market/indicators/{date}", ID: "getMarketIndicatorsByDate", Summary: "일자별 시장 지표. YYYY-MM-DD", Tag: "market", Response: marketIndicatorResponse{}},
+
+		// 시장 지표
+		{Method: "GET", Path: "/indicators", ID: "listIndicators", Summary: "시장 지표 목록", Tag: "indicators", Response: []indicatorResponse{}},
+		{Method: "POST", Path: "/indicators", ID: "addIndicator", Summary: "시장 지표 추가. 응답에 ID 포함", Tag: "indicators", Body: IndicatorReq{}, Response: textResponse},
+		{Method: "GET", Path: "/indicators/{id}", ID: "getIndicator", Summary: "시장 지표", Tag: "indicators", Response: indicatorResponse{}},
+		{Method: "PUT", Path: "/indicators/{id}", ID: "updateIndicator", Summary: "시장 지표 갱신", Tag: "indicators", Body: IndicatorReq{}, Response: textResponse},
+		{Method: "DELETE", Path: "/indicators/{id}", ID: "deleteIndicator", Summary: "시장 지표 삭제", Tag: "indicators", Response: textResponse},
+		{Method: "GET", Path: "/indicators/{id}/values", ID: "getIndicatorValues", Summary: "시장 지표 값. from, to 미입력 시 전체 기간", Tag: "indicators",
+			Query: []openapi.Param{dateFrom, dateTo}, Response: []indicatorValueResponse{}},
+		{Method: "GET", Path: "/indicators/{id}/alerts", ID: "listIndicatorAlerts", Summary: "지표 알림 목록", Tag: "indicators", Response: []indicatorAlertResponse{}},
+		{Method: "POST", Path: "/indicators/{id}/alerts", ID: "addIndicatorAlert", Summary: "지표 알림 추가. 응답에 ID 포함", Tag: "indicators", Body: IndicatorAlertReq{}, Response: textResponse},
+		{Method: "PUT", Path: "/indicators/{id}/alerts/{alertId}", ID: "updateIndicatorAlert", Summary: "지표 알림 갱신", Tag: "indicators", Body: IndicatorAlertReq{}, Response: textResponse},
+		{Method: "DELETE", Path: "/indicators/{id}/alerts/{alertId}", ID: "deleteIndicatorAlert", Summary: "지표 알림 삭제", Tag: "indicators", Response: textResponse},
+
+		// 감시 대상
+		{Method: "GET", Path: "/monitors", ID: "listMonitors", Summary: "감시 대상 목록", Tag: "monitors", Response: []monitorResponse{}},
+		{Method: "POST", Path: "/monitors", ID: "addMonitor", Summary: "감시 대상 추가. 응답에 ID 포함", Tag: "monitors", Body: MonitorReq{}, Response: textResponse},
+		{Method: "GET", Path: "/monitors/{id}", ID: "getMonitor", Summary: "감시 대상", Tag: "monitors", Response: monitorResponse{}},
+		{Method: "PUT", Path: "/monitors/{id}", ID: "updateMonitor", Summary: "감시 대상 갱신", Tag: "monitors", Body: MonitorReq{}, Response: textResponse},
+		{Method: "DELETE", Path: "/monitors/{id}", ID: "deleteMonitor", Summary: "감시 대상 삭제", Tag: "monitors", Response: textResponse},
+		{Method: "GET", Path: "/monitors/{id}/hist", ID: "getMonitorHist", Summary: "감시 대상 확인 이력", Tag: "monitors",
+			Query:    []openapi.Param{{Name: "limit", Type: 0, Description: "기본 20"}},
+			Response: []monitorHistResponse{}},
+		{Method: "POST", Path: "/monitors/{id}/check", ID: "checkMonitor", Summary: "감시 대상 즉시 확인. 확인 결과와 알림 메시지", Tag: "monitors", Response: monitorHistResponse{}},
+	}
+
+	for i, r := range routes {
+		if r.Scope != "" {
+			continue
+		}
+		routes[i].Scope = string(m.WriteScope)
+		if r.Method == "GET" {
+			routes[i].Scope = string(m.ReadScope)
+		}
+	}
+	return routes
+}
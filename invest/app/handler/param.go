@@ -16,16 +16,21 @@ type AddFundReq struct {
 	Name string `json:"name" validate:"required"`
 }
 
+// ex) {"weights":{"deviation":0.5,"rsi":0.2,"zscore":0.2,"range52w":0.1}}
+type SaveScoreWeightsReq struct {
+	Weights map[string]float64 `json:"weights" validate:"required"`
+}
+
 type AddAssetReq struct {
-	Name      string  `json:"name" validate:"required"`
-	Category  uint    `json:"category" validate:"required,category"`
-	Code      string  `json:"code"`
-	Currency  string  `json:"currency" validate:"required"`
-	Top       float64 `json:"top"`
-	Bottom    float64 `json:"bottom"`
-	Ema       float64 `json:"ema"`
-	SellPrice float64 `json:"sel_price"`
-	BuyPrice  float64 `json:"buy_price"`
+	Name      string   `json:"name" validate:"required"`
+	Category  uint     `json:"category" validate:"required,category"`
+	Code      string   `json:"code"`
+	Currency  string   `json:"currency" validate:"required"`
+	Top       float64  `json:"top"`
+	Bottom    float64  `json:"bottom"`
+	SellPrice float64  `json:"sel_price"`
+	BuyPrice  float64  `json:"buy_price"`
+	Averages  []string `json:"averages"`
 }
 
 type UpdateAssetReq struct {
@@ -40,6 +45,40 @@ type UpdateAssetReq struct {
 	BuyPrice  float64 `json:"buy_price"`
 }
 
+// ex) {"averages":["EMA20","SMA60","EMA200"],"reference":"EMA200"}
+type SaveAveragesReq struct {
+	Averages  []string `json:"averages" validate:"required"`
+	Reference string   `json:"reference"`
+}
+
+// 추가/갱신 공용. spec 미입력 시 15분 주기, active 미입력 시 활성
+type MonitorReq struct {
+	Name     string `json:"name" validate:"required"`
+	Url      string `json:"url" validate:"required,url"`
+	Kind     string `json:"kind"`
+	Selector string `json:"selector" validate:"required"`
+	Spec     string `json:"spec"`
+	Expected string `json:"expected"`
+	Active   *bool  `json:"active"`
+}
+
+type IndicatorReq struct {
+	Name     string `json:"name" validate:"required"`
+	Provider string `json:"provider" validate:"required"`
+	Code     string `json:"code"`
+	Spec     string `json:"spec"`
+	Unit     string `json:"unit"`
+	Active   *bool  `json:"active"`
+}
+
+type IndicatorAlertReq struct {
+	Kind      string  `json:"kind" validate:"required"`
+	Threshold float64 `json:"threshold"`
+	Days      int     `json:"days"`
+	Period    int     `json:"period"`
+	Active    *bool   `json:"active"`
+}
+
 type DeleteAssetReq struct {
 	ID uint `json:"id" validate:"required"`
 }
@@ -85,6 +124,26 @@ type HistResponse struct {
 	CreatedAt string  `json:"created_at"`
 }
 
+// amount : 거래 대금(price * count). 매도는 음수
+type investResponse struct {
+	ID        uint    `json:"id"`
+	FundId    uint    `json:"fund_id"`
+	AssetId   uint    `json:"asset_id"`
+	AssetName string  `json:"asset_name"`
+	Category  string  `json:"category"`
+	Side      string  `json:"side"`
+	Price     float64 `json:"price"`
+	Count     float64 `json:"count"`
+	Amount    float64 `json:"amount"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// next_cursor : 다음 페이지 조회용. 마지막 페이지면 생략
+type investPageResponse struct {
+	Items      []investResponse `json:"items"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
 type fundAssetsResponse struct {
 	FundId    uint    `json:"fund_id"`
 	AssetId   uint    `json:"asset_id"`
@@ -92,3 +151,101 @@ type fundAssetsResponse struct {
 	Count     float64 `json:"count"`
 	Sum       float64 `json:"sum"`
 }
+
+type priceResponse struct {
+	Date   string  `json:"date"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Volume float64 `json:"volume"`
+}
+
+type averageResponse struct {
+	Average   string  `json:"average"`
+	Reference bool    `json:"reference"`
+	Date      string  `json:"date"`
+	Value     float64 `json:"value"`
+}
+
+type priorityResponse struct {
+	Rank         int                `json:"rank"`
+	AssetId      uint               `json:"asset_id"`
+	AssetName    string             `json:"asset_name"`
+	PresentPrice float64            `json:"present_price"`
+	AveragePrice float64            `json:"average_price"`
+	HighestPrice float64            `json:"highest_price"`
+	Score        float64            `json:"score"`
+	Breakdown    map[string]float64 `json:"breakdown"`
+}
+
+type monitorResponse struct {
+	ID            uint   `json:"id"`
+	Name          string `json:"name"`
+	Url           string `json:"url"`
+	Kind          string `json:"kind"`
+	Selector      string `json:"selector"`
+	Spec          string `json:"spec"`
+	Expected      string `json:"expected"`
+	Active        bool   `json:"active"`
+	LastValue     string `json:"last_value"`
+	LastCheckedAt string `json:"last_checked_at"`
+}
+
+type monitorHistResponse struct {
+	Value     string `json:"value"`
+	Changed   bool   `json:"changed"`
+	Error     string `json:"error,omitempty"`
+	CheckedAt string `json:"checked_at"`
+	Alert     string `json:"alert,omitempty"`
+}
+
+type indicatorResponse struct {
+	ID              uint   `json:"id"`
+	Name            string `json:"name"`
+	Provider        string `json:"provider"`
+	Code            string `json:"code"`
+	Spec            string `json:"spec"`
+	Unit            string `json:"unit"`
+	Active          bool   `json:"active"`
+	LastCollectedAt string `json:"last_collected_at"`
+}
+
+type indicatorAlertResponse struct {
+	ID              uint    `json:"id"`
+	Kind            string  `json:"kind"`
+	Threshold       float64 `json:"threshold"`
+	Days            int     `json:"days,omitempty"`
+	Period          int     `json:"period,omitempty"`
+	Description     string  `json:"description"`
+	Active          bool    `json:"active"`
+	Triggered       bool    `json:"triggered"`
+	LastTriggeredAt string  `json:"last_triggered_at"`
+}
+
+type indicatorValueResponse struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+// 전일 값 미존재 시 previous, diff, rate 생략
+type indicatorChangeResponse struct {
+	ID       uint     `json:"id"`
+	Name     string   `json:"name"`
+	Unit     string   `json:"unit,omitempty"`
+	Date     string   `json:"date"`
+	Value    float64  `json:"value"`
+	Previous *float64 `json:"previous,omitempty"`
+	Diff     *float64 `json:"diff,omitempty"`
+	Rate     *float64 `json:"rate,omitempty"`
+}
+
+type cliResponse struct {
+	Month string  `json:"month"`
+	Index float64 `json:"index"`
+}
+
+type marketIndicatorResponse struct {
+	Indicators []indicatorChangeResponse `json:"indicators"`
+	Cli        *cliResponse              `json:"cli"`
+}
@@ -1,11 +1,9 @@
 package handler
 
 import (
-	"errors"
 	"fmt"
 	"invest/model"
 	"regexp"
-	"strings"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -38,28 +36,32 @@ func init() {
 	})
 }
 
+// 유효성 검사 실패 시 필드별 실패 내역(m.ValidationError) 반환
 func validCheck(s any) error {
-	if errs := validate(s); len(errs) > 0 && errs[0].Error {
-		errMsgs := make([]string, 0)
-		for _, err := range errs {
-			if err.Tag == "required" {
-				errMsgs = append(errMsgs, fmt.Sprintf(
-					"필수 필드 %s 누락",
-					err.FailedField,
-				))
-			} else {
-				errMsgs = append(errMsgs, fmt.Sprintf(
-					"%s 타입의 필드 %s가 올바르지 않음",
-					err.Tag,
-					err.FailedField,
-				))
-			}
-		}
-		return errors.New(strings.Join(errMsgs, ", "))
+	errs := validate(s)
+	if len(errs) == 0 || !errs[0].Error {
+		return nil
+	}
+
+	fields := make([]model.FieldError, len(errs))
+	for i, err := range errs {
+		fields[i] = model.FieldError{Field: err.FailedField, Tag: err.Tag, Value: err.Value}
 	}
-	return nil
+	return &model.ValidationError{Fields: fields}
+}
+
+// 요청 파라미터 오류. m.ErrInvalid로 분류되어 400 응답
+func invalid(format string, args ...any) error {
+	return invalidError{fmt.Errorf(format, args...)}
+}
+
+type invalidError struct {
+	err error
 }
 
+func (e invalidError) Error() string   { return e.err.Error() }
+func (e invalidError) Unwrap() []error { return []error{model.ErrInvalid, e.err} }
+
 func validate(data any) []ErrorResponse {
 	validationErrors := []ErrorResponse{}
 	errs := myValidator.Struct(data)
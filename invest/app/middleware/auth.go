@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"invest/auth"
+	m "invest/model"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type KeyStore interface {
+	RetrieveApiKeyByHash(hash string) (*m.ApiKey, error)
+	TouchApiKey(id uint, at time.Time) error
+}
+
+// 요청 주체. 인증 후 c.Locals(principalKey)로 조회
+type Principal struct {
+	Name  string
+	Scope m.Scope
+}
+
+const principalKey = "principal"
+
+// 브라우저(대시보드) 인증 쿠키. 값은 API 키 혹은 JWT
+const CookieName = "invest_key"
+
+// 최근 사용 시각 갱신 간격. 요청마다 갱신하지 않도록 제한
+const touchInterval = time.Minute
+
+/*
+Authenticator
+API 키(X-API-Key 혹은 Authorization: Bearer) 혹은 JWT(Authorization: Bearer) 인증
+  - 헤더가 없으면 인증 쿠키(CookieName) 사용
+  - secret 미설정 시 JWT 미허용
+*/
+type Authenticator struct {
+	keys   KeyStore
+	secret []byte
+	now    func() time.Time
+}
+
+func NewAuthenticator(keys KeyStore, secret []byte) *Authenticator {
+	return &Authenticator{keys: keys, secret: secret, now: time.Now}
+}
+
+/*
+Handler
+전체 라우트 인증. 요청 방식별 필요 권한 확인
+  - GET, HEAD : read
+  - 그 외 : write
+*/
+func (a *Authenticator) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+
+		p, err := a.authenticate(c)
+		if err != nil {
+			return err
+		}
+		c.Locals(principalKey, p)
+
+		required := m.WriteScope
+		if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+			required = m.ReadScope
+		}
+		err = check(p, required)
+		if err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// 라우트별 추가 권한 확인. Handler 이후에 사용
+func Require(scope m.Scope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		p, ok := c.Locals(principalKey).(*Principal)
+		if !ok {
+			return fmt.Errorf("%w. 인증 정보 미존재", m.ErrUnauthorized)
+		}
+		err := check(p, scope)
+		if err != nil {
+			return err
+		}
+		return c.Next()
+	}
+}
+
+// 인증 후 요청 주체. 인증 전이면 nil
+func PrincipalOf(c *fiber.Ctx) *Principal {
+	p, _ := c.Locals(principalKey).(*Principal)
+	return p
+}
+
+func check(p *Principal, required m.Scope) error {
+	if !p.Scope.Allows(required) {
+		return fmt.Errorf("%w. %s 권한 필요. %s : %s", m.ErrForbidden, required, p.Name, p.Scope)
+	}
+	return nil
+}
+
+func (a *Authenticator) authenticate(c *fiber.Ctx) (*Principal, error) {
+
+	credential := c.Get("X-API-Key")
+	if credential == "" {
+		scheme, token, _ := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+		if strings.EqualFold(scheme, "Bearer") {
+			credential = strings.TrimSpace(token)
+		}
+	}
+	if credential == "" {
+		credential = c.Cookies(CookieName)
+	}
+	return a.Verify(credential)
+}
+
+// API 키 혹은 JWT 확인. 대시보드 로그인 시 쿠키 발급 전 확인에도 사용
+func (a *Authenticator) Verify(credential string) (*Principal, error) {
+
+	if credential == "" {
+		return nil, fmt.Errorf("%w. API 키 혹은 토큰 미존재", m.ErrUnauthorized)
+	}
+
+	if auth.IsToken(credential) {
+		claims, err := auth.Parse(a.secret, credential, a.now())
+		if err != nil {
+			return nil, err
+		}
+		return &Principal{Name: claims.Subject, Scope: claims.Scope}, nil
+	}
+
+	key, err := a.keys.RetrieveApiKeyByHash(auth.Hash(credential))
+	if errors.Is(err, m.ErrNotFound) {
+		return nil, fmt.Errorf("%w. 등록되지 않았거나 폐기된 API 키", m.ErrUnauthorized)
+	} else if err != nil {
+		return nil, fmt.Errorf("RetrieveApiKeyByHash 시 오류 발생. %w", err)
+	}
+
+	now := a.now()
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+		err = a.keys.TouchApiKey(key.ID, now)
+		if err != nil {
+			log.Printf("[API] 키 %s 사용 시각 갱신 실패. %s", key.Prefix, err)
+		}
+	}
+
+	return &Principal{Name: key.Name, Scope: key.Scope}, nil
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"invest/auth"
+	m "invest/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticator(t *testing.T) {
+
+	now := time.Now()
+	revokedAt := now.Add(-time.Hour)
+	usedAt := now.Add(-time.Second)
+	store := &KeyStoreMock{keys: map[string]m.ApiKey{
+		auth.Hash("inv_read"):    {ID: 1, Name: "bot", Prefix: "inv_read", Scope: m.ReadScope},
+		auth.Hash("inv_write"):   {ID: 2, Name: "app", Prefix: "inv_writ", Scope: m.WriteScope, LastUsedAt: &usedAt},
+		auth.Hash("inv_admin"):   {ID: 3, Name: "ops", Prefix: "inv_admi", Scope: m.AdminScope},
+		auth.Hash("inv_revoked"): {ID: 4, Name: "old", Prefix: "inv_revo", Scope: m.AdminScope, RevokedAt: &revokedAt},
+	}}
+	secret := []byte("secret")
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	SetupMiddleware(app)
+	app.Use(NewAuthenticator(store, secret).Handler())
+	app.Get("/assets", func(c *fiber.Ctx) error { return c.SendString(c.Locals(principalKey).(*Principal).Name) })
+	app.Post("/assets", func(c *fiber.Ctx) error { return c.SendString("ok") })
+	app.Post("/shutdown", Require(m.AdminScope), func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	request := func(method string, path string, header map[string]string) (int, string) {
+		req := httptest.NewRequest(method, path, nil)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := app.Test(req, -1)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		if resp.StatusCode == fiber.StatusOK {
+			var b [64]byte
+			n, _ := resp.Body.Read(b[:])
+			return resp.StatusCode, string(b[:n])
+		}
+		var body ErrorBody
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body.Code
+	}
+	key := func(k string) map[string]string { return map[string]string{"X-API-Key": k} }
+	bearer := func(k string) map[string]string { return map[string]string{"Authorization": "Bearer " + k} }
+
+	t.Run("인증 정보 미존재", func(t *testing.T) {
+		status, code := request(http.MethodGet, "/assets", nil)
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+		assert.Equal(t, CodeUnauthorized, code)
+	})
+
+	t.Run("API 키", func(t *testing.T) {
+		status, name := request(http.MethodGet, "/assets", key("inv_read"))
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "bot", name)
+
+		status, _ = request(http.MethodGet, "/assets", bearer("inv_write"))
+		assert.Equal(t, fiber.StatusOK, status)
+
+		for _, k := range []string{"inv_unknown", "inv_revoked"} {
+			status, code := request(http.MethodGet, "/assets", key(k))
+			assert.Equal(t, fiber.StatusUnauthorized, status, k)
+			assert.Equal(t, CodeUnauthorized, code, k)
+		}
+	})
+
+	t.Run("인증 쿠키", func(t *testing.T) {
+		status, name := request(http.MethodGet, "/assets", map[string]string{"Cookie": CookieName + "=inv_admin"})
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "ops", name)
+
+		status, name = request(http.MethodGet, "/assets", map[string]string{"Cookie": CookieName + "=inv_admin", "X-API-Key": "inv_read"})
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "bot", name) // 헤더 우선
+	})
+
+	t.Run("요청 방식별 권한", func(t *testing.T) {
+		status, code := request(http.MethodPost, "/assets", key("inv_read"))
+		assert.Equal(t, fiber.StatusForbidden, status)
+		assert.Equal(t, CodeForbidden, code)
+
+		status, _ = request(http.MethodPost, "/assets", key("inv_write"))
+		assert.Equal(t, fiber.StatusOK, status)
+	})
+
+	t.Run("서버 종료는 admin 권한", func(t *testing.T) {
+		status, code := request(http.MethodPost, "/shutdown", key("inv_write"))
+		assert.Equal(t, fiber.StatusForbidden, status)
+		assert.Equal(t, CodeForbidden, code)
+
+		status, _ = request(http.MethodPost, "/shutdown", key("inv_admin"))
+		assert.Equal(t, fiber.StatusOK, status)
+	})
+
+	t.Run("JWT", func(t *testing.T) {
+		token, _ := auth.Issue(secret, "dashboard", m.WriteScope, time.Minute, time.Now())
+		status, name := request(http.MethodGet, "/assets", bearer(token))
+		assert.Equal(t, fiber.StatusOK, status)
+		assert.Equal(t, "dashboard", name)
+
+		status, _ = request(http.MethodPost, "/shutdown", bearer(token))
+		assert.Equal(t, fiber.StatusForbidden, status)
+
+		expired, _ := auth.Issue(secret, "dashboard", m.AdminScope, time.Minute, time.Now().Add(-time.Hour))
+		status, code := request(http.MethodGet, "/assets", bearer(expired))
+		assert.Equal(t, fiber.StatusUnauthorized, status)
+		assert.Equal(t, CodeUnauthorized, code)
+	})
+
+	t.Run("최근 사용 시각은 간격마다 갱신", func(t *testing.T) {
+		assert.Contains(t, store.touched, uint(1))
+		assert.NotContains(t, store.touched, uint(2))
+	})
+
+	t.Run("키 조회 실패", func(t *testing.T) {
+		store.err = errors.New("connection refused")
+		defer func() { store.err = nil }()
+
+		status, code := request(http.MethodGet, "/assets", key("inv_read"))
+		assert.Equal(t, fiber.StatusInternalServerError, status)
+		assert.Equal(t, CodeInternal, code)
+	})
+}
@@ -1,6 +1,40 @@
 package middleware
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"errors"
+	m "invest/model"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// 오류 코드
+const (
+	CodeInvalid      = "INVALID_REQUEST"
+	CodeUnauthorized = "UNAUTHORIZED"
+	CodeForbidden    = "FORBIDDEN"
+	CodeNotFound     = "NOT_FOUND"
+	CodeConflict     = "CONFLICT"
+	CodeInternal     = "INTERNAL_ERROR"
+)
+
+/*
+오류 응답 본문
+  - code : 오류 코드. 상태 코드별 고정 값
+  - fields : 유효성 검사 실패 필드. 400 응답에만 포함
+*/
+type ErrorBody struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Value   any    `json:"value,omitempty"`
+	Message string `json:"message"`
+}
 
 func SetupMiddleware(router fiber.Router) {
 
@@ -11,7 +45,81 @@ func errorHandle(c *fiber.Ctx) error {
 
 	err := c.Next()
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		return ErrorHandler(c, err)
 	}
 	return nil
 }
+
+/*
+ErrorHandler
+도메인 오류를 상태 코드와 JSON 본문으로 변환. fiber.Config의 ErrorHandler로도 사용
+  - m.ErrInvalid : 400. 유효성 검사 실패 시 필드별 내역 포함
+  - m.ErrUnauthorized : 401
+  - m.ErrForbidden : 403
+  - m.ErrNotFound : 404
+  - m.ErrConflict : 409
+  - fiber.Error : 해당 상태 코드 (라우트 미존재 404 등)
+  - 그 외 : 500
+*/
+func ErrorHandler(c *fiber.Ctx, err error) error {
+
+	status, body := Classify(err)
+	if status == fiber.StatusInternalServerError {
+		log.Printf("[API] %s %s. %s", c.Method(), c.Path(), err)
+	}
+	return c.Status(status).JSON(body)
+}
+
+// 오류별 상태 코드와 응답 본문. JSON 외 응답(대시보드 오류 페이지)에도 사용
+func Classify(err error) (int, ErrorBody) {
+
+	body := ErrorBody{Message: err.Error()}
+
+	var fe *fiber.Error
+	switch {
+	case errors.Is(err, m.ErrInvalid):
+		body.Code = CodeInvalid
+		var ve *m.ValidationError
+		if errors.As(err, &ve) {
+			body.Fields = make([]FieldError, len(ve.Fields))
+			for i, f := range ve.Fields {
+				body.Fields[i] = FieldError{Field: f.Field, Tag: f.Tag, Value: f.Value, Message: f.String()}
+			}
+		}
+		return fiber.StatusBadRequest, body
+	case errors.Is(err, m.ErrUnauthorized):
+		body.Code = CodeUnauthorized
+		return fiber.StatusUnauthorized, body
+	case errors.Is(err, m.ErrForbidden):
+		body.Code = CodeForbidden
+		return fiber.StatusForbidden, body
+	case errors.Is(err, m.ErrNotFound):
+		body.Code = CodeNotFound
+		return fiber.StatusNotFound, body
+	case errors.Is(err, m.ErrConflict):
+		body.Code = CodeConflict
+		return fiber.StatusConflict, body
+	case errors.As(err, &fe):
+		body.Code = codeOf(fe.Code)
+		return fe.Code, body
+	}
+
+	body.Code = CodeInternal
+	return fiber.StatusInternalServerError, body
+}
+
+func codeOf(status int) string {
+	switch {
+	case status == fiber.StatusUnauthorized:
+		return CodeUnauthorized
+	case status == fiber.StatusForbidden:
+		return CodeForbidden
+	case status == fiber.StatusNotFound:
+		return CodeNotFound
+	case status == fiber.StatusConflict:
+		return CodeConflict
+	case status >= 400 && status < 500:
+		return CodeInvalid
+	}
+	return CodeInternal
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	m "invest/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorHandler(t *testing.T) {
+
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	SetupMiddleware(app)
+
+	routes := map[string]error{
+		"/invalid": fmt.Errorf("파라미터 유효성 검사 시 오류 발생. %w", &m.ValidationError{Fields: []m.FieldError{
+			{Field: "Name", Tag: "required"},
+			{Field: "Category", Tag: "category", Value: 9},
+		}}),
+		"/notfound": fmt.Errorf("RetrieveAsset 오류 발생. %w", m.ErrNotFound),
+		"/conflict": fmt.Errorf("SaveFund 시 오류 발생. %w", m.ErrConflict),
+		"/internal": errors.New("connection refused"),
+		"/fiber":    fiber.ErrUnprocessableEntity,
+	}
+	for path, err := range routes {
+		app.Get(path, func(c *fiber.Ctx) error { return err })
+	}
+
+	request := func(path string) (int, ErrorBody) {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil), -1)
+		assert.NoError(t, err)
+		defer resp.Body.Close()
+
+		var body ErrorBody
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	t.Run("유효성 검사 실패 필드 내역", func(t *testing.T) {
+		status, body := request("/invalid")
+		assert.Equal(t, fiber.StatusBadRequest, status)
+		assert.Equal(t, CodeInvalid, body.Code)
+		assert.Equal(t, "파라미터 유효성 검사 시 오류 발생. 필수 필드 Name 누락, category 타입의 필드 Category가 올바르지 않음", body.Message)
+		assert.Equal(t, []FieldError{
+			{Field: "Name", Tag: "required", Message: "필수 필드 Name 누락"},
+			{Field: "Category", Tag: "category", Value: float64(9), Message: "category 타입의 필드 Category가 올바르지 않음"},
+		}, body.Fields)
+	})
+
+	t.Run("도메인 오류별 상태 코드", func(t *testing.T) {
+		for path, expected := range map[string]struct {
+			status int
+			code   string
+		}{
+			"/notfound": {fiber.StatusNotFound, CodeNotFound},
+			"/conflict": {fiber.StatusConflict, CodeConflict},
+			"/internal": {fiber.StatusInternalServerError, CodeInternal},
+			"/fiber":    {fiber.StatusUnprocessableEntity, CodeInvalid},
+			"/none":     {fiber.StatusNotFound, CodeNotFound}, // 라우트 미존재
+		} {
+			status, body := request(path)
+			assert.Equal(t, expected.status, status, path)
+			assert.Equal(t, expected.code, body.Code, path)
+			assert.Empty(t, body.Fields, path)
+		}
+	})
+}
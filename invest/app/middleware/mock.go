@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	m "invest/model"
+	"time"
+)
+
+/***************************** ApiKey ***********************************/
+type KeyStoreMock struct {
+	keys    map[string]m.ApiKey // 해시별 키
+	touched []uint
+	err     error
+}
+
+func (mock *KeyStoreMock) RetrieveApiKeyByHash(hash string) (*m.ApiKey, error) {
+
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	key, ok := mock.keys[hash]
+	if !ok || key.RevokedAt != nil {
+		return nil, m.ErrNotFound
+	}
+	return &key, nil
+}
+
+func (mock *KeyStoreMock) TouchApiKey(id uint, at time.Time) error {
+	mock.touched = append(mock.touched, id)
+	return nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"invest/app/handler"
+	"invest/app/middleware"
+	m "invest/model"
+	"invest/openapi"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type shutdownResponse struct {
+	Message string `json:"message"`
+}
+
+/*
+OpenAPI
+API 명세. 핸들러 라우트(handler.Routes)와 서버 라우트
+  - 오류 응답은 middleware.ErrorBody
+  - 명세 문서(/openapi.json, /docs)는 제외
+*/
+func OpenAPI() *openapi.Document {
+	return openapi.New(openapi.Info{
+		Title:       "invest API",
+		Version:     "1.0.0",
+		Description: "자산, 자금, 투자 이력, 시장 지표, 감시 대상 관리 API",
+	}).
+		Error(middleware.ErrorBody{}).
+		Add(handler.Routes()...).
+		Add(openapi.Route{
+			Method: "POST", Path: "/shutdown", ID: "shutdown", Summary: "서버 종료. 진행 중 요청 완료 후 종료", Tag: "server",
+			Scope: string(m.AdminScope), Response: shutdownResponse{},
+		}).
+		Document()
+}
+
+/*
+명세 문서 라우트. 인증 없이 조회 가능하도록 인증 미들웨어보다 먼저 등록
+  - /openapi.json : OpenAPI 3 문서
+  - /docs : 문서 페이지
+*/
+func docsRoute(app *fiber.App) {
+
+	doc := OpenAPI()
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+	var page bytes.Buffer
+	err = doc.HTML(&page)
+	if err != nil {
+		panic(err)
+	}
+
+	app.Get("/openapi.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(spec)
+	})
+	app.Get("/docs", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.Send(page.Bytes())
+	})
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	m "invest/model"
+	"strings"
+	"time"
+)
+
+// API 키 접두어. 토큰과 구분
+const keyPrefix = "inv_"
+
+/*
+NewKey
+API 키 발급
+  - key : 요청 헤더에 사용하는 원문. 발급 시에만 확인 가능
+  - prefix : 목록 조회 시 식별용 앞부분
+  - hash : 저장용 해시
+*/
+func NewKey() (key string, prefix string, hash string, err error) {
+
+	b := make([]byte, 24)
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", "", "", fmt.Errorf("API 키 생성 시 오류 발생. %w", err)
+	}
+
+	key = keyPrefix + base64.RawURLEncoding.EncodeToString(b)
+	return key, key[:len(keyPrefix)+8], Hash(key), nil
+}
+
+// 저장, 조회용 키 해시 (SHA-256 hex)
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// JWT 여부. header.payload.signature 형식
+func IsToken(s string) bool {
+	return strings.Count(s, ".") == 2 && !strings.HasPrefix(s, keyPrefix)
+}
+
+/*
+JWT 클레임
+  - Subject : 발급 대상 이름
+  - ExpiresAt, IssuedAt : unix 초
+*/
+type Claims struct {
+	Subject   string  `json:"sub"`
+	Scope     m.Scope `json:"scope"`
+	IssuedAt  int64   `json:"iat"`
+	ExpiresAt int64   `json:"exp"`
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+var jwtHeader = header{Alg: "HS256", Typ: "JWT"}
+
+// HS256 서명 JWT 발급. ttl 이후 만료
+func Issue(secret []byte, subject string, scope m.Scope, ttl time.Duration, now time.Time) (string, error) {
+
+	if len(secret) == 0 {
+		return "", errors.New("JWT 서명 키 미설정")
+	}
+
+	h, err := json.Marshal(jwtHeader)
+	if err != nil {
+		return "", err
+	}
+	c, err := json.Marshal(Claims{Subject: subject, Scope: scope, IssuedAt: now.Unix(), ExpiresAt: now.Add(ttl).Unix()})
+	if err != nil {
+		return "", err
+	}
+
+	signing := encode(h) + "." + encode(c)
+	return signing + "." + encode(sign(secret, signing)), nil
+}
+
+/*
+Parse
+JWT 서명, 만료 검증 후 클레임 반환
+  - 실패 시 m.ErrUnauthorized로 분류되는 오류
+*/
+func Parse(secret []byte, token string, now time.Time) (*Claims, error) {
+
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("%w. JWT 미허용", m.ErrUnauthorized)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w. 토큰 형식 오류", m.ErrUnauthorized)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, sign(secret, parts[0]+"."+parts[1])) {
+		return nil, fmt.Errorf("%w. 토큰 서명 불일치", m.ErrUnauthorized)
+	}
+
+	var h header
+	err = decode(parts[0], &h)
+	if err != nil || h != jwtHeader {
+		return nil, fmt.Errorf("%w. 지원하지 않는 토큰 헤더", m.ErrUnauthorized)
+	}
+
+	var c Claims
+	err = decode(parts[1], &c)
+	if err != nil {
+		return nil, fmt.Errorf("%w. 토큰 클레임 형식 오류", m.ErrUnauthorized)
+	}
+	if now.Unix() >= c.ExpiresAt {
+		return nil, fmt.Errorf("%w. 만료된 토큰", m.ErrUnauthorized)
+	}
+
+	return &c, nil
+}
+
+func sign(secret []byte, signing string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signing))
+	return mac.Sum(nil)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decode(s string, v any) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
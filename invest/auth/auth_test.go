@@ -0,0 +1,88 @@
+package auth
+
+import (
+	m "invest/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+
+	t.Run("키 발급", func(t *testing.T) {
+		key, prefix, hash, err := NewKey()
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(key, prefix))
+		assert.Len(t, prefix, 12)
+		assert.Equal(t, Hash(key), hash)
+		assert.False(t, IsToken(key))
+
+		other, _, _, _ := NewKey()
+		assert.NotEqual(t, key, other)
+	})
+}
+
+func TestToken(t *testing.T) {
+
+	secret := []byte("secret")
+	now := time.Date(2024, 12, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("발급 후 검증", func(t *testing.T) {
+		token, err := Issue(secret, "dashboard", m.WriteScope, time.Hour, now)
+		assert.NoError(t, err)
+		assert.True(t, IsToken(token))
+
+		c, err := Parse(secret, token, now.Add(59*time.Minute))
+		assert.NoError(t, err)
+		assert.Equal(t, "dashboard", c.Subject)
+		assert.Equal(t, m.WriteScope, c.Scope)
+	})
+
+	t.Run("검증 실패", func(t *testing.T) {
+		token, _ := Issue(secret, "dashboard", m.ReadScope, time.Hour, now)
+		forged, _ := Issue([]byte("other"), "dashboard", m.AdminScope, time.Hour, now)
+		parts := strings.Split(token, ".")
+		forgedParts := strings.Split(forged, ".")
+
+		for name, tc := range map[string]struct {
+			secret []byte
+			token  string
+			at     time.Time
+			msg    string
+		}{
+			"만료":       {secret, token, now.Add(time.Hour), "만료된 토큰"},
+			"서명 키 불일치": {secret, forged, now, "토큰 서명 불일치"},
+			"클레임 변조":   {secret, parts[0] + "." + forgedParts[1] + "." + parts[2], now, "토큰 서명 불일치"},
+			"형식 오류":    {secret, "a.b", now, "토큰 형식 오류"},
+			"JWT 미허용":  {nil, token, now, "JWT 미허용"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := Parse(tc.secret, tc.token, tc.at)
+				assert.ErrorIs(t, err, m.ErrUnauthorized)
+				assert.ErrorContains(t, err, tc.msg)
+			})
+		}
+	})
+
+	t.Run("서명 키 미설정 시 발급 불가", func(t *testing.T) {
+		_, err := Issue(nil, "dashboard", m.ReadScope, time.Hour, now)
+		assert.ErrorContains(t, err, "JWT 서명 키 미설정")
+	})
+}
+
+func TestScope(t *testing.T) {
+
+	assert.True(t, m.AdminScope.Allows(m.WriteScope))
+	assert.True(t, m.WriteScope.Allows(m.ReadScope))
+	assert.False(t, m.ReadScope.Allows(m.WriteScope))
+	assert.False(t, m.Scope("").Allows(m.ReadScope))
+
+	s, err := m.ToScope("Admin")
+	assert.NoError(t, err)
+	assert.Equal(t, m.AdminScope, s)
+
+	_, err = m.ToScope("owner")
+	assert.ErrorContains(t, err, "존재하지 않는 권한 범위")
+}
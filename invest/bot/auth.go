@@ -0,0 +1,60 @@
+package bot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 텔레그램 사용자 권한
+type Role uint
+
+const (
+	RoleNone  Role = iota
+	ReadOnly       // 조회 명령어만 허용
+	ReadWrite      // 저장/변경 명령어 포함 전체 허용
+)
+
+func (r Role) String() string {
+	switch r {
+	case ReadOnly:
+		return "read"
+	case ReadWrite:
+		return "write"
+	default:
+		return "none"
+	}
+}
+
+func ToRole(s string) (Role, error) {
+	switch strings.ToLower(s) {
+	case "read":
+		return ReadOnly, nil
+	case "write":
+		return ReadWrite, nil
+	default:
+		return RoleNone, fmt.Errorf("존재하지 않는 권한. read 혹은 write. 입력 값 : %s", s)
+	}
+}
+
+/*
+허용 목록
+채팅 ID(그룹 채팅 포함) 혹은 사용자 ID별 권한. 둘 다 등록된 경우 높은 권한 적용
+*/
+type acl map[int64]Role
+
+func (a acl) allow(id int64, role Role) {
+	if role > a[id] {
+		a[id] = role
+	}
+}
+
+func (a acl) role(chatId int64, userId int64) Role {
+	return max(a[chatId], a[userId])
+}
+
+// 허용 사용자 추가. NewTeleBot의 chatId는 ReadWrite로 자동 등록
+func WithUser(id int64, role Role) func(*TeleBot) {
+	return func(t *TeleBot) {
+		t.acl.allow(id, role)
+	}
+}
@@ -0,0 +1,69 @@
+package bot
+
+import (
+	"testing"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRole(t *testing.T) {
+
+	t.Run("권한 변환", func(t *testing.T) {
+		role, err := ToRole("Write")
+		assert.NoError(t, err)
+		assert.Equal(t, ReadWrite, role)
+
+		_, err = ToRole("admin")
+		assert.Error(t, err)
+	})
+
+	t.Run("채팅 ID와 사용자 ID 중 높은 권한", func(t *testing.T) {
+		a := acl{1: ReadWrite}
+		a.allow(-100, ReadOnly) // 그룹 채팅
+		a.allow(2, ReadWrite)
+		a.allow(2, ReadOnly) // 낮은 권한으로 덮어쓰지 않음
+
+		assert.Equal(t, ReadOnly, a.role(-100, 3))
+		assert.Equal(t, ReadWrite, a.role(-100, 2))
+		assert.Equal(t, ReadWrite, a.role(1, 1))
+		assert.Equal(t, RoleNone, a.role(4, 4))
+	})
+
+	t.Run("WithUser", func(t *testing.T) {
+		tb := &TeleBot{acl: acl{1: ReadWrite}}
+		WithUser(5, ReadOnly)(tb)
+		assert.Equal(t, ReadOnly, tb.acl.role(5, 5))
+	})
+}
+
+func TestIncoming(t *testing.T) {
+
+	t.Run("텍스트 메시지", func(t *testing.T) {
+		in, ok := incoming(tgbotapi.Update{Message: &tgbotapi.Message{
+			Chat: &tgbotapi.Chat{ID: -100},
+			From: &tgbotapi.User{ID: 2, UserName: "tester"},
+			Text: "/market",
+		}})
+		assert.True(t, ok)
+		assert.Equal(t, message{chatId: -100, userId: 2, userName: "tester", text: "/market"}, in)
+	})
+
+	t.Run("inline keyboard 선택", func(t *testing.T) {
+		in, ok := incoming(tgbotapi.Update{CallbackQuery: &tgbotapi.CallbackQuery{
+			From:    &tgbotapi.User{ID: 2},
+			Message: &tgbotapi.Message{MessageID: 9, Chat: &tgbotapi.Chat{ID: 2}},
+			Data:    "buy",
+		}})
+		assert.True(t, ok)
+		assert.Equal(t, message{chatId: 2, userId: 2, text: "buy", callback: 9}, in)
+	})
+
+	t.Run("텍스트 없는 메시지 제외", func(t *testing.T) {
+		_, ok := incoming(tgbotapi.Update{Message: &tgbotapi.Message{Chat: &tgbotapi.Chat{ID: 1}, Sticker: &tgbotapi.Sticker{}}})
+		assert.False(t, ok)
+
+		_, ok = incoming(tgbotapi.Update{})
+		assert.False(t, ok)
+	})
+}
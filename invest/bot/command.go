@@ -0,0 +1,471 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"invest/client"
+	m "invest/model"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type Storage interface {
+	RetrieveFunds() ([]m.Fund, error)
+	RetrieveAssetList() ([]m.Asset, error)
+	RetrieveAssetIdByName(name string) uint
+	RetrieveAssetIdByCode(code string) uint
+	RetrieveMarketStatus(date string) (*m.Market, error)
+	SaveMarketStatus(status uint) error
+}
+
+type Service interface {
+	RecordInvest(fundId uint, assetId uint, price float64, count float64) error
+	AddAsset(asset m.Asset, averages []m.AssetAverage) (uint, error)
+	FundChart(fundId uint) (*m.Chart, error)
+	RatioChart() (*m.Chart, error)
+	PriceChart(assetId uint, days int) (*m.Chart, error)
+}
+
+// 명령어 외 조회 API 목록. 경로 그대로 입력
+const queryHelp = `
+조회 API (경로 그대로 입력)
+/funds
+/funds/{id}/hist
+/funds/{id}/assets
+/funds/{id}/priorities?side=
+/assets/list
+/assets/{id}
+/assets/{id}/hist
+/assets/{id}/prices?from=&to=
+/assets/{id}/averages
+/market/indicators/{date?}
+/indicators
+/indicators/{id}/values?from=&to=
+/indicators/{id}/alerts
+/monitors
+/monitors/{id}/hist`
+
+func NewRouter(stg Storage, svc Service, opts ...func(*Router)) *Router {
+
+	r := newRouter()
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.register(&command{
+		name:  "/help",
+		usage: "/help {명령어?}",
+		desc:  "명령어 목록 혹은 명령어별 사용법",
+		run: func(a args) (string, error) {
+			name := ""
+			if len(a.pos) > 0 {
+				name = a.pos[0]
+			}
+			return r.help(name), nil
+		},
+	})
+
+	r.register(&command{
+		name:  "/form",
+		usage: "/form",
+		desc:  "API 요청 body 양식",
+		run: func(a args) (string, error) {
+			return formHelp, nil
+		},
+	})
+
+	r.register(&command{
+		name:  "/buy",
+		usage: "/buy fund={자금 ID} asset={자산 ID|이름|코드} {수량}@{가격}",
+		desc:  "매수 이력 저장. 자금의 자산 요약과 현금(원화/달러) 갱신\nex) /buy fund=1 asset=360750 10@12500",
+		write: always,
+		run: func(a args) (string, error) {
+			return invest(stg, svc, a, 1)
+		},
+	})
+
+	r.register(&command{
+		name:  "/sell",
+		usage: "/sell fund={자금 ID} asset={자산 ID|이름|코드} {수량}@{가격}",
+		desc:  "매도 이력 저장. 자금의 자산 요약과 현금(원화/달러) 갱신\nex) /sell fund=1 asset=\"TIGER 미국S&P500\" 10@13000",
+		write: always,
+		run: func(a args) (string, error) {
+			return invest(stg, svc, a, -1)
+		},
+	})
+
+	r.register(&command{
+		name:  "/invests",
+		usage: "/invests fund={자금 ID?} asset={자산 ID|이름|코드?} category={카테고리?} side={buy|sell?} from={시작일?} to={종료일?} sort={created_at|price|amount?} order={desc|asc?} limit={건수?} cursor={다음 페이지?}",
+		desc:  "투자 이력 조회. 최근 이력부터 50건\n다음 페이지는 응답의 next_cursor를 cursor로 입력\nex) /invests asset=360750 side=buy from=2024-01-01",
+		run: func(a args) (string, error) {
+			path, err := investsPath(stg, a)
+			if err != nil {
+				return "", err
+			}
+			return r.fallback(path)
+		},
+	})
+
+	r.register(&command{
+		name:  "/market",
+		usage: "/market {시장 단계 1~5?}",
+		desc:  "시장 단계 조회 혹은 저장\n1 : MAJOR_BEAR, 2 : BEAR, 3 : VOLATILIY, 4 : BULL, 5 : MAJOR_BULL",
+		write: func(a args) bool { return len(a.pos) > 0 },
+		run: func(a args) (string, error) {
+			return market(stg, a)
+		},
+	})
+
+	r.register(&command{
+		name:  "/asset",
+		usage: "/asset add name={이름} category={카테고리 번호|이름} currency={WON|USD} code={코드?} top={최고가?} bottom={최저가?} sell={매도 기준?} buy={매수 기준?} averages={EMA20,EMA200?}",
+		desc:  "자산 추가. 최고/최저가 미입력 시 시세 API 값 사용\nex) /asset add name=\"TIGER 미국S&P500\" category=국내ETF currency=WON code=360750 averages=EMA20,EMA200",
+		write: always,
+		run: func(a args) (string, error) {
+			return asset(svc, a)
+		},
+	})
+
+	r.register(&command{
+		name:  "/chart",
+		usage: "/chart fund {자금 ID} | /chart ratio | /chart price {자산 ID|이름|코드} {기간(일)?}",
+		desc:  "차트 이미지\nfund : 자금 자산 비중\nratio : 자금별 변동 자산 비율과 현재 시장 단계 허용 범위\nprice : 종가와 이동평균 (기본 120일)",
+		draw: func(a args) (*m.Chart, error) {
+			return chart(stg, svc, a)
+		},
+	})
+
+	r.registerForm(investForm(stg, svc))
+	r.registerForm(assetForm(svc))
+
+	r.register(&command{
+		name:  "/watch",
+		usage: "/watch {Monitor 양식 JSON}",
+		desc:  "웹 페이지 감시 대상 추가. 양식은 /form 참고",
+		write: always,
+		run: func(a args) (string, error) {
+			var req client.MonitorReq
+			err := json.Unmarshal([]byte(a.raw), &req)
+			if err != nil {
+				return "", fmt.Errorf("%w. Monitor 양식 JSON 오류. %s", errUsage, err)
+			}
+			return r.api.AddMonitor(context.Background(), req)
+		},
+	})
+
+	r.register(&command{
+		name:  "/unwatch",
+		usage: "/unwatch {감시 대상 ID}",
+		desc:  "웹 페이지 감시 대상 삭제",
+		write: always,
+		run: func(a args) (string, error) {
+			id, err := posUint(a, 0, "감시 대상 ID")
+			if err != nil {
+				return "", err
+			}
+			return r.api.DeleteMonitor(context.Background(), int64(id))
+		},
+	})
+
+	r.register(&command{
+		name:  "/check",
+		usage: "/check {감시 대상 ID}",
+		desc:  "웹 페이지 감시 대상 즉시 확인",
+		write: always,
+		run: func(a args) (string, error) {
+			id, err := posUint(a, 0, "감시 대상 ID")
+			if err != nil {
+				return "", err
+			}
+			hist, err := r.api.CheckMonitor(context.Background(), int64(id))
+			if err != nil {
+				return "", err
+			}
+			return pretty(hist)
+		},
+	})
+
+	return r
+}
+
+// sign : 매수 1, 매도 -1
+func invest(stg Storage, svc Service, a args, sign float64) (string, error) {
+
+	fundId, err := a.uint("fund")
+	if err != nil {
+		return "", err
+	}
+
+	assetArg, err := a.str("asset")
+	if err != nil {
+		return "", err
+	}
+	assetId := findAsset(stg, assetArg)
+	if assetId == 0 {
+		return "", fmt.Errorf("%w. 자산 미존재. 입력 값 : %s", errUsage, assetArg)
+	}
+
+	count, price, err := countAtPrice(a)
+	if err != nil {
+		return "", err
+	}
+
+	err = svc.RecordInvest(fundId, assetId, price, sign*count)
+	if err != nil {
+		return "", err
+	}
+
+	side := "매수"
+	if sign < 0 {
+		side = "매도"
+	}
+	return fmt.Sprintf("%s 이력 저장 성공. 자금 %d, 자산 %s(%d), %g@%g", side, fundId, assetArg, assetId, count, price), nil
+}
+
+// 투자 이력 조회 API 경로. 자산은 이름, 코드도 허용
+func investsPath(stg Storage, a args) (string, error) {
+
+	q := url.Values{}
+	for _, key := range []string{"fund", "category", "side", "from", "to", "sort", "order", "limit", "cursor"} {
+		if v, ok := a.named[key]; ok && v != "" {
+			q.Set(key, v)
+		}
+	}
+	if v, ok := a.named["asset"]; ok && v != "" {
+		assetId := findAsset(stg, v)
+		if assetId == 0 {
+			return "", fmt.Errorf("%w. 자산 미존재. 입력 값 : %s", errUsage, v)
+		}
+		q.Set("asset", strconv.FormatUint(uint64(assetId), 10))
+	}
+
+	if len(q) == 0 {
+		return "/invests", nil
+	}
+	return "/invests?" + q.Encode(), nil
+}
+
+// 자산 ID, 이름, 코드 순으로 조회. 미존재 시 0
+func findAsset(stg Storage, s string) uint {
+
+	if id, err := strconv.ParseUint(s, 10, 64); err == nil {
+		if byCode := stg.RetrieveAssetIdByCode(s); byCode != 0 { // 숫자 코드(국내 종목) 우선
+			return byCode
+		}
+		return uint(id)
+	}
+
+	if id := stg.RetrieveAssetIdByName(s); id != 0 {
+		return id
+	}
+	return stg.RetrieveAssetIdByCode(s)
+}
+
+// "{수량}@{가격}" 혹은 count=, price=
+func countAtPrice(a args) (count float64, price float64, err error) {
+
+	if len(a.pos) > 0 {
+		c, p, ok := strings.Cut(a.pos[0], "@")
+		if !ok {
+			return 0, 0, fmt.Errorf("%w. {수량}@{가격} 형태. 입력 값 : %s", errUsage, a.pos[0])
+		}
+		a.named["count"], a.named["price"] = c, p
+	}
+
+	count, err = a.float("count")
+	if err != nil {
+		return 0, 0, err
+	}
+	price, err = a.float("price")
+	if err != nil {
+		return 0, 0, err
+	}
+	if count <= 0 || price <= 0 {
+		return 0, 0, fmt.Errorf("%w. 수량과 가격은 0보다 커야 함", errUsage)
+	}
+
+	return count, price, nil
+}
+
+func market(stg Storage, a args) (string, error) {
+
+	if len(a.pos) == 0 {
+		mk, err := stg.RetrieveMarketStatus("")
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("현재 시장 단계 : %s(%d)", m.MarketLevel(mk.Status), mk.Status), nil
+	}
+
+	status, err := posUint(a, 0, "시장 단계")
+	if err != nil {
+		return "", err
+	}
+	if status < 1 || status > 5 {
+		return "", fmt.Errorf("%w. 시장 단계는 1~5. 입력 값 : %d", errUsage, status)
+	}
+
+	err = stg.SaveMarketStatus(status)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("시장 단계 저장 성공. %s(%d)", m.MarketLevel(status), status), nil
+}
+
+func asset(svc Service, a args) (string, error) {
+
+	if len(a.pos) == 0 || a.pos[0] != "add" {
+		return "", fmt.Errorf("%w. 지원 하위 명령어 : add", errUsage)
+	}
+
+	name, err := a.str("name")
+	if err != nil {
+		return "", err
+	}
+
+	categoryArg, err := a.str("category")
+	if err != nil {
+		return "", err
+	}
+	category, err := toCategory(categoryArg)
+	if err != nil {
+		return "", err
+	}
+
+	currency, err := a.str("currency")
+	if err != nil {
+		return "", err
+	}
+	currency = strings.ToUpper(currency)
+	if !m.IsCurrency(currency) {
+		return "", fmt.Errorf("%w. currency는 WON 혹은 USD. 입력 값 : %s", errUsage, currency)
+	}
+
+	nums := make(map[string]float64)
+	for _, k := range []string{"top", "bottom", "sell", "buy"} {
+		nums[k], err = a.float(k)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var averages []m.AssetAverage
+	if a.has("averages") {
+		averages, err = toAverages(a.named["averages"])
+		if err != nil {
+			return "", err
+		}
+	}
+
+	id, err := svc.AddAsset(m.Asset{
+		Name:      name,
+		Category:  category,
+		Code:      a.named["code"],
+		Currency:  currency,
+		Top:       nums["top"],
+		Bottom:    nums["bottom"],
+		SellPrice: nums["sell"],
+		BuyPrice:  nums["buy"],
+	}, averages)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("자산 정보 저장 성공. ID : %d", id), nil
+}
+
+func chart(stg Storage, svc Service, a args) (*m.Chart, error) {
+
+	if len(a.pos) == 0 {
+		return nil, fmt.Errorf("%w. 차트 종류 필수", errUsage)
+	}
+
+	switch a.pos[0] {
+	case "fund":
+		fundId, err := posUint(a, 1, "자금 ID")
+		if err != nil {
+			return nil, err
+		}
+		return svc.FundChart(fundId)
+	case "ratio":
+		return svc.RatioChart()
+	case "price":
+		if len(a.pos) < 2 {
+			return nil, fmt.Errorf("%w. 자산 필수", errUsage)
+		}
+		assetId := findAsset(stg, a.pos[1])
+		if assetId == 0 {
+			return nil, fmt.Errorf("%w. 자산 미존재. 입력 값 : %s", errUsage, a.pos[1])
+		}
+		days := 0
+		if len(a.pos) > 2 {
+			n, err := posUint(a, 2, "기간")
+			if err != nil {
+				return nil, err
+			}
+			days = int(n)
+		}
+		return svc.PriceChart(assetId, days)
+	default:
+		return nil, fmt.Errorf("%w. 지원 차트 : fund, ratio, price", errUsage)
+	}
+}
+
+// 카테고리 번호 혹은 이름
+func toCategory(s string) (m.Category, error) {
+
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		if n < 1 || n > m.CategoryLength() {
+			return 0, fmt.Errorf("%w. 카테고리 번호는 1~%d. 입력 값 : %s", errUsage, m.CategoryLength(), s)
+		}
+		return m.Category(n), nil
+	}
+
+	c, err := m.ToCategory(s)
+	if err != nil {
+		return 0, fmt.Errorf("%w. %s", errUsage, err)
+	}
+	return c, nil
+}
+
+// "EMA20,EMA200". 기본 이동평균(EMA200)이 목록에 있으면 기준, 없으면 첫 번째가 기준
+func toAverages(s string) ([]m.AssetAverage, error) {
+
+	rtn := make([]m.AssetAverage, 0)
+	seen := make(map[m.AverageSpec]bool)
+	hasRef := false
+	for _, n := range strings.Split(s, ",") {
+		spec, err := m.ToAverageSpec(n)
+		if err != nil {
+			return nil, fmt.Errorf("%w. %s", errUsage, err)
+		}
+		if seen[spec] {
+			continue
+		}
+		seen[spec] = true
+		hasRef = hasRef || spec == m.DefaultAverage
+		rtn = append(rtn, m.AssetAverage{Kind: spec.Kind, Period: spec.Period, Reference: spec == m.DefaultAverage})
+	}
+
+	if !hasRef {
+		rtn[0].Reference = true
+	}
+
+	return rtn, nil
+}
+
+func posUint(a args, i int, name string) (uint, error) {
+
+	if len(a.pos) <= i {
+		return 0, fmt.Errorf("%w. %s 필수", errUsage, name)
+	}
+	n, err := strconv.ParseUint(a.pos[i], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w. %s는 양의 정수. 입력 값 : %s", errUsage, name, a.pos[i])
+	}
+	return uint(n), nil
+}
@@ -0,0 +1,381 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	m "invest/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 응답 메시지. choices 존재 시 inline keyboard로 표시, photo 존재 시 text를 설명으로 사진 전송
+type reply struct {
+	text    string
+	choices [][]choice
+	photo   []byte
+}
+
+// inline keyboard 버튼. 선택 시 data가 답변으로 전달
+type choice struct {
+	label string
+	data  string
+}
+
+/*
+대화형 입력 단계
+  - choices : 선택지. nil이면 자유 입력
+  - parse : 답변 검증 후 저장 값과 확인 요약 표기 값 반환. 오류 시 같은 단계 재질문
+*/
+type step struct {
+	key     string
+	label   string
+	ask     string
+	choices func(s *session) ([][]choice, error)
+	parse   func(s *session, in string) (any, string, error)
+}
+
+/*
+대화형 입력
+단계별 질문 후 입력 값 요약을 보여주고 확인 시 submit
+*/
+type form struct {
+	name   string
+	title  string
+	desc   string
+	steps  []step
+	submit func(vals map[string]any) (string, error)
+}
+
+// 채팅별 대화형 입력 진행 상태
+type session struct {
+	form     *form
+	idx      int
+	vals     map[string]any
+	shown    map[string]string // 확인 요약 표기
+	options  map[string]string // 현재 단계 선택지. data -> label
+	expireAt time.Time
+	busy     bool // 단계 실행 중. Router.mu 보유 후 접근
+	done     bool // 저장 혹은 오류로 입력 종료
+}
+
+func newSession(f *form, expireAt time.Time) *session {
+	return &session{
+		form:     f,
+		vals:     make(map[string]any),
+		shown:    make(map[string]string),
+		expireAt: expireAt,
+	}
+}
+
+const (
+	confirmYes = "yes"
+	cancelData = "/cancel"
+)
+
+// 현재 단계 질문. 모든 단계 완료 시 확인 요약
+func (r *Router) prompt(s *session) reply {
+
+	if s.idx == len(s.form.steps) {
+		return reply{
+			text: s.summary(),
+			choices: [][]choice{{
+				{label: "확인", data: confirmYes},
+				{label: "취소", data: cancelData},
+			}},
+		}
+	}
+
+	st := s.form.steps[s.idx]
+	s.options = nil
+	if st.choices == nil {
+		return reply{text: fmt.Sprintf("%s\n(/cancel 로 취소)", st.ask)}
+	}
+
+	choices, err := st.choices(s)
+	if err != nil {
+		s.done = true
+		return reply{text: fmt.Sprintf("%s 선택지 조회 시 오류 발생. %s", st.label, err)}
+	}
+
+	s.options = make(map[string]string)
+	for _, row := range choices {
+		for _, c := range row {
+			s.options[c.data] = c.label
+		}
+	}
+
+	return reply{
+		text:    st.ask,
+		choices: append(choices, []choice{{label: "취소", data: cancelData}}),
+	}
+}
+
+// 현재 단계 답변 처리 후 다음 질문 반환
+func (r *Router) answer(s *session, in string) reply {
+
+	if s.idx == len(s.form.steps) {
+		switch strings.ToLower(in) {
+		case confirmYes, "y", "확인", "예":
+		default:
+			rp := r.prompt(s)
+			rp.text = "확인 혹은 취소 선택\n" + rp.text
+			return rp
+		}
+
+		s.done = true
+		rtn, err := s.form.submit(s.vals)
+		if err != nil {
+			return reply{text: fmt.Sprintf("%s 저장 시 오류 발생. %s", s.form.title, err)}
+		}
+		return reply{text: rtn}
+	}
+
+	st := s.form.steps[s.idx]
+
+	shown := in
+	for data, label := range s.options { // 버튼 대신 표기 그대로 입력한 경우 포함
+		if in == data || in == label {
+			in, shown = data, label
+			break
+		}
+	}
+
+	v, display, err := st.parse(s, in)
+	if err != nil {
+		rp := r.prompt(s)
+		rp.text = fmt.Sprintf("%s\n%s", err, rp.text)
+		return rp
+	}
+	if display == "" {
+		display = shown
+	}
+
+	s.vals[st.key] = v
+	s.shown[st.key] = display
+	s.idx++
+
+	return r.prompt(s)
+}
+
+func (s *session) summary() string {
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s 입력 확인\n", s.form.title))
+	for _, st := range s.form.steps {
+		sb.WriteString(fmt.Sprintf("%s : %s\n", st.label, s.shown[st.key]))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// 선택지를 한 줄에 n개씩 배치
+func rows(choices []choice, n int) [][]choice {
+
+	rtn := make([][]choice, 0, (len(choices)+n-1)/n)
+	for i := 0; i < len(choices); i += n {
+		end := min(i+n, len(choices))
+		rtn = append(rtn, choices[i:end])
+	}
+	return rtn
+}
+
+// 선택지 중 하나만 허용
+func oneOf(s *session, in string) (any, string, error) {
+	if _, ok := s.options[in]; !ok {
+		return nil, "", fmt.Errorf("선택지에 없는 값. 입력 값 : %s", in)
+	}
+	return in, "", nil
+}
+
+func positiveFloat(name string) func(s *session, in string) (any, string, error) {
+	return func(s *session, in string) (any, string, error) {
+		f, err := strconv.ParseFloat(strings.ReplaceAll(in, ",", ""), 64)
+		if err != nil || f <= 0 {
+			return nil, "", fmt.Errorf("%s는 0보다 큰 숫자. 입력 값 : %s", name, in)
+		}
+		return f, strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+}
+
+/*
+매수/매도 대화형 입력
+구분 -> 자금 -> 자산 -> 가격 -> 수량 -> 확인
+*/
+func investForm(stg Storage, svc Service) *form {
+	return &form{
+		name:  "/invest",
+		title: "투자 이력",
+		desc:  "매수/매도 이력 대화형 입력. 자금, 자산은 목록에서 선택",
+		steps: []step{
+			{
+				key:   "side",
+				label: "구분",
+				ask:   "매수/매도 선택",
+				choices: func(s *session) ([][]choice, error) {
+					return [][]choice{{{label: "매수", data: "buy"}, {label: "매도", data: "sell"}}}, nil
+				},
+				parse: oneOf,
+			},
+			{
+				key:   "fund",
+				label: "자금",
+				ask:   "자금 선택",
+				choices: func(s *session) ([][]choice, error) {
+					funds, err := stg.RetrieveFunds()
+					if err != nil {
+						return nil, err
+					}
+					if len(funds) == 0 {
+						return nil, errors.New("등록된 자금 없음")
+					}
+					choices := make([]choice, len(funds))
+					for i, f := range funds {
+						choices[i] = choice{label: fmt.Sprintf("%d. %s", f.ID, f.Name), data: strconv.FormatUint(uint64(f.ID), 10)}
+					}
+					return rows(choices, 2), nil
+				},
+				parse: func(s *session, in string) (any, string, error) {
+					if _, _, err := oneOf(s, in); err != nil {
+						return nil, "", err
+					}
+					id, _ := strconv.ParseUint(in, 10, 64)
+					return uint(id), "", nil
+				},
+			},
+			{
+				key:   "asset",
+				label: "자산",
+				ask:   "자산 선택 (목록에 없으면 이름/코드 입력)",
+				choices: func(s *session) ([][]choice, error) {
+					assets, err := stg.RetrieveAssetList()
+					if err != nil {
+						return nil, err
+					}
+					choices := make([]choice, 0, len(assets))
+					for _, a := range assets {
+						if a.ID == 0 || a.Name == "" {
+							continue
+						}
+						choices = append(choices, choice{label: a.Name, data: strconv.FormatUint(uint64(a.ID), 10)})
+					}
+					return rows(choices, 2), nil
+				},
+				parse: func(s *session, in string) (any, string, error) {
+					if label, ok := s.options[in]; ok {
+						id, _ := strconv.ParseUint(in, 10, 64)
+						return uint(id), fmt.Sprintf("%s(%s)", label, in), nil
+					}
+					id := findAsset(stg, in)
+					if id == 0 {
+						return nil, "", fmt.Errorf("자산 미존재. 입력 값 : %s", in)
+					}
+					return id, fmt.Sprintf("%s(%d)", in, id), nil
+				},
+			},
+			{
+				key:   "price",
+				label: "가격",
+				ask:   "가격 입력",
+				parse: positiveFloat("가격"),
+			},
+			{
+				key:   "count",
+				label: "수량",
+				ask:   "수량 입력",
+				parse: positiveFloat("수량"),
+			},
+		},
+		submit: func(vals map[string]any) (string, error) {
+			fundId, assetId := vals["fund"].(uint), vals["asset"].(uint)
+			price, count := vals["price"].(float64), vals["count"].(float64)
+
+			side, sign := "매수", 1.0
+			if vals["side"] == "sell" {
+				side, sign = "매도", -1.0
+			}
+
+			err := svc.RecordInvest(fundId, assetId, price, sign*count)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%s 이력 저장 성공. 자금 %d, 자산 %d, %g@%g", side, fundId, assetId, count, price), nil
+		},
+	}
+}
+
+/*
+자산 추가 대화형 입력
+이름 -> 카테고리 -> 통화 -> 코드 -> 확인. 최고/최저가는 시세 API 값 사용
+*/
+func assetForm(svc Service) *form {
+	return &form{
+		name:  "/newasset",
+		title: "자산",
+		desc:  "자산 추가 대화형 입력. 최고/최저가, 이동평균 등 상세 설정은 /asset add 사용",
+		steps: []step{
+			{
+				key:   "name",
+				label: "이름",
+				ask:   "자산 이름 입력",
+				parse: func(s *session, in string) (any, string, error) {
+					if in == "" {
+						return nil, "", errors.New("이름 필수")
+					}
+					return in, "", nil
+				},
+			},
+			{
+				key:   "category",
+				label: "카테고리",
+				ask:   "카테고리 선택",
+				choices: func(s *session) ([][]choice, error) {
+					choices := make([]choice, m.CategoryLength())
+					for i := range choices {
+						n := uint64(i + 1)
+						choices[i] = choice{label: m.Category(n).String(), data: strconv.FormatUint(n, 10)}
+					}
+					return rows(choices, 3), nil
+				},
+				parse: func(s *session, in string) (any, string, error) {
+					if _, _, err := oneOf(s, in); err != nil {
+						return nil, "", err
+					}
+					n, _ := strconv.ParseUint(in, 10, 64)
+					return m.Category(n), "", nil
+				},
+			},
+			{
+				key:   "currency",
+				label: "통화",
+				ask:   "통화 선택",
+				choices: func(s *session) ([][]choice, error) {
+					return [][]choice{{{label: "WON", data: "WON"}, {label: "USD", data: "USD"}}}, nil
+				},
+				parse: oneOf,
+			},
+			{
+				key:   "code",
+				label: "코드",
+				ask:   "종목 코드 입력 (없으면 -)",
+				parse: func(s *session, in string) (any, string, error) {
+					if in == "-" {
+						return "", "-", nil
+					}
+					return in, "", nil
+				},
+			},
+		},
+		submit: func(vals map[string]any) (string, error) {
+			id, err := svc.AddAsset(m.Asset{
+				Name:     vals["name"].(string),
+				Category: vals["category"].(m.Category),
+				Currency: vals["currency"].(string),
+				Code:     vals["code"].(string),
+			}, nil)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("자산 정보 저장 성공. ID : %d", id), nil
+		},
+	}
+}
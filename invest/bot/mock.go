@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	m "invest/model"
+)
+
+type StorageMock struct {
+	funds  []m.Fund
+	list   []m.Asset
+	assets map[string]uint // 이름/코드 -> ID
+	market *m.Market
+	status *uint
+	err    error
+}
+
+func (mock StorageMock) RetrieveFunds() ([]m.Fund, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return mock.funds, nil
+}
+
+func (mock StorageMock) RetrieveAssetList() ([]m.Asset, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return mock.list, nil
+}
+
+func (mock StorageMock) RetrieveAssetIdByName(name string) uint {
+	return mock.assets[name]
+}
+
+func (mock StorageMock) RetrieveAssetIdByCode(code string) uint {
+	return mock.assets[code]
+}
+
+func (mock StorageMock) RetrieveMarketStatus(date string) (*m.Market, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	if mock.market == nil {
+		return nil, errors.New("record not found")
+	}
+	return mock.market, nil
+}
+
+func (mock StorageMock) SaveMarketStatus(status uint) error {
+	if mock.err != nil {
+		return mock.err
+	}
+	if mock.status != nil {
+		*mock.status = status
+	}
+	return nil
+}
+
+type investCall struct {
+	fundId  uint
+	assetId uint
+	price   float64
+	count   float64
+}
+
+type ServiceMock struct {
+	invests *[]investCall
+	assets  *[]m.Asset
+	avgs    *[]m.AssetAverage
+	days    *int
+	err     error
+}
+
+func (mock ServiceMock) RecordInvest(fundId uint, assetId uint, price float64, count float64) error {
+	if mock.err != nil {
+		return mock.err
+	}
+	if mock.invests != nil {
+		*mock.invests = append(*mock.invests, investCall{fundId, assetId, price, count})
+	}
+	return nil
+}
+
+func (mock ServiceMock) AddAsset(asset m.Asset, averages []m.AssetAverage) (uint, error) {
+	if mock.err != nil {
+		return 0, mock.err
+	}
+	if mock.assets != nil {
+		*mock.assets = append(*mock.assets, asset)
+	}
+	if mock.avgs != nil {
+		*mock.avgs = averages
+	}
+	return 7, nil
+}
+
+func (mock ServiceMock) FundChart(fundId uint) (*m.Chart, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return &m.Chart{Image: []byte{1}, Caption: fmt.Sprintf("자금 %d 자산 비중", fundId)}, nil
+}
+
+func (mock ServiceMock) RatioChart() (*m.Chart, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	return &m.Chart{Image: []byte{2}, Caption: "변동 자산 비율"}, nil
+}
+
+func (mock ServiceMock) PriceChart(assetId uint, days int) (*m.Chart, error) {
+	if mock.err != nil {
+		return nil, mock.err
+	}
+	if mock.days != nil {
+		*mock.days = days
+	}
+	return &m.Chart{Image: []byte{3}, Caption: fmt.Sprintf("자산 %d 시세", assetId)}, nil
+}
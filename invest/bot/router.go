@@ -0,0 +1,447 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"invest/client"
+	m "invest/model"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 명령어 인자 오류. 사용법과 함께 응답
+var errUsage = errors.New("잘못된 인자")
+
+/*
+텔레그램 명령어
+  - usage : 사용법. ex) /market {시장 단계}
+  - write : 인자 기준 저장/변경 여부. ReadWrite 권한 필요
+  - run : 인자 파싱 후 실행 결과 메시지 반환
+*/
+type command struct {
+	name  string
+	usage string
+	desc  string
+	write func(a args) bool // 저장/변경 여부. nil이면 조회
+	run   func(a args) (string, error)
+	draw  func(a args) (*m.Chart, error) // 차트 이미지 응답. 설정 시 run 대신 실행
+}
+
+func always(a args) bool {
+	return true
+}
+
+/*
+Router
+"/명령어 인자..." 형태 메시지를 등록된 명령어로 분배
+미등록 명령어 중 "/funds/1/hist" 처럼 경로 형태인 경우 조회 API(GET)로 전달
+*/
+type Router struct {
+	cmds     map[string]*command
+	forms    map[string]*form
+	fallback func(path string) (string, error)
+	api      *client.Client
+
+	mu       sync.Mutex
+	sessions map[int64]*session // 채팅별 진행 중인 대화형 입력
+	timeout  time.Duration
+	now      func() time.Time
+}
+
+// API 서버 주소
+const apiBase = "http://localhost:3000"
+
+// 대화형 입력 대기 시간. 초과 시 입력 취소
+const sessionTimeout = 10 * time.Minute
+
+func newRouter() *Router {
+	r := &Router{
+		cmds:     make(map[string]*command),
+		forms:    make(map[string]*form),
+		sessions: make(map[int64]*session),
+		timeout:  sessionTimeout,
+		now:      time.Now,
+		api:      client.New(apiBase),
+	}
+	r.fallback = r.get
+	return r
+}
+
+/*
+WithClient
+API 요청 클라이언트. 봇 전용 API 키 혹은 JWT 인증 설정
+  - 미설정 시 인증 없이 로컬 서버(apiBase)로 요청
+*/
+func WithClient(api *client.Client) func(*Router) {
+	return func(r *Router) {
+		r.api = api
+	}
+}
+
+// 조회 API(GET) 요청. 응답 JSON 정렬
+func (r *Router) get(path string) (string, error) {
+	data, err := r.api.Get(context.Background(), path)
+	if err != nil {
+		return "", err
+	}
+	return pretty(data)
+}
+
+// 들여쓰기한 JSON. & 등 HTML 문자 escape 안 함
+func pretty(v any) (string, error) {
+
+	if data, ok := v.(json.RawMessage); ok {
+		var jsonData any
+		err := json.Unmarshal(data, &jsonData)
+		if err != nil {
+			return "", err
+		}
+		v = jsonData
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false) // memo. 단순 MarshalIndent 사용하면, &을 \u0026로 바꿔버림.
+	encoder.SetIndent("", "\t")
+	err := encoder.Encode(v)
+	if err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+func (r *Router) register(cmd *command) {
+	r.cmds[cmd.name] = cmd
+}
+
+func (r *Router) registerForm(f *form) {
+	r.forms[f.name] = f
+}
+
+// "/cmd@botname 인자" 에서 "/cmd". 명령어가 아니면 빈 값
+func commandName(txt string) (name string, rest string) {
+
+	if txt == "" || txt[0] != '/' {
+		return "", txt
+	}
+	name, rest, _ = strings.Cut(txt, " ")
+	name, _, _ = strings.Cut(strings.ToLower(name), "@") // 그룹 채팅의 /cmd@botname 형태 대비
+	return name, rest
+}
+
+/*
+채팅별 메시지 처리
+  - /cancel : 진행 중인 대화형 입력 취소
+  - 대화형 입력 명령어 : 채팅별 세션 시작 후 첫 질문 반환. ReadWrite 권한 필요
+  - 세션 진행 중 명령어가 아닌 메시지 : 현재 단계 답변으로 처리
+  - 그 외 : 등록 명령어 실행
+  - 세션 조회/갱신 시에만 잠금. 명령어, 선택지 조회, 저장은 잠금 없이 실행
+*/
+func (r *Router) Chat(chatId int64, role Role, txt string) reply {
+
+	txt = strings.TrimSpace(txt)
+	name, _ := commandName(txt)
+
+	r.mu.Lock()
+	run := r.dispatch(chatId, role, name, txt)
+	r.mu.Unlock()
+
+	return run()
+}
+
+// 세션 상태 확인 후 실행할 작업 반환. r.mu 보유 상태에서 호출
+func (r *Router) dispatch(chatId int64, role Role, name string, txt string) func() reply {
+
+	now := r.now()
+	s, ok := r.sessions[chatId]
+	r.expire(now)
+	if ok && now.After(s.expireAt) {
+		ok = false
+		if name == "" {
+			return respond(fmt.Sprintf("입력 시간 초과로 %s 입력 취소. %s 로 다시 시작", s.form.title, s.form.name))
+		}
+	}
+
+	if ok && s.busy && (name == "" || name == "/cancel") {
+		return respond(fmt.Sprintf("%s 이전 입력 처리 중. 잠시 후 다시 시도", s.form.title))
+	}
+
+	if name == "/cancel" {
+		if !ok {
+			return respond("진행 중인 입력 없음")
+		}
+		if role < ReadWrite {
+			return respond(denied(chatId, role, txt))
+		}
+		delete(r.sessions, chatId)
+		return respond(fmt.Sprintf("%s 입력 취소", s.form.title))
+	}
+
+	if f, exist := r.forms[name]; exist {
+		if role < ReadWrite {
+			return respond(denied(chatId, role, txt))
+		}
+		s = newSession(f, now.Add(r.timeout))
+		s.busy = true
+		r.sessions[chatId] = s
+		return func() reply { return r.step(chatId, s, r.prompt(s)) }
+	}
+
+	if ok && name == "" {
+		if role < ReadWrite { // 그룹 채팅에서 다른 사용자가 시작한 입력
+			return respond(denied(chatId, role, txt))
+		}
+		s.busy = true
+		s.expireAt = now.Add(r.timeout)
+		return func() reply { return r.step(chatId, s, r.answer(s, txt)) }
+	}
+
+	return func() reply { return r.handle(chatId, role, txt) }
+}
+
+func respond(txt string) func() reply {
+	return func() reply { return reply{text: txt} }
+}
+
+// 세션 단계 실행 결과 저장. 종료된 세션은 목록에서 제거
+func (r *Router) step(chatId int64, s *session, rp reply) reply {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s.busy = false
+	if s.done && r.sessions[chatId] == s {
+		delete(r.sessions, chatId)
+	}
+	return rp
+}
+
+// 쓰기 권한 없는 요청 거부 및 기록
+func denied(chatId int64, role Role, txt string) string {
+	log.Printf("[Audit] 권한 부족 요청 거부. chat : %d, role : %s, text : %s", chatId, role, txt)
+	return "권한 없음. 조회 명령어만 사용 가능"
+}
+
+// 만료된 세션 정리
+func (r *Router) expire(now time.Time) {
+	for id, s := range r.sessions {
+		if now.After(s.expireAt) {
+			delete(r.sessions, id)
+		}
+	}
+}
+
+// 명령어 실행 후 응답 메시지 반환. 명령어가 아니면 빈 값
+func (r *Router) handle(chatId int64, role Role, txt string) reply {
+
+	txt = strings.TrimSpace(txt)
+	name, rest := commandName(txt)
+	if name == "" {
+		return reply{}
+	}
+
+	cmd, ok := r.cmds[name]
+	if !ok {
+		if strings.Contains(name[1:], "/") || strings.Contains(name, "?") {
+			rtn, err := r.fallback(txt)
+			if err != nil {
+				return reply{text: err.Error()}
+			}
+			return reply{text: rtn}
+		}
+		return reply{text: fmt.Sprintf("알 수 없는 명령어. %s\n/help 로 명령어 목록 확인", name)}
+	}
+
+	a, err := parseArgs(rest)
+	if err != nil {
+		return reply{text: fmt.Sprintf("%s\n사용법 : %s", err, cmd.usage)}
+	}
+
+	if cmd.write != nil && cmd.write(a) && role < ReadWrite {
+		return reply{text: denied(chatId, role, txt)}
+	}
+
+	if cmd.draw != nil {
+		ct, err := cmd.draw(a)
+		if err != nil {
+			return reply{text: cmd.fail(err)}
+		}
+		return reply{text: ct.Caption, photo: ct.Image}
+	}
+
+	rtn, err := cmd.run(a)
+	if err != nil {
+		return reply{text: cmd.fail(err)}
+	}
+
+	return reply{text: rtn}
+}
+
+func (cmd *command) fail(err error) string {
+	if errors.Is(err, errUsage) {
+		return fmt.Sprintf("%s\n사용법 : %s", err, cmd.usage)
+	}
+	return fmt.Sprintf("%s 실행 시 오류 발생. %s", cmd.name, err)
+}
+
+// 전체 명령어 목록 혹은 명령어별 사용법
+func (r *Router) help(name string) string {
+
+	if name != "" {
+		if !strings.HasPrefix(name, "/") {
+			name = "/" + name
+		}
+		if f, ok := r.forms[name]; ok {
+			return fmt.Sprintf("%s\n%s", f.name, f.desc)
+		}
+		cmd, ok := r.cmds[name]
+		if !ok {
+			return fmt.Sprintf("알 수 없는 명령어. %s", name)
+		}
+		return fmt.Sprintf("%s\n%s", cmd.usage, cmd.desc)
+	}
+
+	names := make([]string, 0, len(r.cmds))
+	for n := range r.cmds {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString("명령어 목록 (/help {명령어} 로 상세 사용법 확인)\n")
+	for _, n := range names {
+		sb.WriteString(fmt.Sprintf("%s : %s\n", n, firstLine(r.cmds[n].desc)))
+	}
+
+	if len(r.forms) > 0 {
+		formNames := make([]string, 0, len(r.forms))
+		for n := range r.forms {
+			formNames = append(formNames, n)
+		}
+		sort.Strings(formNames)
+
+		sb.WriteString("\n대화형 입력 (/cancel 로 취소)\n")
+		for _, n := range formNames {
+			sb.WriteString(fmt.Sprintf("%s : %s\n", n, firstLine(r.forms[n].desc)))
+		}
+	}
+	sb.WriteString(queryHelp)
+
+	return sb.String()
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+/*
+명령어 인자
+  - key=value 형태는 named, 나머지는 순서대로 pos
+  - 공백 포함 값은 큰따옴표로 감쌈. ex) asset="TIGER 미국S&P500"
+*/
+type args struct {
+	raw   string
+	pos   []string
+	named map[string]string
+}
+
+func parseArgs(s string) (args, error) {
+
+	a := args{
+		raw:   strings.TrimSpace(s),
+		named: make(map[string]string),
+	}
+
+	tokens, err := tokenize(s)
+	if err != nil {
+		return a, err
+	}
+
+	for _, t := range tokens {
+		k, v, ok := strings.Cut(t, "=")
+		if ok && k != "" && !strings.ContainsAny(k, `"{`) {
+			a.named[strings.ToLower(k)] = v
+		} else {
+			a.pos = append(a.pos, t)
+		}
+	}
+
+	return a, nil
+}
+
+func tokenize(s string) ([]string, error) {
+
+	var tokens []string
+	var sb strings.Builder
+	inQuote, started := false, false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			started = true
+		case (r == ' ' || r == '\t' || r == '\n') && !inQuote:
+			if started {
+				tokens = append(tokens, sb.String())
+				sb.Reset()
+				started = false
+			}
+		default:
+			sb.WriteRune(r)
+			started = true
+		}
+	}
+	if inQuote {
+		return nil, errors.New("닫히지 않은 따옴표")
+	}
+	if started {
+		tokens = append(tokens, sb.String())
+	}
+
+	return tokens, nil
+}
+
+func (a args) has(key string) bool {
+	_, ok := a.named[key]
+	return ok
+}
+
+func (a args) str(key string) (string, error) {
+	v := a.named[key]
+	if v == "" {
+		return "", fmt.Errorf("%w. %s 필수", errUsage, key)
+	}
+	return v, nil
+}
+
+func (a args) uint(key string) (uint, error) {
+	v, err := a.str(key)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w. %s는 양의 정수. 입력 값 : %s", errUsage, key, v)
+	}
+	return uint(n), nil
+}
+
+// 미입력 시 0
+func (a args) float(key string) (float64, error) {
+	v := a.named[key]
+	if v == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(strings.ReplaceAll(v, ",", ""), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w. %s는 숫자. 입력 값 : %s", errUsage, key, v)
+	}
+	return f, nil
+}
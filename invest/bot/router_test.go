@@ -0,0 +1,513 @@
+package bot
+
+import (
+	"errors"
+	"invest/client"
+	m "invest/model"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseArgs(t *testing.T) {
+
+	t.Run("key=value와 위치 인자 구분", func(t *testing.T) {
+		a, err := parseArgs(`fund=1 asset="TIGER 미국S&P500" 10@12500`)
+		assert.NoError(t, err)
+		assert.Equal(t, "1", a.named["fund"])
+		assert.Equal(t, "TIGER 미국S&P500", a.named["asset"])
+		assert.Equal(t, []string{"10@12500"}, a.pos)
+	})
+
+	t.Run("닫히지 않은 따옴표", func(t *testing.T) {
+		_, err := parseArgs(`asset="TIGER`)
+		assert.Error(t, err)
+	})
+}
+
+func TestRouter(t *testing.T) {
+
+	status := uint(0)
+	invests := make([]investCall, 0)
+	assets := make([]m.Asset, 0)
+	avgs := make([]m.AssetAverage, 0)
+
+	stg := &StorageMock{
+		assets: map[string]uint{"TIGER 미국S&P500": 3, "360750": 3, "MSFT": 4},
+		market: &m.Market{Status: 4},
+		status: &status,
+	}
+	svc := &ServiceMock{invests: &invests, assets: &assets, avgs: &avgs}
+
+	r := NewRouter(stg, svc)
+	r.fallback = func(path string) (string, error) { return "GET " + path, nil }
+
+	t.Run("명령어가 아닌 메시지 무시", func(t *testing.T) {
+		assert.Empty(t, r.handle(1, ReadWrite, "").text)
+		assert.Empty(t, r.handle(1, ReadWrite, "   ").text)
+		assert.Empty(t, r.handle(1, ReadWrite, "안녕").text)
+	})
+
+	t.Run("매수", func(t *testing.T) {
+		t.Run("성공 테스트 - 자산 이름", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, `/buy fund=1 asset="TIGER 미국S&P500" 10@12500`).text
+			assert.Contains(t, rtn, "매수 이력 저장 성공")
+			assert.Equal(t, investCall{1, 3, 12500, 10}, invests[0])
+		})
+
+		t.Run("성공 테스트 - 자산 코드, 그룹 채팅 명령어", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, `/buy@invest_bot fund=2 asset=360750 count=1 price=12,600`).text
+			assert.Contains(t, rtn, "매수 이력 저장 성공")
+			assert.Equal(t, investCall{2, 3, 12600, 1}, invests[1])
+		})
+
+		t.Run("실패 테스트 - 수량@가격 형식", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, `/buy fund=1 asset=MSFT 10`).text
+			assert.Contains(t, rtn, "{수량}@{가격} 형태")
+			assert.Contains(t, rtn, "사용법 : /buy")
+		})
+
+		t.Run("실패 테스트 - 자산 미존재", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, `/buy fund=1 asset=AAPL 1@100`).text
+			assert.Contains(t, rtn, "자산 미존재")
+		})
+
+		t.Run("실패 테스트 - 자금 미입력", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, `/buy asset=MSFT 1@100`).text
+			assert.Contains(t, rtn, "fund 필수")
+			assert.Len(t, invests, 2)
+		})
+	})
+
+	t.Run("매도 시 수량 음수", func(t *testing.T) {
+		rtn := r.handle(1, ReadWrite, `/sell fund=1 asset=MSFT 2@400`).text
+		assert.Contains(t, rtn, "매도 이력 저장 성공")
+		assert.Equal(t, -2.0, invests[2].count)
+	})
+
+	t.Run("서비스 오류 전달", func(t *testing.T) {
+		svc.err = errors.New("현금 자산 미존재")
+		defer func() { svc.err = nil }()
+
+		rtn := r.handle(1, ReadWrite, `/sell fund=1 asset=MSFT 2@400`).text
+		assert.Equal(t, "/sell 실행 시 오류 발생. 현금 자산 미존재", rtn)
+	})
+
+	t.Run("시장 단계", func(t *testing.T) {
+		t.Run("조회", func(t *testing.T) {
+			assert.Equal(t, "현재 시장 단계 : BULL(4)", r.handle(1, ReadWrite, "/market").text)
+		})
+
+		t.Run("저장", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, "/market 3").text
+			assert.Contains(t, rtn, "VOLATILIY(3)")
+			assert.Equal(t, uint(3), status)
+		})
+
+		t.Run("실패 테스트 - 범위 초과", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, "/market 6").text
+			assert.Contains(t, rtn, "시장 단계는 1~5")
+		})
+	})
+
+	t.Run("자산 추가", func(t *testing.T) {
+		t.Run("성공 테스트", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, `/asset add name="TIGER 미국나스닥100" category=국내ETF currency=won code=133690 top=120000 averages=EMA20,EMA200`).text
+			assert.Equal(t, "자산 정보 저장 성공. ID : 7", rtn)
+			assert.Equal(t, m.DomesticETF, assets[0].Category)
+			assert.Equal(t, "WON", assets[0].Currency)
+			assert.Equal(t, 120000.0, assets[0].Top)
+			assert.Len(t, avgs, 2)
+			assert.True(t, avgs[1].Reference)
+		})
+
+		t.Run("실패 테스트 - 카테고리", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, `/asset add name=X category=99 currency=WON`).text
+			assert.Contains(t, rtn, "카테고리 번호는 1~")
+		})
+
+		t.Run("실패 테스트 - 하위 명령어", func(t *testing.T) {
+			rtn := r.handle(1, ReadWrite, `/asset remove name=X`).text
+			assert.Contains(t, rtn, "지원 하위 명령어 : add")
+		})
+	})
+
+	t.Run("도움말", func(t *testing.T) {
+		assert.Contains(t, r.handle(1, ReadWrite, "/help").text, "/buy : 매수 이력 저장")
+		assert.Contains(t, r.handle(1, ReadWrite, "/help buy").text, "/buy fund={자금 ID}")
+		assert.Contains(t, r.handle(1, ReadWrite, "/help nope").text, "알 수 없는 명령어")
+	})
+
+	t.Run("경로 형태는 조회 API로 전달", func(t *testing.T) {
+		assert.Equal(t, "GET /funds/1/hist", r.handle(1, ReadWrite, "/funds/1/hist").text)
+		assert.Equal(t, "GET /monitors?x=1", r.handle(1, ReadWrite, "/monitors?x=1").text)
+		assert.Contains(t, r.handle(1, ReadWrite, "/funds").text, "알 수 없는 명령어")
+	})
+
+	t.Run("투자 이력 조회", func(t *testing.T) {
+		assert.Equal(t, "GET /invests", r.handle(1, ReadOnly, "/invests").text)
+		assert.Equal(t, "GET /invests?asset=4&from=2024-01-01&side=sell", r.handle(1, ReadOnly, "/invests asset=MSFT side=sell from=2024-01-01").text)
+		assert.Equal(t, "GET /invests?cursor=eyJzIjo&fund=1&limit=10", r.handle(1, ReadOnly, "/invests fund=1 limit=10 cursor=eyJzIjo").text)
+		assert.Contains(t, r.handle(1, ReadOnly, "/invests asset=NONE").text, "자산 미존재. 입력 값 : NONE")
+	})
+}
+
+func TestRouterChat(t *testing.T) {
+
+	invests := make([]investCall, 0)
+	assets := make([]m.Asset, 0)
+
+	stg := &StorageMock{
+		funds:  []m.Fund{{ID: 1, Name: "개인"}, {ID: 2, Name: "연금"}},
+		list:   []m.Asset{{ID: 3, Name: "TIGER 미국S&P500"}, {ID: 4, Name: "MSFT"}},
+		assets: map[string]uint{"TIGER 미국S&P500": 3, "360750": 3, "MSFT": 4},
+	}
+	svc := &ServiceMock{invests: &invests, assets: &assets}
+
+	now := time.Date(2024, 9, 1, 10, 0, 0, 0, time.Local)
+	r := NewRouter(stg, svc)
+	r.now = func() time.Time { return now }
+
+	t.Run("매수 대화형 입력", func(t *testing.T) {
+		rp := r.Chat(1, ReadWrite, "/invest")
+		assert.Equal(t, "매수/매도 선택", rp.text)
+		assert.Equal(t, "buy", rp.choices[0][0].data)
+		assert.Equal(t, cancelData, rp.choices[len(rp.choices)-1][0].data)
+
+		rp = r.Chat(1, ReadWrite, "buy")
+		assert.Equal(t, "자금 선택", rp.text)
+		assert.Equal(t, choice{label: "2. 연금", data: "2"}, rp.choices[0][1])
+
+		rp = r.Chat(1, ReadWrite, "2")
+		assert.Contains(t, rp.text, "자산 선택")
+		assert.Equal(t, choice{label: "MSFT", data: "4"}, rp.choices[0][1])
+
+		rp = r.Chat(1, ReadWrite, "4")
+		assert.Contains(t, rp.text, "가격 입력")
+		assert.Nil(t, rp.choices)
+
+		t.Run("잘못된 입력은 같은 단계 재질문", func(t *testing.T) {
+			rp = r.Chat(1, ReadWrite, "abc")
+			assert.Contains(t, rp.text, "가격는 0보다 큰 숫자")
+			assert.Contains(t, rp.text, "가격 입력")
+		})
+
+		rp = r.Chat(1, ReadWrite, "412,000")
+		assert.Contains(t, rp.text, "수량 입력")
+
+		rp = r.Chat(1, ReadWrite, "2")
+		assert.Equal(t, "투자 이력 입력 확인\n구분 : 매수\n자금 : 2. 연금\n자산 : MSFT(4)\n가격 : 412000\n수량 : 2", rp.text)
+		assert.Equal(t, confirmYes, rp.choices[0][0].data)
+		assert.Empty(t, invests)
+
+		rp = r.Chat(1, ReadWrite, confirmYes)
+		assert.Equal(t, "매수 이력 저장 성공. 자금 2, 자산 4, 2@412000", rp.text)
+		assert.Equal(t, investCall{2, 4, 412000, 2}, invests[0])
+		assert.Empty(t, r.sessions)
+	})
+
+	t.Run("매도 - 표기 및 자산 코드 직접 입력", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/invest@invest_bot")
+		r.Chat(1, ReadWrite, "매도")
+		r.Chat(1, ReadWrite, "1. 개인")
+		rp := r.Chat(1, ReadWrite, "360750")
+		assert.Contains(t, rp.text, "가격 입력")
+		r.Chat(1, ReadWrite, "12500")
+		r.Chat(1, ReadWrite, "10")
+		r.Chat(1, ReadWrite, "확인")
+		assert.Equal(t, investCall{1, 3, 12500, -10}, invests[1])
+	})
+
+	t.Run("선택지 외 값 재질문", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/invest")
+		rp := r.Chat(1, ReadWrite, "hold")
+		assert.Contains(t, rp.text, "선택지에 없는 값")
+		assert.NotEmpty(t, rp.choices)
+		r.Chat(1, ReadWrite, "/cancel")
+	})
+
+	t.Run("취소", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/invest")
+		r.Chat(1, ReadWrite, "buy")
+		assert.Equal(t, "투자 이력 입력 취소", r.Chat(1, ReadWrite, "/cancel").text)
+		assert.Equal(t, "진행 중인 입력 없음", r.Chat(1, ReadWrite, "/cancel").text)
+		assert.Empty(t, r.Chat(1, ReadWrite, "1").text)
+		assert.Len(t, invests, 2)
+	})
+
+	t.Run("채팅별 세션 분리", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/invest")
+		r.Chat(2, ReadWrite, "/newasset")
+
+		assert.Equal(t, "자금 선택", r.Chat(1, ReadWrite, "buy").text)
+		assert.Equal(t, "카테고리 선택", r.Chat(2, ReadWrite, "TIGER 미국나스닥100").text)
+		r.Chat(1, ReadWrite, "/cancel")
+		r.Chat(2, ReadWrite, "/cancel")
+	})
+
+	t.Run("세션 진행 중 일반 명령어 처리", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/invest")
+		assert.Contains(t, r.Chat(1, ReadWrite, "/help").text, "/invest : 매수/매도 이력 대화형 입력")
+		assert.Equal(t, "자금 선택", r.Chat(1, ReadWrite, "buy").text)
+		r.Chat(1, ReadWrite, "/cancel")
+	})
+
+	t.Run("시간 초과", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/invest")
+		r.Chat(2, ReadWrite, "/invest")
+
+		now = now.Add(sessionTimeout + time.Second)
+		assert.Equal(t, "입력 시간 초과로 투자 이력 입력 취소. /invest 로 다시 시작", r.Chat(1, ReadWrite, "buy").text)
+		assert.Empty(t, r.sessions) // 다른 채팅의 만료 세션 정리
+		assert.Empty(t, r.Chat(1, ReadWrite, "buy").text)
+	})
+
+	t.Run("입력 시마다 만료 시간 연장", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/invest")
+		now = now.Add(sessionTimeout - time.Second)
+		r.Chat(1, ReadWrite, "buy")
+		now = now.Add(sessionTimeout - time.Second)
+		assert.Contains(t, r.Chat(1, ReadWrite, "1").text, "자산 선택")
+		r.Chat(1, ReadWrite, "/cancel")
+	})
+
+	t.Run("자산 추가 대화형 입력", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/newasset")
+		r.Chat(1, ReadWrite, "TIGER 미국나스닥100")
+		rp := r.Chat(1, ReadWrite, "국내ETF")
+		assert.Equal(t, "통화 선택", rp.text)
+		r.Chat(1, ReadWrite, "WON")
+		rp = r.Chat(1, ReadWrite, "133690")
+		assert.Equal(t, "자산 입력 확인\n이름 : TIGER 미국나스닥100\n카테고리 : 국내ETF\n통화 : WON\n코드 : 133690", rp.text)
+
+		rp = r.Chat(1, ReadWrite, "아니")
+		assert.Contains(t, rp.text, "확인 혹은 취소 선택")
+
+		rp = r.Chat(1, ReadWrite, confirmYes)
+		assert.Equal(t, "자산 정보 저장 성공. ID : 7", rp.text)
+		assert.Equal(t, m.Asset{Name: "TIGER 미국나스닥100", Category: m.DomesticETF, Currency: "WON", Code: "133690"}, assets[0])
+	})
+
+	t.Run("서비스 오류 시 세션 종료", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/newasset")
+		r.Chat(1, ReadWrite, "X")
+		r.Chat(1, ReadWrite, "레버리지")
+		r.Chat(1, ReadWrite, "USD")
+		r.Chat(1, ReadWrite, "-")
+
+		svc.err = errors.New("시세 조회 실패")
+		defer func() { svc.err = nil }()
+
+		assert.Equal(t, "자산 저장 시 오류 발생. 시세 조회 실패", r.Chat(1, ReadWrite, confirmYes).text)
+		assert.Empty(t, r.sessions)
+	})
+
+	t.Run("선택지 조회 실패 시 세션 종료", func(t *testing.T) {
+		stg.err = errors.New("db 연결 실패")
+		defer func() { stg.err = nil }()
+
+		r.Chat(1, ReadWrite, "/invest")
+		assert.Equal(t, "자금 선택지 조회 시 오류 발생. db 연결 실패", r.Chat(1, ReadWrite, "buy").text)
+		assert.Empty(t, r.sessions)
+	})
+}
+
+func TestRouterRole(t *testing.T) {
+
+	invests := make([]investCall, 0)
+	status := uint(0)
+
+	stg := &StorageMock{
+		funds:  []m.Fund{{ID: 1, Name: "개인"}},
+		assets: map[string]uint{"MSFT": 4},
+		market: &m.Market{Status: 4},
+		status: &status,
+	}
+	r := NewRouter(stg, &ServiceMock{invests: &invests})
+	r.fallback = func(path string) (string, error) { return "GET " + path, nil }
+
+	denied := "권한 없음. 조회 명령어만 사용 가능"
+
+	t.Run("조회 권한", func(t *testing.T) {
+		assert.Contains(t, r.Chat(1, ReadOnly, "/help").text, "명령어 목록")
+		assert.Equal(t, "현재 시장 단계 : BULL(4)", r.Chat(1, ReadOnly, "/market").text)
+		assert.Equal(t, "GET /funds/1/hist", r.Chat(1, ReadOnly, "/funds/1/hist").text)
+
+		assert.Equal(t, denied, r.Chat(1, ReadOnly, "/market 2").text)
+		assert.Equal(t, denied, r.Chat(1, ReadOnly, "/buy fund=1 asset=MSFT 1@100").text)
+		assert.Equal(t, denied, r.Chat(1, ReadOnly, "/invest").text)
+		assert.Empty(t, invests)
+		assert.Equal(t, uint(0), status)
+		assert.Empty(t, r.sessions)
+	})
+
+	t.Run("그룹 채팅 입력 중 조회 권한 사용자 답변 거부", func(t *testing.T) {
+		r.Chat(-100, ReadWrite, "/invest")
+		assert.Equal(t, denied, r.Chat(-100, ReadOnly, "buy").text)
+		assert.Equal(t, denied, r.Chat(-100, ReadOnly, "/cancel").text)
+		assert.Equal(t, "자금 선택", r.Chat(-100, ReadWrite, "buy").text)
+	})
+}
+
+func TestRouterConcurrent(t *testing.T) {
+
+	r := NewRouter(&StorageMock{}, &ServiceMock{})
+	started, release := make(chan struct{}), make(chan struct{})
+	r.register(&command{name: "/slow", run: func(a args) (string, error) {
+		started <- struct{}{}
+		<-release
+		return "완료", nil
+	}})
+	r.registerForm(&form{name: "/slowform", title: "지연 입력", submit: func(vals map[string]any) (string, error) {
+		started <- struct{}{}
+		<-release
+		return "저장 완료", nil
+	}})
+
+	t.Run("명령어 실행 중 다른 메시지 처리", func(t *testing.T) {
+		done := make(chan string)
+		go func() { done <- r.Chat(1, ReadWrite, "/slow").text }()
+		<-started
+
+		assert.Contains(t, r.Chat(2, ReadWrite, "/help").text, "명령어 목록")
+		assert.Contains(t, r.Chat(1, ReadWrite, "/help").text, "명령어 목록")
+
+		release <- struct{}{}
+		assert.Equal(t, "완료", <-done)
+	})
+
+	t.Run("저장 중 같은 채팅 답변 대기 요청", func(t *testing.T) {
+		r.Chat(1, ReadWrite, "/slowform")
+
+		done := make(chan string)
+		go func() { done <- r.Chat(1, ReadWrite, confirmYes).text }()
+		<-started
+
+		assert.Equal(t, "지연 입력 이전 입력 처리 중. 잠시 후 다시 시도", r.Chat(1, ReadWrite, confirmYes).text)
+		assert.Equal(t, "지연 입력 이전 입력 처리 중. 잠시 후 다시 시도", r.Chat(1, ReadWrite, "/cancel").text)
+
+		release <- struct{}{}
+		assert.Equal(t, "저장 완료", <-done)
+		assert.Empty(t, r.sessions)
+	})
+}
+
+func TestRouterChart(t *testing.T) {
+
+	days := -1
+	stg := &StorageMock{assets: map[string]uint{"MSFT": 4}}
+	svc := &ServiceMock{days: &days}
+	r := NewRouter(stg, svc)
+
+	t.Run("자금 자산 비중", func(t *testing.T) {
+		rp := r.Chat(1, ReadOnly, "/chart fund 1")
+		assert.Equal(t, reply{text: "자금 1 자산 비중", photo: []byte{1}}, rp)
+	})
+
+	t.Run("변동 자산 비율", func(t *testing.T) {
+		rp := r.Chat(1, ReadOnly, "/chart ratio")
+		assert.Equal(t, []byte{2}, rp.photo)
+	})
+
+	t.Run("시세", func(t *testing.T) {
+		rp := r.Chat(1, ReadOnly, "/chart price MSFT")
+		assert.Equal(t, "자산 4 시세", rp.text)
+		assert.Equal(t, 0, days)
+
+		r.Chat(1, ReadOnly, "/chart price MSFT 60")
+		assert.Equal(t, 60, days)
+	})
+
+	t.Run("실패 테스트", func(t *testing.T) {
+		rp := r.Chat(1, ReadOnly, "/chart")
+		assert.Contains(t, rp.text, "차트 종류 필수")
+		assert.Nil(t, rp.photo)
+
+		assert.Contains(t, r.Chat(1, ReadOnly, "/chart pie").text, "지원 차트 : fund, ratio, price")
+		assert.Contains(t, r.Chat(1, ReadOnly, "/chart fund x").text, "자금 ID는 양의 정수")
+		assert.Contains(t, r.Chat(1, ReadOnly, "/chart price AAPL").text, "자산 미존재")
+
+		svc.err = errors.New("일봉 부족")
+		defer func() { svc.err = nil }()
+		assert.Equal(t, "/chart 실행 시 오류 발생. 일봉 부족", r.Chat(1, ReadOnly, "/chart price MSFT").text)
+	})
+}
+
+func TestRouterCredential(t *testing.T) {
+
+	t.Run("인증 정보 조회 실패 시 API 미요청", func(t *testing.T) {
+		api := client.New("http://localhost:3000", client.WithCredential(func() (string, error) {
+			return "", errors.New("JWT 서명 키 미설정")
+		}))
+		r := NewRouter(&StorageMock{}, &ServiceMock{}, WithClient(api))
+		assert.Equal(t, "API 인증 정보 조회 시 오류 발생. JWT 서명 키 미설정", r.Chat(1, ReadOnly, "/funds/1/hist").text)
+		assert.Equal(t, "/unwatch 실행 시 오류 발생. API 인증 정보 조회 시 오류 발생. JWT 서명 키 미설정", r.Chat(1, ReadWrite, "/unwatch 1").text)
+	})
+
+	t.Run("Bearer 헤더", func(t *testing.T) {
+		var header string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			header = req.Header.Get("Authorization")
+			w.Write([]byte("감시 대상 삭제 성공"))
+		}))
+		defer srv.Close()
+
+		api := client.New(srv.URL, client.WithCredential(func() (string, error) { return "inv_key", nil }))
+		r := NewRouter(&StorageMock{}, &ServiceMock{}, WithClient(api))
+		assert.Equal(t, "감시 대상 삭제 성공", r.Chat(1, ReadWrite, "/unwatch 1").text)
+		assert.Equal(t, "Bearer inv_key", header)
+	})
+}
+
+func TestRouterAPI(t *testing.T) {
+
+	var method, path, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		b, _ := io.ReadAll(req.Body)
+		method, path, body = req.Method, req.URL.RequestURI(), string(b)
+		switch {
+		case req.URL.Path == "/monitors/9/check":
+			w.Write([]byte(`{"value":"S&P 500","changed":true,"checked_at":"2024-12-01 09:00:00"}`))
+		case req.URL.Path == "/funds/1/hist":
+			w.Write([]byte(`[{"fund_id":1,"asset_name":"S&P"}]`))
+		case req.URL.Path == "/monitors":
+			w.Write([]byte("감시 대상 저장 성공. ID : 9"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"NOT_FOUND","message":"대상 미존재"}`))
+		}
+	}))
+	defer srv.Close()
+
+	r := NewRouter(&StorageMock{}, &ServiceMock{}, WithClient(client.New(srv.URL)))
+
+	t.Run("경로 형태 조회", func(t *testing.T) {
+		assert.Equal(t, "[\n\t{\n\t\t\"asset_name\": \"S&P\",\n\t\t\"fund_id\": 1\n\t}\n]\n", r.Chat(1, ReadOnly, "/funds/1/hist").text)
+		assert.Equal(t, "GET /funds/1/hist", method+" "+path)
+
+		assert.Equal(t, "404 NOT_FOUND. 대상 미존재", r.Chat(1, ReadOnly, "/funds/2/none").text)
+	})
+
+	t.Run("감시 대상 추가", func(t *testing.T) {
+		rtn := r.Chat(1, ReadWrite, `/watch {"name":"S&P","url":"https://example.com","selector":"#price"}`).text
+		assert.Equal(t, "감시 대상 저장 성공. ID : 9", rtn)
+		assert.Equal(t, "POST /monitors", method+" "+path)
+		assert.JSONEq(t, `{"name":"S&P","url":"https://example.com","selector":"#price"}`, body)
+
+		assert.Contains(t, r.Chat(1, ReadWrite, `/watch {"name":`).text, "Monitor 양식 JSON 오류")
+	})
+
+	t.Run("감시 대상 확인", func(t *testing.T) {
+		rtn := r.Chat(1, ReadWrite, "/check 9").text
+		assert.Equal(t, "POST /monitors/9/check", method+" "+path)
+		assert.Contains(t, rtn, "\"value\": \"S&P 500\"")
+		assert.Contains(t, rtn, "\"changed\": true")
+
+		assert.Equal(t, "/unwatch 실행 시 오류 발생. 404 NOT_FOUND. 대상 미존재", r.Chat(1, ReadWrite, "/unwatch 3").text)
+		assert.Equal(t, "DELETE /monitors/3", method+" "+path)
+	})
+}
@@ -1,21 +1,20 @@
 package bot
 
 import (
-	"bytes"
-	"encoding/json"
-	"io"
-	"net/http"
+	"fmt"
+	"log"
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 )
 
 type TeleBot struct {
 	bot     *tgbotapi.BotAPI
-	chatId  int64
+	chatId  int64 // 알림 수신 채팅
 	updates tgbotapi.UpdatesChannel
+	acl     acl
 }
 
-func NewTeleBot(token string, chatId int64) (*TeleBot, error) {
+func NewTeleBot(token string, chatId int64, opts ...func(*TeleBot)) (*TeleBot, error) {
 
 	bot, err := tgbotapi.NewBotAPI(token)
 	if err != nil {
@@ -27,142 +26,247 @@ func NewTeleBot(token string, chatId int64) (*TeleBot, error) {
 	u.Timeout = 60
 	updates := bot.GetUpdatesChan(u)
 
-	return &TeleBot{
+	t := &TeleBot{
 		bot:     bot,
 		chatId:  chatId,
 		updates: updates,
-	}, nil
+		acl:     acl{chatId: ReadWrite},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+
+	return t, nil
 }
 
+// 복호화 키는 ReadWrite 사용자의 메시지만 허용
 func (t TeleBot) InitKey() string {
 	t.SendMessage("Enter decrypt key for invest server")
-	update := <-t.updates
 
-	return update.Message.Text
+	for update := range t.updates {
+		in, ok := incoming(update)
+		if !ok {
+			continue
+		}
+		role := t.authorize(in)
+		if role == ReadOnly {
+			t.send(in.chatId, reply{text: "권한 없음. 복호화 키는 쓰기 권한 사용자만 입력 가능"})
+		}
+		if role < ReadWrite {
+			continue
+		}
+		return in.text
+	}
+
+	return ""
+}
+
+// 알림 메시지. 설정된 chatId로 전송
+func (t TeleBot) SendMessage(msg string) error {
+	_, err := t.bot.Send(tgbotapi.NewMessage(t.chatId, msg))
+	return err
 }
 
-func (t TeleBot) SendMessage(msg string) {
-	t.bot.Send(tgbotapi.NewMessage(t.chatId, msg))
+// MarkdownV2 서식 메시지. 특수 문자는 escape된 상태여야 함
+func (t TeleBot) SendMarkdown(msg string) error {
+	m := tgbotapi.NewMessage(t.chatId, msg)
+	m.ParseMode = tgbotapi.ModeMarkdownV2
+	_, err := t.bot.Send(m)
+	return err
 }
 
-func (t TeleBot) Listen(ch chan string) {
+// 명령어 응답은 요청한 채팅으로 전송
+func (t TeleBot) Listen(r *Router) {
 
 	for update := range t.updates {
-		if update.Message != nil {
-			txt := update.Message.Text
-			if txt[0] != '/' {
-				continue
-			}
 
-			switch txt {
-			case "/help":
-				ch <- `
-				조회 API 목록
-				/funds
-				/funds/{id}/hist
-				/funds/:{id}/assets
-				/assets/list
-				/assets/{id}
-				/assets/{id}/hist
-				/market
-				/market/indicators/{date?}
-				`
-			case "/form":
-				ch <- `
-				Asset
-				{
-				  ("id" : , )
-				  "name": "",
-				  "category": ,
-				  "code": "",
-				  "currency": "",
-				  "top": ,
-				  "bottom": ,
-				  "ema": ,
-				  "sel_price": ,
-				  "buy_price": 
-				}
-
-				Invest
-				{
-				  "fund_id" : ,
-				  "asset_id" : ,
-				  "price" : ,
-				  "count" :
-				}
-
-				AddFunds
-				{
-				  "name" : ""
-				}
-				
-				SaveMarketStatus
-				{
-				  "status" : 
-				}
-				`
-			default:
-				rtn, err := httpsend(txt)
-				if err != nil {
-					ch <- err.Error()
-				} else {
-					ch <- rtn
-				}
-			}
+		in, ok := incoming(update)
+		if !ok {
+			continue
+		}
 
+		if cb := update.CallbackQuery; cb != nil {
+			t.bot.Request(tgbotapi.NewCallback(cb.ID, ""))
 		}
+
+		role := t.authorize(in)
+		if role == RoleNone {
+			continue
+		}
+
+		if in.callback != 0 { // 선택 완료된 keyboard 제거. 중복 선택 방지
+			t.bot.Request(tgbotapi.NewEditMessageReplyMarkup(in.chatId, in.callback, tgbotapi.InlineKeyboardMarkup{InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{}}))
+		}
+
+		rp := r.Chat(in.chatId, role, in.text)
+		if rp.text == "" {
+			continue
+		}
+		t.send(in.chatId, rp)
 	}
 }
 
-func httpsend(path string) (string, error) {
+// 메시지 수신 중단. 진행 중인 수신 대기(long polling)가 끝나면 Listen 종료
+func (t TeleBot) Stop() {
+	t.bot.StopReceivingUpdates()
+}
 
-	url := "http://localhost:3000" + path
-	req, _ := http.NewRequest(http.MethodGet, url, nil)
+// 수신 메시지
+type message struct {
+	chatId   int64
+	userId   int64
+	userName string
+	text     string
+	callback int // inline keyboard 선택 시 keyboard 메시지 ID
+}
 
-	client := &http.Client{}
-	res, err := client.Do(req)
-	if err != nil {
-		return "", err
+// 텍스트 메시지 혹은 inline keyboard 선택. 사진, 스티커 등은 제외
+func incoming(update tgbotapi.Update) (message, bool) {
+
+	switch {
+	case update.CallbackQuery != nil:
+		cb := update.CallbackQuery
+		if cb.Message == nil || cb.Message.Chat == nil || cb.From == nil {
+			return message{}, false
+		}
+		return message{
+			chatId:   cb.Message.Chat.ID,
+			userId:   cb.From.ID,
+			userName: cb.From.UserName,
+			text:     cb.Data,
+			callback: cb.Message.MessageID,
+		}, true
+	case update.Message != nil && update.Message.Text != "" && update.Message.Chat != nil:
+		msg := update.Message
+		in := message{chatId: msg.Chat.ID, text: msg.Text}
+		if msg.From != nil {
+			in.userId, in.userName = msg.From.ID, msg.From.UserName
+		}
+		return in, true
+	default:
+		return message{}, false
 	}
+}
 
-	defer res.Body.Close()
+// 허용 목록 확인. 미허용 사용자는 기록 후 거부 응답
+func (t TeleBot) authorize(in message) Role {
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return "", err
+	role := t.acl.role(in.chatId, in.userId)
+	if role == RoleNone {
+		log.Printf("[Audit] 미허용 사용자 요청 거부. chat : %d, user : %d(%s), text : %q", in.chatId, in.userId, in.userName, in.text)
+		t.send(in.chatId, reply{text: fmt.Sprintf("허용되지 않은 사용자. chat : %d, user : %d", in.chatId, in.userId)})
 	}
+	return role
+}
 
-	var jsonData interface{}
+// 차트 등 사진 알림. 설정된 chatId로 전송
+func (t TeleBot) SendPhoto(img []byte, caption string) {
+	t.send(t.chatId, reply{text: caption, photo: img})
+}
 
-	err = json.Unmarshal(body, &jsonData)
-	if err != nil {
-		return "", err
-	}
+// 텔레그램 사진 설명 최대 길이
+const captionLimit = 1024
 
-	// pretty, err := json.MarshalIndent(jsonData, "", "\t") // memo. 단순 MarshalIndent 사용하면, &을 \u0026로 바꿔버림.
-	// if err != nil {
-	// 	return "", err
-	// }
+// 선택지는 inline keyboard로 전송
+func (t TeleBot) send(chatId int64, rp reply) {
 
-	// return string(pretty), nil
-	var buffer bytes.Buffer
-	encoder := json.NewEncoder(&buffer)
-	encoder.SetEscapeHTML(false) // Disable HTML escaping
+	if rp.photo != nil {
+		photo := tgbotapi.NewPhoto(chatId, tgbotapi.FileBytes{Name: "chart.png", Bytes: rp.photo})
+		caption := []rune(rp.text)
+		if len(caption) > captionLimit {
+			caption = caption[:captionLimit]
+		}
+		photo.Caption = string(caption)
+		t.bot.Send(photo)
+		return
+	}
 
-	// Marshal with indentation
-	encoder.SetIndent("", "\t")
-	err = encoder.Encode(jsonData)
-	if err != nil {
-		return "", err
+	msg := tgbotapi.NewMessage(chatId, rp.text)
+	if len(rp.choices) > 0 {
+		keyboard := make([][]tgbotapi.InlineKeyboardButton, len(rp.choices))
+		for i, row := range rp.choices {
+			buttons := make([]tgbotapi.InlineKeyboardButton, len(row))
+			for j, c := range row {
+				buttons[j] = tgbotapi.NewInlineKeyboardButtonData(c.label, c.data)
+			}
+			keyboard[i] = buttons
+		}
+		msg.ReplyMarkup = tgbotapi.NewInlineKeyboardMarkup(keyboard...)
 	}
+	t.bot.Send(msg)
+}
+
+// API 요청 body 양식. 전체 명세는 API 서버의 /docs
+const formHelp = `
+전체 API 명세 : ` + apiBase + `/docs
+
+Asset
+{
+  ("id" : , )
+  "name": "",
+  "category": ,
+  "code": "",
+  "currency": "",
+  "top": ,
+  "bottom": ,
+  "sel_price": ,
+  "buy_price": ,
+  ("averages": ["EMA20", "EMA200"])
+}
 
-	return buffer.String(), nil
+Averages
+{
+  "averages": ["EMA20", "SMA60", "EMA200"],
+  "reference": "EMA200"
 }
 
-/*
-var buffer bytes.Buffer
-encoder := json.NewEncoder(&buffer)
-encoder.SetEscapeHTML(false) // Disable HTML escaping
-err := encoder.Encode(v)
-return buffer.Bytes(), err
-*/
+Invest
+{
+  "fund_id" : ,
+  "asset_id" : ,
+  "price" : ,
+  "count" :
+}
+
+AddFunds
+{
+  "name" : ""
+}
+
+ScoreWeights
+{
+  "weights" : {"deviation": , "rsi": , "zscore": , "range52w": }
+}
+
+SaveMarketStatus
+{
+  "status" : 
+}
+
+Indicator
+{
+  "name" : "",
+  "provider" : "fear_greed|exchange_rate|kis_overseas|kis_fx|kis_treasury|kis_domestic",
+  ("code" : "",)
+  ("spec" : "0 3 9 * * 1-5",)
+  ("unit" : "")
+}
+
+IndicatorAlert
+{
+  "kind" : "above|below|change|cross_above|cross_below",
+  "threshold" : ,
+  ("days" : ,)
+  ("period" : )
+}
+
+Monitor
+{
+  "name" : "",
+  "url" : "",
+  "kind" : "css|json",
+  "selector" : "",
+  ("spec" : "0 */15 * * * *",)
+  ("expected" : "")
+}
+`
@@ -0,0 +1,110 @@
+package bus
+
+import (
+	"log"
+	"slices"
+	"sync"
+)
+
+// 구독자별 대기 이벤트 수. 초과 시 발행 측 대기
+const bufferSize = 100
+
+/*
+구독 필터
+  - Kinds : 미설정 시 전체 종류
+  - Severity : 최소 심각도
+*/
+type Filter struct {
+	Kinds    []Kind
+	Severity Severity
+}
+
+func (f Filter) Match(e Event) bool {
+	if e.Severity() < f.Severity {
+		return false
+	}
+	return len(f.Kinds) == 0 || slices.Contains(f.Kinds, e.Kind())
+}
+
+type subscriber struct {
+	filter Filter
+	events chan Event
+	handle func(Event)
+}
+
+/*
+프로세스 내 이벤트 버스
+  - 구독자별 고루틴에서 발행 순서대로 처리. 느린 구독자가 다른 구독자를 지연시키지 않음
+  - 구독자 처리 중 panic은 로그만 남기고 다음 이벤트 처리
+*/
+type Bus struct {
+	mu     sync.RWMutex
+	subs   []*subscriber
+	closed bool
+	wg     sync.WaitGroup
+}
+
+func New() *Bus {
+	return &Bus{}
+}
+
+func (b *Bus) Subscribe(f Filter, handle func(Event)) {
+
+	s := &subscriber{filter: f, events: make(chan Event, bufferSize), handle: handle}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.subs = append(b.subs, s)
+
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		for e := range s.events {
+			s.run(e)
+		}
+	}()
+}
+
+func (s *subscriber) run(e Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[Bus] %s 처리 시, panic 발생. %v", e.Kind(), r)
+		}
+	}()
+	s.handle(e)
+}
+
+// 필터가 일치하는 구독자에게 전달. 종료 후 발행은 로그만 남김
+func (b *Bus) Publish(e Event) {
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.closed {
+		log.Printf("[Bus] 종료 후 발행. %s", e)
+		return
+	}
+
+	for _, s := range b.subs {
+		if s.filter.Match(e) {
+			s.events <- e
+		}
+	}
+}
+
+// 신규 발행 중단 후 구독자가 대기 이벤트를 모두 처리할 때까지 대기
+func (b *Bus) Close() {
+
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		for _, s := range b.subs {
+			close(s.events)
+		}
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
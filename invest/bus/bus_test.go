@@ -0,0 +1,111 @@
+package bus
+
+import (
+	m "invest/model"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+
+	alert := PriceAlert{}
+	failure := JobError{Job: "AssetEvent"}
+	report := Digest{}
+
+	t.Run("종류 미설정 시 전체", func(t *testing.T) {
+		f := Filter{}
+		assert.True(t, f.Match(alert))
+		assert.True(t, f.Match(report))
+	})
+
+	t.Run("종류 일치", func(t *testing.T) {
+		f := Filter{Kinds: []Kind{KindPriceAlert, KindJobError}}
+		assert.True(t, f.Match(alert))
+		assert.True(t, f.Match(failure))
+		assert.False(t, f.Match(report))
+	})
+
+	t.Run("최소 심각도", func(t *testing.T) {
+		f := Filter{Severity: Warning}
+		assert.True(t, f.Match(alert))
+		assert.True(t, f.Match(failure))
+		assert.False(t, f.Match(report))
+	})
+
+	t.Run("범위 내 자금 안내는 Info", func(t *testing.T) {
+		assert.Equal(t, Info, PortfolioImbalance{}.Severity())
+		assert.Equal(t, Warning, PortfolioImbalance{State: m.RebalanceOver}.Severity())
+	})
+}
+
+func TestBus(t *testing.T) {
+
+	t.Run("필터 일치 구독자에게 발행 순서대로 전달", func(t *testing.T) {
+		b := New()
+
+		var mu sync.Mutex
+		all, errs := make([]Event, 0), make([]Event, 0)
+		b.Subscribe(Filter{}, func(e Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			all = append(all, e)
+		})
+		b.Subscribe(Filter{Kinds: []Kind{KindJobError}}, func(e Event) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, e)
+		})
+
+		b.Publish(PriceAlert{Price: 1})
+		b.Publish(JobError{Job: "CliEvent"})
+		b.Publish(PriceAlert{Price: 2})
+		b.Close()
+
+		assert.Equal(t, []Event{PriceAlert{Price: 1}, JobError{Job: "CliEvent"}, PriceAlert{Price: 2}}, all)
+		assert.Equal(t, []Event{JobError{Job: "CliEvent"}}, errs)
+	})
+
+	t.Run("구독자 panic 시 다음 이벤트 처리", func(t *testing.T) {
+		b := New()
+
+		cnt := 0
+		b.Subscribe(Filter{}, func(e Event) {
+			cnt++
+			if cnt == 1 {
+				panic("render")
+			}
+		})
+
+		b.Publish(Digest{})
+		b.Publish(Digest{})
+		b.Close()
+		assert.Equal(t, 2, cnt)
+	})
+
+	t.Run("종료 후 발행 무시", func(t *testing.T) {
+		b := New()
+		cnt := 0
+		b.Subscribe(Filter{}, func(e Event) { cnt++ })
+		b.Close()
+
+		b.Publish(Digest{})
+		b.Close()
+		assert.Equal(t, 0, cnt)
+	})
+
+	t.Run("종류, 심각도 변환", func(t *testing.T) {
+		k, err := ToKind("pricealert")
+		assert.NoError(t, err)
+		assert.Equal(t, KindPriceAlert, k)
+		_, err = ToKind("Unknown")
+		assert.Error(t, err)
+
+		s, err := ToSeverity("warning")
+		assert.NoError(t, err)
+		assert.Equal(t, Warning, s)
+		_, err = ToSeverity("fatal")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,195 @@
+package bus
+
+import (
+	"fmt"
+	m "invest/model"
+	"invest/render"
+	"strings"
+	"time"
+)
+
+// 이벤트 종류. 구독 필터, 알림 라우팅 기준
+type Kind string
+
+const (
+	KindPriceAlert         Kind = "PriceAlert"
+	KindPortfolioImbalance Kind = "PortfolioImbalance"
+	KindIndicatorUpdate    Kind = "IndicatorUpdate"
+	KindIndicatorAlert     Kind = "IndicatorAlert"
+	KindMonitorChange      Kind = "MonitorChange"
+	KindCliUpdate          Kind = "CliUpdate"
+	KindDigest             Kind = "Digest"
+	KindJobError           Kind = "JobError"
+)
+
+var Kinds = []Kind{
+	KindPriceAlert, KindPortfolioImbalance, KindIndicatorUpdate, KindIndicatorAlert,
+	KindMonitorChange, KindCliUpdate, KindDigest, KindJobError,
+}
+
+func ToKind(s string) (Kind, error) {
+	for _, k := range Kinds {
+		if strings.EqualFold(string(k), s) {
+			return k, nil
+		}
+	}
+	return "", fmt.Errorf("존재하지 않는 이벤트 종류. 입력 값 : %s", s)
+}
+
+// 심각도. 구독 시 최소 심각도 이상만 수신
+type Severity uint
+
+const (
+	Info    Severity = iota // 정기 갱신, 리포트
+	Warning                 // 투자 행동이 필요한 알림
+	Error                   // 작업 실패
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+func ToSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "", "info":
+		return Info, nil
+	case "warning":
+		return Warning, nil
+	case "error":
+		return Error, nil
+	}
+	return Info, fmt.Errorf("존재하지 않는 심각도. info, warning, error. 입력 값 : %s", s)
+}
+
+/*
+버스로 발행되는 이벤트
+  - String : 기본 문구. 종류 이름의 일반 텍스트 템플릿(render)으로 작성
+*/
+type Event interface {
+	Kind() Kind
+	Severity() Severity
+	String() string
+}
+
+// 매수/매도 기준가 도달
+type PriceAlert struct {
+	Asset m.Asset
+	Sell  bool
+	Bound float64 // 매도 시 상한, 매수 시 하한
+	Price float64
+	At    time.Time
+}
+
+func (e PriceAlert) Kind() Kind         { return KindPriceAlert }
+func (e PriceAlert) Severity() Severity { return Warning }
+func (e PriceAlert) String() string     { return render.Text(string(e.Kind()), e) }
+
+/*
+자금의 변동 자산 비중과 처분/매수 우선순위
+  - State : 초과, 부족. 범위 내 자금의 매수 우선순위 안내는 빈 값
+*/
+type PortfolioImbalance struct {
+	FundID     uint
+	State      m.RebalanceState
+	Rate       float64
+	Volatile   float64
+	Total      float64
+	Level      m.MarketLevel
+	Priorities []m.AssetPriority
+}
+
+func (e PortfolioImbalance) Kind() Kind { return KindPortfolioImbalance }
+
+func (e PortfolioImbalance) Severity() Severity {
+	if e.State == "" {
+		return Info
+	}
+	return Warning
+}
+
+func (e PortfolioImbalance) String() string { return render.Text(string(e.Kind()), e) }
+
+// 수집된 지표들의 전일 대비 등락
+type IndicatorUpdate struct {
+	Changes []m.IndicatorChange
+}
+
+func (e IndicatorUpdate) Kind() Kind         { return KindIndicatorUpdate }
+func (e IndicatorUpdate) Severity() Severity { return Info }
+func (e IndicatorUpdate) String() string     { return render.Text(string(e.Kind()), e) }
+
+// 지표 알림 규칙 충족
+type IndicatorAlert struct {
+	Indicator m.Indicator
+	Rule      m.IndicatorAlert
+	Value     float64
+}
+
+func (e IndicatorAlert) Kind() Kind         { return KindIndicatorAlert }
+func (e IndicatorAlert) Severity() Severity { return Warning }
+func (e IndicatorAlert) String() string     { return render.Text(string(e.Kind()), e) }
+
+/*
+웹 페이지 감시 대상 변동
+  - Changed : 직전 값과 다름. 최초 확인이면 false
+  - Diff : 직전 값 대비 줄 단위 변경 내역
+*/
+type MonitorChange struct {
+	Monitor  m.Monitor
+	Value    string
+	Previous string
+	Changed  bool
+	Diff     string
+}
+
+func (e MonitorChange) Kind() Kind         { return KindMonitorChange }
+func (e MonitorChange) Severity() Severity { return Warning }
+func (e MonitorChange) String() string     { return render.Text(string(e.Kind()), e) }
+
+// OECD 경기선행지수 신규 월 발표
+type CliUpdate struct {
+	Latest   m.CliIndex
+	Previous *m.CliIndex
+}
+
+func (e CliUpdate) Kind() Kind         { return KindCliUpdate }
+func (e CliUpdate) Severity() Severity { return Info }
+func (e CliUpdate) String() string     { return render.Text(string(e.Kind()), e) }
+
+/*
+정기 리포트. 항목별 문구는 Digest 템플릿에서 작성
+  - Charts : 리포트 문구 뒤에 이어서 전송할 차트. 사진 전송 채널(텔레그램)에서만 전송
+*/
+type Digest struct {
+	Report *m.DigestReport
+	Charts []m.Chart `json:"-"`
+}
+
+func (e Digest) Kind() Kind         { return KindDigest }
+func (e Digest) Severity() Severity { return Info }
+func (e Digest) String() string     { return render.Text(string(e.Kind()), e) }
+
+/*
+주기 작업 실패
+  - Job : 작업 이름(AssetEvent 등)
+  - Step : 실패 단계(RetrieveAssetList 등)
+  - Target : 실패 대상 지표, 감시 대상, 종목 코드. 없으면 빈 값
+  - Message : 원인 오류
+*/
+type JobError struct {
+	Job     string
+	Step    string
+	Target  string
+	Message string
+}
+
+func (e JobError) Kind() Kind         { return KindJobError }
+func (e JobError) Severity() Severity { return Error }
+func (e JobError) String() string     { return render.Text(string(e.Kind()), e) }
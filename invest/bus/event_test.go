@@ -0,0 +1,128 @@
+package bus
+
+import (
+	m "invest/model"
+	"invest/render"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestEventTemplates(t *testing.T) {
+
+	prev, cliAt := 20.0, datatypes.Date(time.Date(2024, 12, 1, 0, 0, 0, 0, time.Local))
+	from, to := time.Date(2024, 9, 9, 18, 0, 0, 0, time.Local), time.Date(2024, 9, 10, 18, 0, 0, 0, time.Local)
+	level := m.MarketLevel(4)
+	fundPrev := 9500000.0
+	indicator := m.Indicator{Name: "공포 탐욕 지수", Unit: "pt"}
+	events := []Event{
+		PriceAlert{Asset: m.Asset{ID: 1, Name: "종목1"}, Sell: true, Bound: 500, Price: 510},
+		PortfolioImbalance{FundID: 1, State: m.RebalanceOver, Rate: 0.7, Volatile: 70, Total: 100, Level: m.MarketLevel(3),
+			Priorities: []m.AssetPriority{{Asset: m.Asset{ID: 2, Name: "종목2"}, Present: 100, Average: 90, Highest: 120, Score: 0.5}}},
+		IndicatorUpdate{Changes: []m.IndicatorChange{{Indicator: indicator, Value: 23, Previous: &prev}, {Indicator: m.Indicator{Name: "VIX"}, Value: 15}}},
+		IndicatorAlert{Indicator: indicator, Rule: m.IndicatorAlert{Kind: m.ChangeAlert, Days: 1, Threshold: -3}, Value: 18},
+		MonitorChange{Monitor: m.Monitor{Name: "공지", Url: "https://example.com", Expected: "A"}, Value: "B", Previous: "A", Changed: true, Diff: "- A\n+ B"},
+		CliUpdate{Latest: m.CliIndex{CreatedAt: cliAt, Index: 100.52}, Previous: &m.CliIndex{Index: 100.45}},
+		Digest{Report: &m.DigestReport{
+			Spec: m.DigestSpec{Kind: m.DailyDigest}, From: from, To: to,
+			Funds:      []m.FundDigest{{FundID: 1, Value: 10000000, Previous: &fundPrev}},
+			Movers:     []m.AssetMove{{Asset: m.Asset{ID: 2, Name: "MSFT"}, From: 110, To: 121}},
+			Rebalances: []m.Rebalance{{FundID: 1, Rate: 0.6, Min: 0.5, Max: 0.6, State: m.RebalanceNear}},
+			Market:     &level,
+			Indicators: []m.IndicatorMove{{Indicator: m.Indicator{Name: "VIX"}, From: 16, To: 20}},
+			Alerts:     []m.AlertLog{{Source: m.MonitorSource, Message: "[MonitorEvent] 공시 변경\n상세 내역", CreatedAt: from.Add(8 * time.Hour)}},
+			Errors:     []m.DigestError{{Step: "RetrieveLatestDigestHist", Message: "db down"}},
+		}},
+		JobError{Job: "IndexEvent", Step: "IndicatorValue", Target: "VIX", Message: "timeout"},
+	}
+
+	t.Run("모든 언어, 서식 템플릿 실행", func(t *testing.T) {
+		tpl := render.Default()
+		for _, e := range events {
+			for _, l := range render.Locales {
+				for _, v := range []render.Variant{render.Plain, render.Markdown, render.HTML} {
+					s, err := tpl.Render(l, v, string(e.Kind()), e)
+					assert.NoError(t, err, "%s/%s/%s", l, v, e.Kind())
+					assert.NotEmpty(t, s)
+				}
+				_, err := tpl.Render(l, render.Plain, string(e.Kind())+".title", e)
+				assert.NoError(t, err, "%s/%s.title", l, e.Kind())
+			}
+		}
+	})
+
+	t.Run("기본 문구", func(t *testing.T) {
+		assert.Equal(t, "매도 종목1. ID : 1. 상한 : 500.00. 현재가 : 510.00", events[0].String())
+		assert.Equal(t, "금일 공포 탐욕 지수 : 23.00 pt\n   (전일 : 20.00, +15.00%)\n금일 VIX : 15.00", events[2].String())
+		assert.Equal(t, "[지표 알림] 공포 탐욕 지수 : 18.00 pt\n   (1일 변동률 -3.00% 이하)", events[3].String())
+		assert.Equal(t, "[공지] 기대 값과 다름. A => B\n- A\n+ B\nhttps://example.com", events[4].String())
+		assert.Equal(t, "OECD 경기선행지수 : 100.52 (2024-12)\n   (전월 : 100.45)", events[5].String())
+		assert.Equal(t, "[IndexEvent] IndicatorValue 시, 에러 발생. VIX. timeout", events[7].String())
+	})
+
+	t.Run("영문 문구", func(t *testing.T) {
+		s, _ := render.Default().Render(render.English, render.Plain, string(KindIndicatorAlert), events[3])
+		assert.Equal(t, "[Indicator alert] 공포 탐욕 지수 : 18.00 pt\n   (1-day change <= -3.00%)", s)
+
+		s, _ = render.Default().Render(render.English, render.Plain, string(KindPortfolioImbalance)+".title", events[1])
+		assert.Equal(t, "Fund 1 volatile asset weight over", s)
+
+		s, _ = render.Default().Render(render.English, render.Plain, string(KindJobError), events[7])
+		assert.Equal(t, "[IndexEvent] IndicatorValue failed. VIX. timeout", s)
+	})
+
+	t.Run("정기 리포트 언어별 문구", func(t *testing.T) {
+		assert.Equal(t, `[DigestEvent] 일간 리포트 (2024-09-09 18:00 ~ 2024-09-10 18:00)
+
+■ 자금 평가 금액
+자금 1 : 10,000,000원 (+500,000원, +5.26%)
+
+■ 보유 자산 등락 (상위 5)
+MSFT : 110 → 121 (+10.00%)
+
+■ 리밸런싱
+자금 1 변동 자산 비율 60.0% (허용 50%~60%, 근접)
+
+■ 시장
+시장 단계 : BULL
+VIX : 16 → 20 (+25.00%)
+
+■ 알림 (1건)
+09-10 02:00 [monitor] [MonitorEvent] 공시 변경
+
+■ 조회 실패
+RetrieveLatestDigestHist 조회 실패. db down`, events[6].String())
+
+		s, _ := render.Default().Render(render.English, render.Plain, string(KindDigest), events[6])
+		assert.Equal(t, `[DigestEvent] Daily digest (2024-09-09 18:00 ~ 2024-09-10 18:00)
+
+■ Fund values
+Fund 1 : 10,000,000 KRW (+500,000 KRW, +5.26%)
+
+■ Top 5 movers
+MSFT : 110 → 121 (+10.00%)
+
+■ Rebalancing
+Fund 1 volatile asset rate 60.0% (allowed 50%~60%, near)
+
+■ Market
+Market level : BULL
+VIX : 16 → 20 (+25.00%)
+
+■ Alerts (1)
+09-10 02:00 [monitor] [MonitorEvent] 공시 변경
+
+■ Failed lookups
+RetrieveLatestDigestHist failed. db down`, s)
+
+		s, _ = render.Default().Render(render.English, render.Markdown, string(KindDigest), events[6])
+		assert.Contains(t, s, "*Daily digest*\n2024\\-09\\-09 18:00 \\~ 2024\\-09\\-10 18:00")
+		assert.Contains(t, s, "Fund 1 volatile asset rate 60\\.0% \\(allowed 50%\\~60%, near\\)")
+
+		s, _ = render.Default().Render(render.English, render.HTML, string(KindDigest), events[6])
+		assert.Contains(t, s, "<h4>Top 5 movers</h4>")
+		assert.Contains(t, s, "<li>Market level : BULL</li>")
+	})
+}
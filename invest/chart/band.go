@@ -0,0 +1,83 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+)
+
+// 비율과 허용 범위. 비율, 범위 모두 0~1
+type Band struct {
+	Label string // ASCII. ex) #1
+	Value float64
+	Min   float64
+	Max   float64
+}
+
+var (
+	bandTrack = color.RGBA{240, 240, 240, 255}
+	bandRange = color.RGBA{200, 235, 200, 255}
+	inBand    = color.RGBA{85, 172, 238, 255}
+	overBand  = color.RGBA{221, 46, 68, 255}
+	underBand = color.RGBA{244, 144, 12, 255}
+)
+
+/*
+Bands
+항목별 가로 막대. 허용 범위(Min~Max)는 초록 음영
+  - 범위 내 파랑, 초과 빨강, 미달 주황
+*/
+func Bands(bands []Band) ([]byte, error) {
+
+	if len(bands) == 0 {
+		return nil, errors.New("차트 값 없음")
+	}
+
+	const left, right, margin, bottom = 60, 40, 30, 50
+	plotW := Width - left - right
+	rowH := min(90, (Height-margin-bottom)/len(bands))
+	barH := rowH * 2 / 5
+	top := max(margin, (Height-bottom-rowH*len(bands))/2) // 항목이 적으면 세로 가운데 정렬
+
+	c := newCanvas()
+	xOf := func(v float64) int {
+		return left + int(clamp(v, 0, 1)*float64(plotW))
+	}
+
+	// 0~100% 눈금
+	axisY := top + rowH*len(bands)
+	for p := 0; p <= 100; p += 20 {
+		x := xOf(float64(p) / 100)
+		c.rect(x, top, x+1, axisY, grid)
+		c.centerText(x, axisY+15, fmt.Sprintf("%d%%", p))
+	}
+
+	for i, b := range bands {
+		y0 := top + i*rowH + (rowH-barH)/2
+		y1 := y0 + barH
+
+		c.rect(left, y0, left+plotW, y1, bandTrack)
+		c.rect(xOf(b.Min), y0-4, xOf(b.Max), y1+4, bandRange)
+
+		col := inBand
+		switch {
+		case b.Value > b.Max:
+			col = overBand
+		case b.Value < b.Min:
+			col = underBand
+		}
+		c.rect(left, y0+barH/4, xOf(b.Value), y1-barH/4, col)
+
+		c.rightText(left-8, (y0+y1)/2, b.Label)
+		c.text(xOf(b.Value)+6, (y0+y1)/2+5, fmt.Sprintf("%.1f%%", b.Value*100))
+	}
+
+	c.rect(left, top, left+1, axisY, axis)
+	c.rect(left, axisY, left+plotW, axisY+1, axis)
+
+	return c.png()
+}
+
+func clamp(v float64, lo float64, hi float64) float64 {
+	return max(lo, min(hi, v))
+}
@@ -0,0 +1,127 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+/*
+텔레그램 사진 전송용 PNG 차트
+  - 이미지에는 숫자, 날짜 등 ASCII 문자만 표기 (기본 글꼴에 한글 미포함)
+  - 자산 이름 등 범례는 Legend 색상 이모지와 함께 사진 설명(caption)에 표기
+*/
+
+const (
+	Width  = 800
+	Height = 500
+)
+
+var (
+	background = color.RGBA{255, 255, 255, 255}
+	axis       = color.RGBA{90, 90, 90, 255}
+	grid       = color.RGBA{225, 225, 225, 255}
+	text       = color.RGBA{40, 40, 40, 255}
+)
+
+// 텔레그램 색상 이모지와 같은 순서의 색상
+var palette = []struct {
+	color color.RGBA
+	emoji string
+}{
+	{color.RGBA{221, 46, 68, 255}, "🟥"},
+	{color.RGBA{85, 172, 238, 255}, "🟦"},
+	{color.RGBA{120, 177, 89, 255}, "🟩"},
+	{color.RGBA{253, 203, 88, 255}, "🟨"},
+	{color.RGBA{170, 142, 214, 255}, "🟪"},
+	{color.RGBA{244, 144, 12, 255}, "🟧"},
+	{color.RGBA{193, 105, 79, 255}, "🟫"},
+	{color.RGBA{49, 55, 61, 255}, "⬛"},
+}
+
+// i번째 계열 색상의 범례 이모지
+func Legend(i int) string {
+	return palette[i%len(palette)].emoji
+}
+
+// 계열 최대 개수. 초과분은 호출 측에서 "기타"로 합산
+func PaletteSize() int {
+	return len(palette)
+}
+
+func seriesColor(i int) color.RGBA {
+	return palette[i%len(palette)].color
+}
+
+type canvas struct {
+	img *image.RGBA
+}
+
+func newCanvas() *canvas {
+	img := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+	return &canvas{img: img}
+}
+
+func (c *canvas) png() ([]byte, error) {
+	var buf bytes.Buffer
+	err := png.Encode(&buf, c.img)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *canvas) rect(x0, y0, x1, y1 int, col color.Color) {
+	draw.Draw(c.img, image.Rect(x0, y0, x1, y1), &image.Uniform{col}, image.Point{}, draw.Src)
+}
+
+// 두께 width의 직선
+func (c *canvas) line(x0, y0, x1, y1 float64, width int, col color.RGBA) {
+
+	steps := int(math.Max(math.Abs(x1-x0), math.Abs(y1-y0)))
+	if steps == 0 {
+		steps = 1
+	}
+	half := width / 2
+	for i := 0; i <= steps; i++ {
+		t := float64(i) / float64(steps)
+		x := int(math.Round(x0 + (x1-x0)*t))
+		y := int(math.Round(y0 + (y1-y0)*t))
+		for dx := -half; dx < width-half; dx++ {
+			for dy := -half; dy < width-half; dy++ {
+				c.img.SetRGBA(x+dx, y+dy, col)
+			}
+		}
+	}
+}
+
+// (x, y)가 왼쪽 기준선인 ASCII 문자열
+func (c *canvas) text(x, y int, s string) {
+	d := &font.Drawer{
+		Dst:  c.img,
+		Src:  &image.Uniform{text},
+		Face: basicfont.Face7x13,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+// (x, y)가 가운데인 ASCII 문자열
+func (c *canvas) centerText(x, y int, s string) {
+	w := font.MeasureString(basicfont.Face7x13, s).Round()
+	c.text(x-w/2, y+5, s)
+}
+
+// (x, y)가 오른쪽 끝인 ASCII 문자열
+func (c *canvas) rightText(x, y int, s string) {
+	w := font.MeasureString(basicfont.Face7x13, s).Round()
+	c.text(x-w, y+5, s)
+}
@@ -0,0 +1,102 @@
+package chart
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decode(t *testing.T, b []byte) image.Image {
+	img, err := png.Decode(bytes.NewReader(b))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, Width, Height), img.Bounds())
+	return img
+}
+
+func TestPie(t *testing.T) {
+
+	t.Run("조각 배치", func(t *testing.T) {
+		b, err := Pie([]float64{75, 25})
+		assert.NoError(t, err)
+		img := decode(t, b)
+
+		cx, cy := Width/2, Height/2
+		assert.Equal(t, seriesColor(0), img.At(cx+100, cy)) // 3시 방향. 첫 번째 조각(0~270도)
+		assert.Equal(t, seriesColor(1), img.At(cx-100, cy-5))
+		assert.Equal(t, background, img.At(5, 5))
+	})
+
+	t.Run("실패 테스트", func(t *testing.T) {
+		_, err := Pie([]float64{0, 0})
+		assert.Error(t, err)
+		_, err = Pie([]float64{1, -1})
+		assert.Error(t, err)
+	})
+}
+
+func TestBands(t *testing.T) {
+
+	b, err := Bands([]Band{
+		{Label: "#1", Value: 0.7, Min: 0.5, Max: 0.6},
+		{Label: "#2", Value: 0.55, Min: 0.5, Max: 0.6},
+		{Label: "#3", Value: 0.2, Min: 0.5, Max: 0.6},
+	})
+	assert.NoError(t, err)
+	img := decode(t, b)
+
+	// 행별 막대 시작 부분 색상
+	const left = 60
+	rowH := min(90, (Height-30-50)/3)
+	top := (Height - 50 - rowH*3) / 2
+	for i, col := range []any{overBand, inBand, underBand} {
+		assert.Equal(t, col, img.At(left+5, top+i*rowH+rowH/2), i)
+	}
+
+	_, err = Bands(nil)
+	assert.Error(t, err)
+}
+
+func TestLines(t *testing.T) {
+
+	day := time.Date(2024, 9, 2, 0, 0, 0, 0, time.Local)
+	price := Series{}
+	avg := Series{}
+	for i := range 30 {
+		price.Points = append(price.Points, Point{day.AddDate(0, 0, i), 100 + float64(i)})
+		avg.Points = append(avg.Points, Point{day.AddDate(0, 0, i), 100})
+	}
+
+	b, err := Lines([]Series{price, avg})
+	assert.NoError(t, err)
+	img := decode(t, b)
+
+	// 평균선(고정 값)은 그래프 가운데 아래쪽 가로선
+	found := false
+	for y := Height / 2; y < Height-50; y++ {
+		if img.At(Width/2, y) == seriesColor(1) {
+			found = true
+			break
+		}
+	}
+	assert.True(t, found)
+
+	t.Run("단일 값", func(t *testing.T) {
+		_, err := Lines([]Series{{Points: []Point{{day, 5}}}})
+		assert.NoError(t, err)
+	})
+
+	t.Run("실패 테스트 - 값 없음", func(t *testing.T) {
+		_, err := Lines([]Series{{}})
+		assert.Error(t, err)
+	})
+}
+
+func TestTickLabel(t *testing.T) {
+	assert.Equal(t, "12500", tickLabel(12500.4, 1000))
+	assert.Equal(t, "1.25", tickLabel(1.2534, 2))
+	assert.Equal(t, "0.0125", tickLabel(0.0125, 0.01))
+}
@@ -0,0 +1,101 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// 선 그래프 계열. 첫 번째 계열은 굵게 표시
+type Series struct {
+	Points []Point
+}
+
+/*
+Lines
+시간축 선 그래프. 모든 계열의 기간과 값 범위를 합쳐 축 설정
+*/
+func Lines(series []Series) ([]byte, error) {
+
+	var from, to time.Time
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, p := range s.Points {
+			if from.IsZero() || p.Time.Before(from) {
+				from = p.Time
+			}
+			if p.Time.After(to) {
+				to = p.Time
+			}
+			lo, hi = math.Min(lo, p.Value), math.Max(hi, p.Value)
+		}
+	}
+	if from.IsZero() {
+		return nil, errors.New("차트 값 없음")
+	}
+	if !to.After(from) {
+		to = from.Add(24 * time.Hour)
+	}
+	pad := (hi - lo) * 0.05
+	if pad == 0 {
+		pad = math.Max(math.Abs(hi)*0.05, 1)
+	}
+	lo, hi = lo-pad, hi+pad
+
+	const left, right, top, bottom = 80, 45, 30, 50
+	plotW, plotH := float64(Width-left-right), float64(Height-top-bottom)
+	xOf := func(t time.Time) float64 {
+		return left + float64(t.Sub(from))/float64(to.Sub(from))*plotW
+	}
+	yOf := func(v float64) float64 {
+		return top + (hi-v)/(hi-lo)*plotH
+	}
+
+	c := newCanvas()
+
+	const ticks = 5
+	for i := 0; i < ticks; i++ {
+		v := lo + (hi-lo)*float64(i)/(ticks-1)
+		y := int(yOf(v))
+		c.rect(left, y, Width-right, y+1, grid)
+		c.rightText(left-8, y, tickLabel(v, hi-lo))
+
+		t := from.Add(time.Duration(float64(to.Sub(from)) * float64(i) / (ticks - 1)))
+		x := int(xOf(t))
+		c.rect(x, top, x+1, Height-bottom, grid)
+		c.centerText(x, Height-bottom+15, t.Format("2006-01-02"))
+	}
+	c.rect(left, top, left+1, Height-bottom, axis)
+	c.rect(left, Height-bottom, Width-right, Height-bottom+1, axis)
+
+	for i, s := range series {
+		width := 2
+		if i == 0 {
+			width = 3
+		}
+		for j := 1; j < len(s.Points); j++ {
+			p0, p1 := s.Points[j-1], s.Points[j]
+			c.line(xOf(p0.Time), yOf(p0.Value), xOf(p1.Time), yOf(p1.Value), width, seriesColor(i))
+		}
+	}
+
+	return c.png()
+}
+
+// 값 범위에 따라 소수점 자리 결정
+func tickLabel(v float64, span float64) string {
+	switch {
+	case span >= 100:
+		return fmt.Sprintf("%.0f", v)
+	case span >= 1:
+		return fmt.Sprintf("%.2f", v)
+	default:
+		return fmt.Sprintf("%.4f", v)
+	}
+}
@@ -0,0 +1,70 @@
+package chart
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+/*
+Pie
+비중 원형 차트. 12시 방향부터 시계 방향으로 values 순서대로 배치
+  - 4% 이상인 조각에 비율 표기
+*/
+func Pie(values []float64) ([]byte, error) {
+
+	var total float64
+	for _, v := range values {
+		if v < 0 {
+			return nil, fmt.Errorf("음수 비중. 입력 값 : %f", v)
+		}
+		total += v
+	}
+	if total == 0 {
+		return nil, errors.New("차트 값 없음")
+	}
+
+	// 조각별 누적 종료 각도
+	ends := make([]float64, len(values))
+	var acc float64
+	for i, v := range values {
+		acc += v / total * 2 * math.Pi
+		ends[i] = acc
+	}
+
+	c := newCanvas()
+	cx, cy, r := Width/2, Height/2, float64(Height/2-40)
+
+	for y := cy - int(r); y <= cy+int(r); y++ {
+		for x := cx - int(r); x <= cx+int(r); x++ {
+			dx, dy := float64(x-cx), float64(y-cy)
+			if dx*dx+dy*dy > r*r {
+				continue
+			}
+			angle := math.Atan2(dx, -dy) // 12시 방향 0, 시계 방향 증가
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+			for i, end := range ends {
+				if angle <= end {
+					c.img.SetRGBA(x, y, seriesColor(i))
+					break
+				}
+			}
+		}
+	}
+
+	start := 0.0
+	for i, v := range values {
+		rate := v / total
+		if rate >= 0.04 {
+			mid := start + rate*math.Pi
+			x := cx + int(0.65*r*math.Sin(mid))
+			y := cy - int(0.65*r*math.Cos(mid))
+			c.centerText(x, y, fmt.Sprintf("%.1f%%", rate*100))
+		}
+		start = ends[i]
+	}
+
+	return c.png()
+}
@@ -0,0 +1,155 @@
+package client
+
+//go:generate go run ../cmd/genclient -o client_gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+/*
+Client
+invest API 클라이언트. 작업별 메서드는 OpenAPI 명세로 생성(client_gen.go)
+  - 명세 변경 시 go generate ./client 로 재생성
+*/
+type Client struct {
+	base       string
+	http       *http.Client
+	credential func() (string, error)
+}
+
+type Option func(*Client)
+
+// 요청별 인증 정보. API 키 혹은 JWT. Authorization: Bearer 헤더로 전송. 빈 값이면 헤더 생략
+func WithCredential(credential func() (string, error)) Option {
+	return func(c *Client) {
+		c.credential = credential
+	}
+}
+
+func WithHTTPClient(h *http.Client) Option {
+	return func(c *Client) {
+		c.http = h
+	}
+}
+
+// base : 서버 주소. ex) http://localhost:3000
+func New(base string, opts ...Option) *Client {
+	c := &Client{
+		base: strings.TrimSuffix(base, "/"),
+		http: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+/*
+Error
+2xx 외 응답. 본문은 서버 오류 응답(ErrorBody)
+  - 본문이 오류 응답 형식이 아니면 Message에 원문
+*/
+type Error struct {
+	Status int
+	ErrorBody
+}
+
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("%d. %s", e.Status, e.Message)
+	}
+	return fmt.Sprintf("%d %s. %s", e.Status, e.Code, e.Message)
+}
+
+/*
+Get
+경로 그대로 조회. 생성 메서드가 없는 경로나 명령어 입력을 그대로 전달할 때 사용
+  - path : 쿼리 포함 경로. ex) /invests?fund=1
+*/
+func (c *Client) Get(ctx context.Context, path string) (json.RawMessage, error) {
+
+	var out json.RawMessage
+	err := c.do(ctx, http.MethodGet, path, nil, nil, &out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+/*
+요청 전송 후 응답 본문을 out에 저장
+  - out이 *string이면 본문 원문, nil이면 본문 무시, 그 외 JSON
+*/
+func (c *Client) do(ctx context.Context, method string, path string, query url.Values, body any, out any) error {
+
+	u := c.base + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("요청 본문 변환 시 오류 발생. %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return fmt.Errorf("요청 생성 시 오류 발생. %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.credential != nil {
+		cred, err := c.credential()
+		if err != nil {
+			return fmt.Errorf("API 인증 정보 조회 시 오류 발생. %w", err)
+		}
+		if cred != "" {
+			req.Header.Set("Authorization", "Bearer "+cred)
+		}
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s 요청 시 오류 발생. %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s %s 응답 조회 시 오류 발생. %w", method, path, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		e := &Error{Status: resp.StatusCode}
+		if json.Unmarshal(data, &e.ErrorBody) != nil || e.Code == "" {
+			e.Message = string(data)
+		}
+		return e
+	}
+
+	switch o := out.(type) {
+	case nil:
+		return nil
+	case *string:
+		*o = string(data)
+		return nil
+	}
+	err = json.Unmarshal(data, out)
+	if err != nil {
+		return fmt.Errorf("%s %s 응답 변환 시 오류 발생. %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,778 @@
+// Code generated by cmd/genclient. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type AddAssetReq struct {
+	Name     string   `json:"name"`
+	Category int64    `json:"category"`
+	Code     string   `json:"code,omitempty"`
+	Currency string   `json:"currency"`
+	Top      float64  `json:"top,omitempty"`
+	Bottom   float64  `json:"bottom,omitempty"`
+	SelPrice float64  `json:"sel_price,omitempty"`
+	BuyPrice float64  `json:"buy_price,omitempty"`
+	Averages []string `json:"averages,omitempty"`
+}
+
+type AddFundReq struct {
+	Name string `json:"name"`
+}
+
+type AssetListResponse struct {
+	AssetId int64  `json:"asset_id"`
+	Name    string `json:"name"`
+}
+
+type AssetResponse struct {
+	ID        int64   `json:"ID"`
+	Name      string  `json:"Name"`
+	Category  string  `json:"Category"`
+	Code      string  `json:"Code"`
+	Currency  string  `json:"Currency"`
+	Top       float64 `json:"Top"`
+	Bottom    float64 `json:"Bottom"`
+	SellPrice float64 `json:"SellPrice"`
+	BuyPrice  float64 `json:"BuyPrice"`
+}
+
+type AverageResponse struct {
+	Average   string  `json:"average"`
+	Reference bool    `json:"reference"`
+	Date      string  `json:"date"`
+	Value     float64 `json:"value"`
+}
+
+type CliResponse struct {
+	Month string  `json:"month"`
+	Index float64 `json:"index"`
+}
+
+type DeleteAssetReq struct {
+	Id int64 `json:"id"`
+}
+
+type ErrorBody struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+type FieldError struct {
+	Field   string          `json:"field"`
+	Tag     string          `json:"tag"`
+	Value   json.RawMessage `json:"value,omitempty"`
+	Message string          `json:"message"`
+}
+
+type FundAssetsResponse struct {
+	FundId    int64   `json:"fund_id"`
+	AssetId   int64   `json:"asset_id"`
+	AssetName string  `json:"asset_name"`
+	Count     float64 `json:"count"`
+	Sum       float64 `json:"sum"`
+}
+
+type HistResponse struct {
+	FundId    int64   `json:"fund_id"`
+	AssetId   int64   `json:"asset_id"`
+	AssetName string  `json:"asset_name"`
+	Count     float64 `json:"count"`
+	Price     float64 `json:"price"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type IndicatorAlertReq struct {
+	Kind      string  `json:"kind"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Days      int64   `json:"days,omitempty"`
+	Period    int64   `json:"period,omitempty"`
+	Active    *bool   `json:"active,omitempty"`
+}
+
+type IndicatorAlertResponse struct {
+	Id              int64   `json:"id"`
+	Kind            string  `json:"kind"`
+	Threshold       float64 `json:"threshold"`
+	Days            int64   `json:"days,omitempty"`
+	Period          int64   `json:"period,omitempty"`
+	Description     string  `json:"description"`
+	Active          bool    `json:"active"`
+	Triggered       bool    `json:"triggered"`
+	LastTriggeredAt string  `json:"last_triggered_at"`
+}
+
+type IndicatorChangeResponse struct {
+	Id       int64    `json:"id"`
+	Name     string   `json:"name"`
+	Unit     string   `json:"unit,omitempty"`
+	Date     string   `json:"date"`
+	Value    float64  `json:"value"`
+	Previous *float64 `json:"previous,omitempty"`
+	Diff     *float64 `json:"diff,omitempty"`
+	Rate     *float64 `json:"rate,omitempty"`
+}
+
+type IndicatorReq struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Code     string `json:"code,omitempty"`
+	Spec     string `json:"spec,omitempty"`
+	Unit     string `json:"unit,omitempty"`
+	Active   *bool  `json:"active,omitempty"`
+}
+
+type IndicatorResponse struct {
+	Id              int64  `json:"id"`
+	Name            string `json:"name"`
+	Provider        string `json:"provider"`
+	Code            string `json:"code"`
+	Spec            string `json:"spec"`
+	Unit            string `json:"unit"`
+	Active          bool   `json:"active"`
+	LastCollectedAt string `json:"last_collected_at"`
+}
+
+type IndicatorValueResponse struct {
+	Date  string  `json:"date"`
+	Value float64 `json:"value"`
+}
+
+type InvestPageResponse struct {
+	Items      []InvestResponse `json:"items"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+type InvestResponse struct {
+	Id        int64   `json:"id"`
+	FundId    int64   `json:"fund_id"`
+	AssetId   int64   `json:"asset_id"`
+	AssetName string  `json:"asset_name"`
+	Category  string  `json:"category"`
+	Side      string  `json:"side"`
+	Price     float64 `json:"price"`
+	Count     float64 `json:"count"`
+	Amount    float64 `json:"amount"`
+	CreatedAt string  `json:"created_at"`
+}
+
+type Market struct {
+	CreatedAt time.Time `json:"CreatedAt"`
+	Status    int64     `json:"Status"`
+}
+
+type MarketIndicatorResponse struct {
+	Indicators []IndicatorChangeResponse `json:"indicators"`
+	Cli        *CliResponse              `json:"cli,omitempty"`
+}
+
+type MonitorHistResponse struct {
+	Value     string `json:"value"`
+	Changed   bool   `json:"changed"`
+	Error     string `json:"error,omitempty"`
+	CheckedAt string `json:"checked_at"`
+	Alert     string `json:"alert,omitempty"`
+}
+
+type MonitorReq struct {
+	Name     string `json:"name"`
+	Url      string `json:"url"`
+	Kind     string `json:"kind,omitempty"`
+	Selector string `json:"selector"`
+	Spec     string `json:"spec,omitempty"`
+	Expected string `json:"expected,omitempty"`
+	Active   *bool  `json:"active,omitempty"`
+}
+
+type MonitorResponse struct {
+	Id            int64  `json:"id"`
+	Name          string `json:"name"`
+	Url           string `json:"url"`
+	Kind          string `json:"kind"`
+	Selector      string `json:"selector"`
+	Spec          string `json:"spec"`
+	Expected      string `json:"expected"`
+	Active        bool   `json:"active"`
+	LastValue     string `json:"last_value"`
+	LastCheckedAt string `json:"last_checked_at"`
+}
+
+type PriceResponse struct {
+	Date   string  `json:"date"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Volume float64 `json:"volume"`
+}
+
+type PriorityResponse struct {
+	Rank         int64              `json:"rank"`
+	AssetId      int64              `json:"asset_id"`
+	AssetName    string             `json:"asset_name"`
+	PresentPrice float64            `json:"present_price"`
+	AveragePrice float64            `json:"average_price"`
+	HighestPrice float64            `json:"highest_price"`
+	Score        float64            `json:"score"`
+	Breakdown    map[string]float64 `json:"breakdown"`
+}
+
+type SaveAveragesReq struct {
+	Averages  []string `json:"averages"`
+	Reference string   `json:"reference,omitempty"`
+}
+
+type SaveInvestParam struct {
+	FundId  int64   `json:"fund_id"`
+	AssetId int64   `json:"asset_id,omitempty"`
+	Name    string  `json:"name,omitempty"`
+	Code    string  `json:"code,omitempty"`
+	Price   float64 `json:"price"`
+	Count   float64 `json:"count"`
+}
+
+type SaveMarketStatusParam struct {
+	Status int64 `json:"status"`
+}
+
+type SaveScoreWeightsReq struct {
+	Weights map[string]float64 `json:"weights"`
+}
+
+type ShutdownResponse struct {
+	Message string `json:"message"`
+}
+
+type TotalStatusResp struct {
+	Id     int64   `json:"id"`
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+type UpdateAssetReq struct {
+	Id       int64   `json:"id"`
+	Name     string  `json:"name,omitempty"`
+	Category int64   `json:"category,omitempty"`
+	Code     string  `json:"code,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	Top      float64 `json:"top,omitempty"`
+	Bottom   float64 `json:"bottom,omitempty"`
+	SelPrice float64 `json:"sel_price,omitempty"`
+	BuyPrice float64 `json:"buy_price,omitempty"`
+}
+
+// AddAsset 자산 추가
+//
+//	POST /assets
+func (c *Client) AddAsset(ctx context.Context, body AddAssetReq) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", "/assets", nil, body, &out)
+	return out, err
+}
+
+// UpdateAsset 자산 정보 갱신
+//
+//	PUT /assets
+func (c *Client) UpdateAsset(ctx context.Context, body UpdateAssetReq) (string, error) {
+	var out string
+	err := c.do(ctx, "PUT", "/assets", nil, body, &out)
+	return out, err
+}
+
+// DeleteAsset 자산 삭제
+//
+//	DELETE /assets
+func (c *Client) DeleteAsset(ctx context.Context, body DeleteAssetReq) (string, error) {
+	var out string
+	err := c.do(ctx, "DELETE", "/assets", nil, body, &out)
+	return out, err
+}
+
+// ListAssets 자산 목록
+//
+//	GET /assets/list
+func (c *Client) ListAssets(ctx context.Context) ([]AssetListResponse, error) {
+	var out []AssetListResponse
+	err := c.do(ctx, "GET", "/assets/list", nil, nil, &out)
+	return out, err
+}
+
+// GetAsset 자산 정보
+//
+//	GET /assets/{id}
+func (c *Client) GetAsset(ctx context.Context, id int64) (*AssetResponse, error) {
+	out := new(AssetResponse)
+	err := c.do(ctx, "GET", fmt.Sprintf("/assets/%d", id), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetAssetAverages 종목 이동평균
+//
+//	GET /assets/{id}/averages
+func (c *Client) GetAssetAverages(ctx context.Context, id int64) ([]AverageResponse, error) {
+	var out []AverageResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/assets/%d/averages", id), nil, nil, &out)
+	return out, err
+}
+
+// SaveAssetAverages 이동평균 설정 저장
+//
+//	POST /assets/{id}/averages
+func (c *Client) SaveAssetAverages(ctx context.Context, id int64, body SaveAveragesReq) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", fmt.Sprintf("/assets/%d/averages", id), nil, body, &out)
+	return out, err
+}
+
+// RecomputeAverages 이동평균 재산출
+//
+//	POST /assets/{id}/averages/recompute
+func (c *Client) RecomputeAverages(ctx context.Context, id int64) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", fmt.Sprintf("/assets/%d/averages/recompute", id), nil, nil, &out)
+	return out, err
+}
+
+// GetAssetHist 자산 투자 이력
+//
+//	GET /assets/{id}/hist
+func (c *Client) GetAssetHist(ctx context.Context, id int64) ([]HistResponse, error) {
+	var out []HistResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/assets/%d/hist", id), nil, nil, &out)
+	return out, err
+}
+
+// 쿼리 파라미터. 빈 값은 생략
+type GetAssetPricesParams struct {
+	From string
+	To   string
+}
+
+func (p GetAssetPricesParams) values() url.Values {
+	q := url.Values{}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.To != "" {
+		q.Set("to", p.To)
+	}
+	return q
+}
+
+// GetAssetPrices 종목 일별 시세. from, to 미입력 시 전체 기간
+//
+//	GET /assets/{id}/prices
+func (c *Client) GetAssetPrices(ctx context.Context, id int64, params GetAssetPricesParams) ([]PriceResponse, error) {
+	var out []PriceResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/assets/%d/prices", id), params.values(), nil, &out)
+	return out, err
+}
+
+// TotalStatus 자금별 평가 금액. 자금 ID별
+//
+//	GET /funds
+func (c *Client) TotalStatus(ctx context.Context) (map[string]*TotalStatusResp, error) {
+	var out map[string]*TotalStatusResp
+	err := c.do(ctx, "GET", "/funds", nil, nil, &out)
+	return out, err
+}
+
+// AddFund 자금 추가
+//
+//	POST /funds
+func (c *Client) AddFund(ctx context.Context, body AddFundReq) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", "/funds", nil, body, &out)
+	return out, err
+}
+
+// GetFundAssets 자금별 보유 자산
+//
+//	GET /funds/{id}/assets
+func (c *Client) GetFundAssets(ctx context.Context, id int64) ([]FundAssetsResponse, error) {
+	var out []FundAssetsResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/funds/%d/assets", id), nil, nil, &out)
+	return out, err
+}
+
+// GetFundHist 자금 투자 이력
+//
+//	GET /funds/{id}/hist
+func (c *Client) GetFundHist(ctx context.Context, id int64) ([]HistResponse, error) {
+	var out []HistResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/funds/%d/hist", id), nil, nil, &out)
+	return out, err
+}
+
+// 쿼리 파라미터. 빈 값은 생략
+type GetFundPrioritiesParams struct {
+	Side string
+}
+
+func (p GetFundPrioritiesParams) values() url.Values {
+	q := url.Values{}
+	if p.Side != "" {
+		q.Set("side", p.Side)
+	}
+	return q
+}
+
+// GetFundPriorities 자금별 매도/매수 우선순위
+//
+//	GET /funds/{id}/priorities
+func (c *Client) GetFundPriorities(ctx context.Context, id int64, params GetFundPrioritiesParams) ([]PriorityResponse, error) {
+	var out []PriorityResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/funds/%d/priorities", id), params.values(), nil, &out)
+	return out, err
+}
+
+// SaveScoreWeights 우선순위 점수 가중치 저장
+//
+//	POST /funds/{id}/weights
+func (c *Client) SaveScoreWeights(ctx context.Context, id int64, body SaveScoreWeightsReq) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", fmt.Sprintf("/funds/%d/weights", id), nil, body, &out)
+	return out, err
+}
+
+// ListIndicators 시장 지표 목록
+//
+//	GET /indicators
+func (c *Client) ListIndicators(ctx context.Context) ([]IndicatorResponse, error) {
+	var out []IndicatorResponse
+	err := c.do(ctx, "GET", "/indicators", nil, nil, &out)
+	return out, err
+}
+
+// AddIndicator 시장 지표 추가. 응답에 ID 포함
+//
+//	POST /indicators
+func (c *Client) AddIndicator(ctx context.Context, body IndicatorReq) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", "/indicators", nil, body, &out)
+	return out, err
+}
+
+// GetIndicator 시장 지표
+//
+//	GET /indicators/{id}
+func (c *Client) GetIndicator(ctx context.Context, id int64) (*IndicatorResponse, error) {
+	out := new(IndicatorResponse)
+	err := c.do(ctx, "GET", fmt.Sprintf("/indicators/%d", id), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateIndicator 시장 지표 갱신
+//
+//	PUT /indicators/{id}
+func (c *Client) UpdateIndicator(ctx context.Context, id int64, body IndicatorReq) (string, error) {
+	var out string
+	err := c.do(ctx, "PUT", fmt.Sprintf("/indicators/%d", id), nil, body, &out)
+	return out, err
+}
+
+// DeleteIndicator 시장 지표 삭제
+//
+//	DELETE /indicators/{id}
+func (c *Client) DeleteIndicator(ctx context.Context, id int64) (string, error) {
+	var out string
+	err := c.do(ctx, "DELETE", fmt.Sprintf("/indicators/%d", id), nil, nil, &out)
+	return out, err
+}
+
+// ListIndicatorAlerts 지표 알림 목록
+//
+//	GET /indicators/{id}/alerts
+func (c *Client) ListIndicatorAlerts(ctx context.Context, id int64) ([]IndicatorAlertResponse, error) {
+	var out []IndicatorAlertResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/indicators/%d/alerts", id), nil, nil, &out)
+	return out, err
+}
+
+// AddIndicatorAlert 지표 알림 추가. 응답에 ID 포함
+//
+//	POST /indicators/{id}/alerts
+func (c *Client) AddIndicatorAlert(ctx context.Context, id int64, body IndicatorAlertReq) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", fmt.Sprintf("/indicators/%d/alerts", id), nil, body, &out)
+	return out, err
+}
+
+// UpdateIndicatorAlert 지표 알림 갱신
+//
+//	PUT /indicators/{id}/alerts/{alertId}
+func (c *Client) UpdateIndicatorAlert(ctx context.Context, id int64, alertId int64, body IndicatorAlertReq) (string, error) {
+	var out string
+	err := c.do(ctx, "PUT", fmt.Sprintf("/indicators/%d/alerts/%d", id, alertId), nil, body, &out)
+	return out, err
+}
+
+// DeleteIndicatorAlert 지표 알림 삭제
+//
+//	DELETE /indicators/{id}/alerts/{alertId}
+func (c *Client) DeleteIndicatorAlert(ctx context.Context, id int64, alertId int64) (string, error) {
+	var out string
+	err := c.do(ctx, "DELETE", fmt.Sprintf("/indicators/%d/alerts/%d", id, alertId), nil, nil, &out)
+	return out, err
+}
+
+// 쿼리 파라미터. 빈 값은 생략
+type GetIndicatorValuesParams struct {
+	From string
+	To   string
+}
+
+func (p GetIndicatorValuesParams) values() url.Values {
+	q := url.Values{}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.To != "" {
+		q.Set("to", p.To)
+	}
+	return q
+}
+
+// GetIndicatorValues 시장 지표 값. from, to 미입력 시 전체 기간
+//
+//	GET /indicators/{id}/values
+func (c *Client) GetIndicatorValues(ctx context.Context, id int64, params GetIndicatorValuesParams) ([]IndicatorValueResponse, error) {
+	var out []IndicatorValueResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/indicators/%d/values", id), params.values(), nil, &out)
+	return out, err
+}
+
+// SaveInvest 투자 이력 저장
+//
+//	POST /invest
+func (c *Client) SaveInvest(ctx context.Context, body SaveInvestParam) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", "/invest", nil, body, &out)
+	return out, err
+}
+
+// 쿼리 파라미터. 빈 값은 생략
+type ListInvestsParams struct {
+	Fund     int64
+	Asset    int64
+	Category string
+	Side     string
+	From     string
+	To       string
+	Sort     string
+	Order    string
+	Limit    int64
+	Cursor   string
+}
+
+func (p ListInvestsParams) values() url.Values {
+	q := url.Values{}
+	if p.Fund != 0 {
+		q.Set("fund", strconv.FormatInt(p.Fund, 10))
+	}
+	if p.Asset != 0 {
+		q.Set("asset", strconv.FormatInt(p.Asset, 10))
+	}
+	if p.Category != "" {
+		q.Set("category", p.Category)
+	}
+	if p.Side != "" {
+		q.Set("side", p.Side)
+	}
+	if p.From != "" {
+		q.Set("from", p.From)
+	}
+	if p.To != "" {
+		q.Set("to", p.To)
+	}
+	if p.Sort != "" {
+		q.Set("sort", p.Sort)
+	}
+	if p.Order != "" {
+		q.Set("order", p.Order)
+	}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	if p.Cursor != "" {
+		q.Set("cursor", p.Cursor)
+	}
+	return q
+}
+
+// ListInvests 투자 이력 조회. 조건, 정렬, 커서 기반 페이지
+//
+//	GET /invests
+func (c *Client) ListInvests(ctx context.Context, params ListInvestsParams) (*InvestPageResponse, error) {
+	out := new(InvestPageResponse)
+	err := c.do(ctx, "GET", "/invests", params.values(), nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMarket 최근 시장 상태
+//
+//	GET /market
+func (c *Client) GetMarket(ctx context.Context) (*Market, error) {
+	out := new(Market)
+	err := c.do(ctx, "GET", "/market", nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ChangeMarketStatus 시장 상태 저장
+//
+//	POST /market
+func (c *Client) ChangeMarketStatus(ctx context.Context, body SaveMarketStatusParam) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", "/market", nil, body, &out)
+	return out, err
+}
+
+// GetMarketIndicators 최근 시장 지표
+//
+//	GET /market/indicators
+func (c *Client) GetMarketIndicators(ctx context.Context) (*MarketIndicatorResponse, error) {
+	out := new(MarketIndicatorResponse)
+	err := c.do(ctx, "GET", "/market/indicators", nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMarketIndicatorsByDate 일자별 시장 지표. YYYY-MM-DD
+//
+//	GET /market/indicators/{date}
+func (c *Client) GetMarketIndicatorsByDate(ctx context.Context, date string) (*MarketIndicatorResponse, error) {
+	out := new(MarketIndicatorResponse)
+	err := c.do(ctx, "GET", fmt.Sprintf("/market/indicators/%s", url.PathEscape(date)), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetMarketByDate 일자별 시장 상태. YYYY-MM-DD
+//
+//	GET /market/{date}
+func (c *Client) GetMarketByDate(ctx context.Context, date string) (*Market, error) {
+	out := new(Market)
+	err := c.do(ctx, "GET", fmt.Sprintf("/market/%s", url.PathEscape(date)), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListMonitors 감시 대상 목록
+//
+//	GET /monitors
+func (c *Client) ListMonitors(ctx context.Context) ([]MonitorResponse, error) {
+	var out []MonitorResponse
+	err := c.do(ctx, "GET", "/monitors", nil, nil, &out)
+	return out, err
+}
+
+// AddMonitor 감시 대상 추가. 응답에 ID 포함
+//
+//	POST /monitors
+func (c *Client) AddMonitor(ctx context.Context, body MonitorReq) (string, error) {
+	var out string
+	err := c.do(ctx, "POST", "/monitors", nil, body, &out)
+	return out, err
+}
+
+// GetMonitor 감시 대상
+//
+//	GET /monitors/{id}
+func (c *Client) GetMonitor(ctx context.Context, id int64) (*MonitorResponse, error) {
+	out := new(MonitorResponse)
+	err := c.do(ctx, "GET", fmt.Sprintf("/monitors/%d", id), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// UpdateMonitor 감시 대상 갱신
+//
+//	PUT /monitors/{id}
+func (c *Client) UpdateMonitor(ctx context.Context, id int64, body MonitorReq) (string, error) {
+	var out string
+	err := c.do(ctx, "PUT", fmt.Sprintf("/monitors/%d", id), nil, body, &out)
+	return out, err
+}
+
+// DeleteMonitor 감시 대상 삭제
+//
+//	DELETE /monitors/{id}
+func (c *Client) DeleteMonitor(ctx context.Context, id int64) (string, error) {
+	var out string
+	err := c.do(ctx, "DELETE", fmt.Sprintf("/monitors/%d", id), nil, nil, &out)
+	return out, err
+}
+
+// CheckMonitor 감시 대상 즉시 확인. 확인 결과와 알림 메시지
+//
+//	POST /monitors/{id}/check
+func (c *Client) CheckMonitor(ctx context.Context, id int64) (*MonitorHistResponse, error) {
+	out := new(MonitorHistResponse)
+	err := c.do(ctx, "POST", fmt.Sprintf("/monitors/%d/check", id), nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// 쿼리 파라미터. 빈 값은 생략
+type GetMonitorHistParams struct {
+	Limit int64
+}
+
+func (p GetMonitorHistParams) values() url.Values {
+	q := url.Values{}
+	if p.Limit != 0 {
+		q.Set("limit", strconv.FormatInt(p.Limit, 10))
+	}
+	return q
+}
+
+// GetMonitorHist 감시 대상 확인 이력
+//
+//	GET /monitors/{id}/hist
+func (c *Client) GetMonitorHist(ctx context.Context, id int64, params GetMonitorHistParams) ([]MonitorHistResponse, error) {
+	var out []MonitorHistResponse
+	err := c.do(ctx, "GET", fmt.Sprintf("/monitors/%d/hist", id), params.values(), nil, &out)
+	return out, err
+}
+
+// Shutdown 서버 종료. 진행 중 요청 완료 후 종료
+//
+//	POST /shutdown
+func (c *Client) Shutdown(ctx context.Context) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.do(ctx, "POST", "/shutdown", nil, nil, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
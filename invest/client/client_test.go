@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"invest/app"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerated(t *testing.T) {
+
+	t.Run("생성 코드 최신 여부", func(t *testing.T) {
+		src, err := app.OpenAPI().Client("client")
+		assert.NoError(t, err)
+
+		gen, err := os.ReadFile("client_gen.go")
+		assert.NoError(t, err)
+		assert.Equal(t, string(src), string(gen), "API 명세 변경. go generate ./client 실행 필요")
+	})
+}
+
+func TestClient(t *testing.T) {
+
+	var method, uri, auth, contentType, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		b, _ := io.ReadAll(req.Body)
+		method, uri, body = req.Method, req.URL.RequestURI(), string(b)
+		auth, contentType = req.Header.Get("Authorization"), req.Header.Get("Content-Type")
+
+		switch req.URL.Path {
+		case "/invests":
+			w.Write([]byte(`{"items":[{"id":3,"asset_name":"MSFT","side":"buy","price":400}],"next_cursor":"abc"}`))
+		case "/monitors":
+			w.Write([]byte("감시 대상 저장 성공. ID : 1"))
+		case "/assets/9":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"code":"NOT_FOUND","message":"대상 미존재"}`))
+		case "/assets":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"code":"INVALID_REQUEST","message":"유효성 검사 실패","fields":[{"field":"name","tag":"required","message":"필수 값"}]}`))
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+			w.Write([]byte("bad gateway"))
+		}
+	}))
+	defer srv.Close()
+
+	c := New(srv.URL+"/", WithCredential(func() (string, error) { return "inv_key", nil }))
+	ctx := context.Background()
+
+	t.Run("쿼리 파라미터와 JSON 응답", func(t *testing.T) {
+		page, err := c.ListInvests(ctx, ListInvestsParams{Fund: 1, Side: "buy", Limit: 10})
+		assert.NoError(t, err)
+		assert.Equal(t, "GET /invests?fund=1&limit=10&side=buy", method+" "+uri)
+		assert.Equal(t, "Bearer inv_key", auth)
+		assert.Equal(t, "abc", page.NextCursor)
+		assert.Equal(t, InvestResponse{Id: 3, AssetName: "MSFT", Side: "buy", Price: 400}, page.Items[0])
+	})
+
+	t.Run("요청 본문과 텍스트 응답", func(t *testing.T) {
+		rtn, err := c.AddMonitor(ctx, MonitorReq{Name: "S&P", Url: "https://example.com", Selector: "#price"})
+		assert.NoError(t, err)
+		assert.Equal(t, "감시 대상 저장 성공. ID : 1", rtn)
+		assert.Equal(t, "POST /monitors", method+" "+uri)
+		assert.Equal(t, "application/json", contentType)
+		assert.JSONEq(t, `{"name":"S&P","url":"https://example.com","selector":"#price"}`, body)
+	})
+
+	t.Run("오류 응답", func(t *testing.T) {
+		_, err := c.GetAsset(ctx, 9)
+		var e *Error
+		assert.True(t, errors.As(err, &e))
+		assert.Equal(t, http.StatusNotFound, e.Status)
+		assert.Equal(t, "404 NOT_FOUND. 대상 미존재", err.Error())
+
+		_, err = c.AddAsset(ctx, AddAssetReq{})
+		assert.True(t, errors.As(err, &e))
+		assert.Equal(t, "name", e.Fields[0].Field)
+
+		_, err = c.Get(ctx, "/none")
+		assert.Equal(t, "502. bad gateway", err.Error()) // 오류 응답 형식이 아니면 원문
+	})
+
+	t.Run("경로 그대로 조회", func(t *testing.T) {
+		data, err := c.Get(ctx, "/invests?asset=4")
+		assert.NoError(t, err)
+		assert.Equal(t, "GET /invests?asset=4", method+" "+uri)
+		assert.Contains(t, string(data), `"next_cursor":"abc"`)
+	})
+
+	t.Run("인증 정보", func(t *testing.T) {
+		_, err := New(srv.URL).ListAssets(ctx)
+		assert.Error(t, err)
+		assert.Empty(t, auth)
+
+		fail := New(srv.URL, WithCredential(func() (string, error) { return "", errors.New("JWT 서명 키 미설정") }))
+		_, err = fail.ListAssets(ctx)
+		assert.EqualError(t, err, "API 인증 정보 조회 시 오류 발생. JWT 서명 키 미설정")
+	})
+}
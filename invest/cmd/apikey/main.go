@@ -0,0 +1,168 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"invest/auth"
+	"invest/config"
+	"invest/db"
+	m "invest/model"
+	"log"
+	"os"
+	"time"
+)
+
+const usage = `API 키 관리
+go run ./cmd/apikey create -name {이름} [-scope read|write|admin]
+go run ./cmd/apikey list
+go run ./cmd/apikey revoke -id {키 ID}
+go run ./cmd/apikey token -name {이름} [-scope read|write|admin] [-ttl 24h]`
+
+/*
+API 키 발급, 조회, 폐기와 JWT 발급
+  - create : 키 원문은 발급 시에만 출력. DB에는 해시만 저장
+  - token : 설정 파일 auth.jwt-secret으로 서명
+*/
+func main() {
+
+	if len(os.Args) < 2 {
+		log.Fatal(usage)
+	}
+
+	conf, err := config.NewConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cmd, args := os.Args[1], os.Args[2:]
+	switch cmd {
+	case "create":
+		err = create(conf, args)
+	case "list":
+		err = list(conf)
+	case "revoke":
+		err = revoke(conf, args)
+	case "token":
+		err = token(conf, args)
+	default:
+		log.Fatal(usage)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func create(conf *config.Config, args []string) error {
+
+	fs := flag.NewFlagSet("create", flag.ExitOnError)
+	name := fs.String("name", "", "키 이름. 사용처 식별용")
+	scopeStr := fs.String("scope", "read", "권한 범위. read, write, admin")
+	fs.Parse(args)
+
+	if *name == "" {
+		return fmt.Errorf("키 이름 미입력. %s", usage)
+	}
+	scope, err := m.ToScope(*scopeStr)
+	if err != nil {
+		return err
+	}
+
+	key, prefix, hash, err := auth.NewKey()
+	if err != nil {
+		return err
+	}
+
+	stg, err := db.NewStorage(conf.Dsn())
+	if err != nil {
+		return err
+	}
+	id, err := stg.SaveApiKey(m.ApiKey{Name: *name, Prefix: prefix, Hash: hash, Scope: scope})
+	if err != nil {
+		return fmt.Errorf("SaveApiKey 시 오류 발생. %w", err)
+	}
+
+	fmt.Printf("ID : %d. 이름 : %s. 권한 : %s\n", id, *name, scope)
+	fmt.Printf("API 키 : %s\n", key)
+	fmt.Println("키는 다시 조회할 수 없으니 안전한 곳에 보관")
+	return nil
+}
+
+func list(conf *config.Config) error {
+
+	stg, err := db.NewStorage(conf.Dsn())
+	if err != nil {
+		return err
+	}
+	keys, err := stg.RetrieveApiKeys()
+	if err != nil {
+		return fmt.Errorf("RetrieveApiKeys 시 오류 발생. %w", err)
+	}
+
+	for _, k := range keys {
+		fmt.Printf("%d\t%s\t%s...\t%s\t생성 : %s\t최근 사용 : %s\t%s\n",
+			k.ID, k.Name, k.Prefix, k.Scope, k.CreatedAt.Format(time.DateTime), datetime(k.LastUsedAt), revoked(k.RevokedAt))
+	}
+	return nil
+}
+
+func revoke(conf *config.Config, args []string) error {
+
+	fs := flag.NewFlagSet("revoke", flag.ExitOnError)
+	id := fs.Uint("id", 0, "키 ID")
+	fs.Parse(args)
+
+	if *id == 0 {
+		return fmt.Errorf("키 ID 미입력. %s", usage)
+	}
+
+	stg, err := db.NewStorage(conf.Dsn())
+	if err != nil {
+		return err
+	}
+	err = stg.RevokeApiKey(*id)
+	if err != nil {
+		return fmt.Errorf("RevokeApiKey 시 오류 발생. %w", err)
+	}
+
+	fmt.Printf("키 %d 폐기 완료\n", *id)
+	return nil
+}
+
+func token(conf *config.Config, args []string) error {
+
+	fs := flag.NewFlagSet("token", flag.ExitOnError)
+	name := fs.String("name", "", "토큰 발급 대상 이름")
+	scopeStr := fs.String("scope", "read", "권한 범위. read, write, admin")
+	ttl := fs.Duration("ttl", 24*time.Hour, "유효 시간")
+	fs.Parse(args)
+
+	if *name == "" {
+		return fmt.Errorf("발급 대상 이름 미입력. %s", usage)
+	}
+	scope, err := m.ToScope(*scopeStr)
+	if err != nil {
+		return err
+	}
+
+	t, err := auth.Issue([]byte(conf.Auth.Secret), *name, scope, *ttl, time.Now())
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(t)
+	return nil
+}
+
+func datetime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format(time.DateTime)
+}
+
+func revoked(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return "폐기 " + t.Format(time.DateTime)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"invest/config"
+	"invest/db"
+	"invest/event"
+	"invest/scrape"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// 복호화 키 환경 변수. 미설정 시 터미널에서 입력
+const keyEnv = "INVEST_KEY"
+
+/*
+일봉 시세 백필
+go run ./cmd/backfill [-asset {자산 ID}] [-from 2006-01-02] [-to 2006-01-02]
+  - 복호화 키는 INVEST_KEY 환경 변수 혹은 실행 후 입력. 명령행 인자로 받지 않음(프로세스 목록, 셸 이력 노출)
+*/
+func main() {
+
+	assetId := flag.Uint("asset", 0, "자산 ID. 0이면 전체 자산")
+	from := flag.String("from", time.Now().AddDate(-1, 0, 0).Format("2006-01-02"), "시작일")
+	to := flag.String("to", time.Now().Format("2006-01-02"), "종료일")
+	tokenPath := flag.String("token", ".kis_token", "KIS 토큰 저장 경로")
+	flag.Parse()
+
+	start, err := time.ParseInLocation("2006-01-02", *from, time.Local)
+	if err != nil {
+		log.Fatalf("시작일 형식 오류. %s", err)
+	}
+	end, err := time.ParseInLocation("2006-01-02", *to, time.Local)
+	if err != nil {
+		log.Fatalf("종료일 형식 오류. %s", err)
+	}
+
+	conf, err := config.NewConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	key, err := readKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = conf.InitKIS(key)
+	if err != nil {
+		log.Fatalf("KIS 키 복호화 실패. %s", err)
+	}
+
+	scraper := scrape.NewScraper(conf,
+		scrape.WithKIS(conf.KisAppKey(), conf.KisAppSecret()),
+		scrape.WithTokenStore(scrape.NewFileTokenStore(*tokenPath, []byte(key))),
+	)
+
+	stg, err := db.NewStorage(conf.Dsn())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cnt, err := event.NewEvent(stg, scraper, scraper).PriceBackfill(uint(*assetId), start, end)
+	if err != nil {
+		log.Fatalf("백필 중 오류 발생. 저장 건수 : %d. %s", cnt, err)
+	}
+
+	log.Printf("백필 완료. 저장 건수 : %d", cnt)
+}
+
+// 환경 변수, 터미널 입력(화면 미표시) 순으로 복호화 키 조회
+func readKey() (string, error) {
+
+	if key := os.Getenv(keyEnv); key != "" {
+		return key, nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("복호화 키 미존재. %s 환경 변수 설정 혹은 터미널에서 실행 필요", keyEnv)
+	}
+
+	fmt.Fprint(os.Stderr, "복호화 키 : ")
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("복호화 키 입력 시 오류 발생. %w", err)
+	}
+
+	key := strings.TrimSpace(string(b))
+	if key == "" {
+		return "", errors.New("복호화 키 미입력")
+	}
+	return key, nil
+}
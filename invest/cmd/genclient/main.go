@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"invest/app"
+	"log"
+	"os"
+)
+
+/*
+API 명세로 Go 클라이언트 코드 생성
+  - client 패키지의 go:generate에서 실행. go generate ./client
+  - -spec 지정 시 OpenAPI 문서도 저장
+*/
+func main() {
+
+	out := flag.String("o", "client_gen.go", "생성 코드 경로")
+	pkg := flag.String("pkg", "client", "생성 코드 패키지")
+	spec := flag.String("spec", "", "OpenAPI 문서 저장 경로")
+	flag.Parse()
+
+	doc := app.OpenAPI()
+
+	src, err := doc.Client(*pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = os.WriteFile(*out, src, 0o644)
+	if err != nil {
+		log.Fatalf("클라이언트 코드 저장 시 오류 발생. %s", err)
+	}
+
+	if *spec == "" {
+		return
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = os.WriteFile(*spec, data, 0o644)
+	if err != nil {
+		log.Fatalf("OpenAPI 문서 저장 시 오류 발생. %s", err)
+	}
+}
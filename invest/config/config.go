@@ -15,8 +15,9 @@ type Config struct {
 	Api      map[string]apiConfig   `yaml:"api"`
 	Crawl    map[string]crawlConfig `yaml:"crawl"`
 	Telegram struct {
-		ChatId string `yaml:"chatId"`
-		Token  string `yaml:"token"`
+		ChatId string         `yaml:"chatId"`
+		Token  string         `yaml:"token"`
+		Users  []telegramUser `yaml:"users"` // 허용 사용자. chatId는 write 권한으로 자동 허용
 	} `yaml:"telegram"`
 	Key struct {
 		KIS map[string]*string `yaml:"KIS"`
@@ -29,6 +30,27 @@ type Config struct {
 		Port     string `yaml:"port"`
 		Scheme   string `yaml:"scheme"`
 	} `yaml:"db"`
+
+	Auth struct { // API 인증. API 키는 go run ./cmd/apikey 로 발급
+		Secret string `yaml:"jwt-secret"` // JWT(HS256) 서명 키. 미설정 시 API 키만 허용
+		BotKey string `yaml:"bot-key"`    // 텔레그램 봇의 조회 API 키. 미설정 시 jwt-secret으로 토큰 발급
+	} `yaml:"auth"`
+
+	Digest []digestConfig `yaml:"digest"` // 정기 리포트. 미설정 시 기본 일간/주간 리포트
+
+	Notify struct { // 알림 채널. 텔레그램(telegram)은 기본 등록
+		Channels []notifyChannel  `yaml:"channels"`
+		Routes   []notifyRoute    `yaml:"routes"`
+		Default  []string         `yaml:"default"`  // 일치하는 규칙이 없을 때 전송 채널. 미설정 시 telegram
+		Dispatch []dispatchConfig `yaml:"dispatch"` // 채널별 전송 정책. telegram 미설정 시 30초 묶음, error 즉시 전송
+
+		Templates string `yaml:"templates"` // 문구 템플릿 재정의 디렉토리. {언어}/{plain|markdown|html}.tmpl
+		Locale    string `yaml:"locale"`    // 기본 언어. ko(기본), en
+		Telegram  struct {
+			Locale string `yaml:"locale"`
+			Format string `yaml:"format"` // plain(기본), markdown(MarkdownV2)
+		} `yaml:"telegram"`
+	} `yaml:"notify"`
 }
 
 type apiConfig struct {
@@ -36,6 +58,81 @@ type apiConfig struct {
 	Header map[string]string `yaml:"header"`
 }
 
+// 채팅 ID 혹은 사용자 ID. role은 read(조회만) 혹은 write
+type telegramUser struct {
+	Id   int64  `yaml:"id"`
+	Role string `yaml:"role"`
+}
+
+/*
+정기 리포트 설정
+  - kind : daily 혹은 weekly
+  - spec : cron 표현식
+  - sections : funds, movers, rebalance, market, alerts 중 선택. 미설정 시 전체
+  - charts : 리포트 후 차트 전송 여부
+  - dir, format : 리포트 파일 저장 경로와 형식(md 혹은 html). dir 미설정 시 미저장
+*/
+type digestConfig struct {
+	Kind     string   `yaml:"kind"`
+	Spec     string   `yaml:"spec"`
+	Sections []string `yaml:"sections"`
+	Charts   bool     `yaml:"charts"`
+	Dir      string   `yaml:"dir"`
+	Format   string   `yaml:"format"`
+}
+
+/*
+알림 채널 설정
+  - type : slack, discord, webhook, email
+  - url : slack, discord, webhook 주소. header는 webhook 추가 헤더
+  - host, port, user, pwd, from, to : email SMTP 설정. user 미설정 시 인증 없이 전송
+  - locale : 채널 문구 언어. 미설정 시 notify.locale
+*/
+type notifyChannel struct {
+	Name   string            `yaml:"name"`
+	Type   string            `yaml:"type"`
+	Url    string            `yaml:"url"`
+	Header map[string]string `yaml:"header"`
+	Host   string            `yaml:"host"`
+	Port   string            `yaml:"port"`
+	User   string            `yaml:"user"`
+	Pwd    string            `yaml:"pwd"`
+	From   string            `yaml:"from"`
+	To     []string          `yaml:"to"`
+	Locale string            `yaml:"locale"`
+}
+
+/*
+이벤트별 전송 채널
+  - kinds : 이벤트 종류(PriceAlert, JobError 등). 미설정 시 전체
+  - severity : 최소 심각도. info, warning, error
+*/
+type notifyRoute struct {
+	notifyFilter `yaml:",inline"`
+	Channels     []string `yaml:"channels"`
+}
+
+type notifyFilter struct {
+	Kinds    []string `yaml:"kinds"`
+	Severity string   `yaml:"severity"`
+}
+
+/*
+채널별 전송 정책
+  - quiet : 조용한 시간. HH:MM-HH:MM. 해당 시간 알림은 종료 후 묶어서 전송
+  - limit, per : per(기본 1m) 동안 최대 limit 건 전송
+  - window : 첫 알림 후 대기 시간(ex. 30s). 대기 중 알림을 한 메시지로 묶음
+  - urgent : 조용한 시간, 전송 한도, 대기 없이 즉시 전송할 알림 종류/심각도
+*/
+type dispatchConfig struct {
+	Channel string        `yaml:"channel"`
+	Quiet   string        `yaml:"quiet"`
+	Limit   int           `yaml:"limit"`
+	Per     string        `yaml:"per"`
+	Window  string        `yaml:"window"`
+	Urgent  *notifyFilter `yaml:"urgent"`
+}
+
 type crawlConfig struct {
 	Url     string `yaml:"url"`
 	CssPath string `yaml:"css-path"`
@@ -35,8 +35,32 @@ func init() {
 }
 
 func TestMigration(t *testing.T) {
-	// db.AutoMigrate(&m.EmaHist{})
-	db.AutoMigrate(&m.Fund{}, &m.Asset{}, &m.Invest{}, &m.InvestSummary{}, &m.Market{}, &m.DailyIndex{}, &m.CliIndex{}, &m.EmaHist{})
+	db.AutoMigrate(&m.Fund{}, &m.Asset{}, &m.Invest{}, &m.InvestSummary{}, &m.Market{}, &m.Indicator{}, &m.IndicatorValue{}, &m.IndicatorAlert{}, &m.CliIndex{}, &m.DailyPrice{}, &m.AssetAverage{}, &m.AverageHist{}, &m.ScoreWeight{}, &m.Monitor{}, &m.MonitorHist{}, &m.FundValue{}, &m.AlertLog{}, &m.DigestHist{}, &m.ApiKey{})
+}
+
+// 기본 지표 등록 후 기존 daily_indices 컬럼 값을 indicator_values로 이관
+func TestMigrateDailyIndex(t *testing.T) {
+
+	ids := make([]uint, len(m.DefaultIndicators))
+	for i, indicator := range m.DefaultIndicators {
+		result := db.Where(m.Indicator{Name: indicator.Name}).FirstOrCreate(&indicator)
+		if result.Error != nil {
+			t.Fatal(result.Error)
+		}
+		ids[i] = indicator.ID
+	}
+
+	if !db.Migrator().HasTable("daily_indices") {
+		return
+	}
+
+	for i, column := range []string{"fear_greed_index", "nas_daq"} {
+		result := db.Exec(fmt.Sprintf("INSERT IGNORE INTO indicator_values (indicator_id, date, value) SELECT ?, created_at, %s FROM daily_indices", column), ids[i])
+		if result.Error != nil {
+			t.Fatal(result.Error)
+		}
+		t.Log(column, result.RowsAffected)
+	}
 }
 
 func TestCreate(t *testing.T) {
@@ -95,12 +119,12 @@ func TestTime(t *testing.T) {
 }
 
 func TestSelectFirst(t *testing.T) {
-	var dailyIdx m.DailyIndex
+	var value m.IndicatorValue
 
-	result := db.Where("created_at = ?", "2024-09-21").Select(&dailyIdx)
+	result := db.Where("date = ?", "2024-09-21").Select(&value)
 	if result.Error != nil {
 		t.Error(result.Error)
 	}
 
-	fmt.Printf("%+v", dailyIdx)
+	fmt.Printf("%+v", value)
 }
@@ -2,13 +2,15 @@ package db
 
 import (
 	"database/sql"
+	"errors"
+	"fmt"
 	m "invest/model"
-	"math"
 	"time"
 
 	"gorm.io/datatypes"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Storage struct {
@@ -28,11 +30,55 @@ func NewStorage(dsn string, opts ...gorm.Option) (*Storage, error) {
 		return nil, err
 	}
 
+	// 중복 키, 외래 키 오류를 gorm 오류로 변환 후 도메인 오류로 분류
+	db.TranslateError = true
+	for name, cb := range map[string]interface {
+		Register(name string, fn func(*gorm.DB)) error
+	}{
+		"query":  db.Callback().Query(),
+		"create": db.Callback().Create(),
+		"update": db.Callback().Update(),
+		"delete": db.Callback().Delete(),
+		"row":    db.Callback().Row(),
+		"raw":    db.Callback().Raw(),
+	} {
+		err = cb.Register("invest:classify_error", classifyError)
+		if err != nil {
+			return nil, fmt.Errorf("%s 콜백 등록 시 오류 발생. %w", name, err)
+		}
+	}
+
 	return &Storage{
 		db: db,
 	}, nil
 }
 
+// 커넥션 풀 종료
+func (s Storage) Close() error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+var errNotFound = fmt.Errorf("%w. %w", m.ErrNotFound, gorm.ErrRecordNotFound)
+
+/*
+gorm 오류를 도메인 오류(m.ErrNotFound, m.ErrConflict)로 감쌈
+  - 원래 gorm 오류도 errors.Is로 확인 가능
+*/
+func classifyError(db *gorm.DB) {
+	switch {
+	case db.Error == nil:
+	case errors.Is(db.Error, m.ErrNotFound), errors.Is(db.Error, m.ErrConflict):
+	case errors.Is(db.Error, gorm.ErrRecordNotFound):
+		db.Error = errNotFound
+	case errors.Is(db.Error, gorm.ErrDuplicatedKey), errors.Is(db.Error, gorm.ErrForeignKeyViolated):
+		db.Error = fmt.Errorf("%w. %w", m.ErrConflict, db.Error)
+	}
+}
+
 func (s Storage) RetreiveFundsSummaryOrderByFundId() ([]m.InvestSummary, error) {
 
 	var fundsSummary []m.InvestSummary
@@ -114,6 +160,17 @@ func (s Storage) SaveFund(name string) error {
 	return nil
 }
 
+func (s Storage) RetrieveFunds() ([]m.Fund, error) {
+
+	var funds []m.Fund
+
+	result := s.db.Model(&m.Fund{}).Order("id").Find(&funds)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return funds, nil
+}
+
 func (s Storage) RetrieveAssetList() ([]m.Asset, error) {
 
 	var assets []m.Asset
@@ -255,44 +312,97 @@ func (s Storage) RetrieveMarketStatus(date string) (*m.Market, error) {
 	return &market, nil
 }
 
-func (s Storage) RetrieveMarketIndicator(date string) (*m.DailyIndex, *m.CliIndex, error) {
+// 기간 내 일자별 시장 단계. 일자 순. from, to 미입력 시 전체 기간
+func (s Storage) RetrieveMarketStatuses(from string, to string) ([]m.Market, error) {
 
-	var dailyIdx m.DailyIndex
-	var cliIdx m.CliIndex
+	query := s.db.Model(&m.Market{})
 
-	if date == "" {
-		result := s.db.Last(&dailyIdx) // Preload("Asset")
-		if result.Error != nil {
-			return nil, nil, result.Error
+	if from != "" {
+		query.Where("created_at >= ?", from)
+	}
+	if to != "" {
+		query.Where("created_at <= ?", to)
+	}
+
+	var markets []m.Market
+
+	result := query.Order("created_at").Find(&markets)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return markets, nil
+}
+
+/*
+활성 지표별 date 기준 최근 값과 그 이전 값. date 미입력 시 최신 값 기준
+  - 값이 없는 지표는 제외
+  - CLI는 월별 지표이므로 date 이전 가장 최근 월 값 반환. CLI 미저장 시 nil
+*/
+func (s Storage) RetrieveMarketIndicator(date string) ([]m.IndicatorChange, *m.CliIndex, error) {
+
+	var indicators []m.Indicator
+
+	result := s.db.Where("active = ?", true).Order("id").Find(&indicators)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+
+	changes := make([]m.IndicatorChange, 0, len(indicators))
+	for _, indicator := range indicators {
+
+		var values []m.IndicatorValue
+
+		query := s.db.Where("indicator_id = ?", indicator.ID).Order("date desc").Limit(2)
+		if date != "" {
+			query = query.Where("date <= ?", date)
 		}
 
-		// result = s.db.Last(&cliIdx) // Preload("Asset") // todo. CLI Index 우선 미사용
-		// if result.Error != nil {
-		// 	return nil, nil, result.Error
-		// }
-	} else {
-		// memo. createdAt을 PK로 지정했더라도, First에 인자로 넣어서 where절 만들 수 없음
-		result := s.db.Where("created_at = ?", date).First(&dailyIdx) // Preload("Asset")
+		result := query.Find(&values)
 		if result.Error != nil {
 			return nil, nil, result.Error
 		}
+		if len(values) == 0 {
+			continue
+		}
+
+		change := m.IndicatorChange{
+			Indicator: indicator,
+			Date:      time.Time(values[0].Date).Format("2006-01-02"),
+			Value:     values[0].Value,
+		}
+		if len(values) > 1 {
+			change.Previous = &values[1].Value
+		}
+		changes = append(changes, change)
+	}
 
-		// result = s.db.First(&cliIdx, date) // Preload("Asset")
-		// if result.Error != nil {
-		// 	return nil, nil, result.Error
-		// }
+	var cliIdx m.CliIndex
+
+	cliQuery := s.db.Order("created_at desc").Limit(1)
+	if date != "" {
+		cliQuery = cliQuery.Where("created_at <= ?", date)
+	}
+
+	result = cliQuery.Find(&cliIdx)
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return changes, nil, nil
 	}
 
-	return &dailyIdx, &cliIdx, nil
+	return changes, &cliIdx, nil
 }
 
-func (s Storage) SaveDailyMarketIndicator(fearGreedIndex uint, nasdaq float64) error {
+// 월별 CLI 저장. 이미 존재하는 월은 갱신 (OECD 수정치 반영)
+func (s Storage) SaveCliIndex(series []m.CliIndex) error {
 
-	result := s.db.Create(&m.DailyIndex{
-		CreatedAt:      datatypes.Date(time.Now()),
-		FearGreedIndex: fearGreedIndex,
-		NasDaq:         nasdaq,
-	})
+	if len(series) == 0 {
+		return nil
+	}
+
+	result := s.db.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(series, 100)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -313,31 +423,69 @@ func (s Storage) SaveMarketStatus(status uint) error {
 	return nil
 }
 
-func (s Storage) RetrieveInvestHist(fundId uint, assetId uint, start string, end string) ([]m.Invest, error) {
+// 정렬 기준별 컬럼. 자산 조인 시 컬럼명 중복 방지
+var investSortColumns = map[m.InvestSort]string{
+	m.SortCreatedAt: "invests.created_at",
+	m.SortPrice:     "invests.price",
+	m.SortAmount:    "invests.price * invests.count",
+}
 
-	query := s.db.Model(&m.Invest{}) // Note. 필수가 아니더라도, 처음에 모델을 명시하는 것이 good practice
+/*
+조건별 투자 이력
+  - 정렬 값, ID 순으로 정렬. After 이후 이력부터 최대 Limit 건 (keyset 페이지)
+  - 카테고리 조건은 자산 조인
+*/
+func (s Storage) RetrieveInvests(q m.InvestQuery) ([]m.Invest, error) {
 
-	if fundId != 0 {
-		query.Where("fund_id = ?", fundId)
+	query := s.db.Model(&m.Invest{}).Joins("Asset")
+
+	if q.FundID != 0 {
+		query = query.Where("invests.fund_id = ?", q.FundID)
+	}
+	if q.AssetID != 0 {
+		query = query.Where("invests.asset_id = ?", q.AssetID)
+	}
+	if q.Category != 0 {
+		query = query.Where("`Asset`.`category` = ?", q.Category)
+	}
+	switch q.Side {
+	case m.BuySide:
+		query = query.Where("invests.count > 0")
+	case m.SellSide:
+		query = query.Where("invests.count < 0")
+	}
+	if !q.From.IsZero() {
+		query = query.Where("invests.created_at >= ?", q.From)
+	}
+	if !q.To.IsZero() {
+		query = query.Where("invests.created_at < ?", q.To)
 	}
-	if assetId != 0 {
-		query.Where("asset_id = ?", assetId)
+
+	column, ok := investSortColumns[q.Sort]
+	if !ok {
+		column = investSortColumns[m.SortCreatedAt]
+	}
+	op, dir := ">", "ASC"
+	if q.Desc {
+		op, dir = "<", "DESC"
 	}
-	if start != "" {
-		query.Where("created_at >= ?", start)
+	if q.After != nil {
+		query = query.Where(fmt.Sprintf("(%s, invests.id) %s (?, ?)", column, op), q.After.Value, q.After.ID)
 	}
-	if end != "" {
-		query.Where("created_at <= ?", end)
+	query = query.Order(fmt.Sprintf("%s %s, invests.id %s", column, dir, dir))
+
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
 	}
 
-	var investHist []m.Invest
+	var invests []m.Invest
 
-	result := query.Preload("Asset").Find(&investHist)
+	result := query.Find(&invests)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	return investHist, nil
+	return invests, nil
 }
 
 func (s Storage) SaveInvest(fundId uint, assetId uint, price float64, count float64) error {
@@ -415,32 +563,110 @@ func (s Storage) UpdateInvestSummarySum(fundId uint, assetId uint, sum float64)
 	return nil
 }
 
-func (s Storage) RetreiveLatestEma(assetId uint) (float64, error) {
+func (s Storage) RetrieveAssetAverages(assetId uint) ([]m.AssetAverage, error) {
+
+	var averages []m.AssetAverage
 
-	var ema m.EmaHist
-	// result := s.db.Where("asset_id", assetId).Order("date desc").First(&ema)
-	result := s.db.Where("asset_id", assetId).Last(&ema)
+	result := s.db.Where("asset_id = ?", assetId).Order("id").Find(&averages)
 	if result.Error != nil {
-		return 0, result.Error
+		return nil, result.Error
 	}
 
-	return ema.Ema, nil
+	return averages, nil
 }
 
-func (s Storage) SaveEmaHist(assetId uint, price float64) error {
+// 자산의 이동평균 설정 전체 교체
+func (s Storage) SaveAssetAverages(assetId uint, averages []m.AssetAverage) error {
 
-	emay, err := s.RetreiveLatestEma(assetId)
-	if err != nil {
-		emay = price
+	return s.db.Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Where("asset_id = ?", assetId).Delete(&m.AssetAverage{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(averages) == 0 {
+			return nil
+		}
+
+		for i := range averages {
+			averages[i].ID = 0
+			averages[i].AssetID = assetId
+		}
+
+		return tx.Create(&averages).Error
+	})
+}
+
+// 이동평균 이력 재산출 결과로 해당 시계열 전체 교체
+func (s Storage) SaveAverageHist(assetId uint, spec m.AverageSpec, hist []m.AverageHist) error {
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Where("asset_id = ? AND kind = ? AND period = ?", assetId, spec.Kind, spec.Period).Delete(&m.AverageHist{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(hist) == 0 {
+			return nil
+		}
+
+		return tx.CreateInBatches(hist, 100).Error
+	})
+}
+
+// 가장 최근 이동평균. 산출 이력 미존재 시 nil
+func (s Storage) RetrieveLatestAverage(assetId uint, spec m.AverageSpec) (*m.AverageHist, error) {
+
+	var hist m.AverageHist
+
+	result := s.db.Where("asset_id = ? AND kind = ? AND period = ?", assetId, spec.Kind, spec.Period).
+		Order("date desc").
+		Limit(1).
+		Find(&hist)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
 	}
 
-	var ema = m.EmaHist{
+	return &hist, nil
+}
+
+// 당일 시세 갱신. 최초 값을 시가로 두고 고가/저가/종가 갱신
+func (s Storage) UpdateDailyPrice(assetId uint, price float64) error {
+
+	result := s.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]any{
+			"high":  gorm.Expr("GREATEST(high, VALUES(high))"),
+			"low":   gorm.Expr("LEAST(low, VALUES(low))"),
+			"close": gorm.Expr("VALUES(close)"),
+		}),
+	}).Create(&m.DailyPrice{
 		AssetID: assetId,
 		Date:    datatypes.Date(time.Now()),
-		Ema:     ema(price, emay),
+		Open:    price,
+		High:    price,
+		Low:     price,
+		Close:   price,
+	})
+	if result.Error != nil {
+		return result.Error
 	}
 
-	result := s.db.Create(&ema)
+	return nil
+}
+
+// 일봉 저장. 이미 존재하는 일자는 덮어씀
+func (s Storage) SaveDailyPrices(prices []m.DailyPrice) error {
+
+	if len(prices) == 0 {
+		return nil
+	}
+
+	result := s.db.Clauses(clause.OnConflict{UpdateAll: true}).CreateInBatches(prices, 100)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -448,12 +674,456 @@ func (s Storage) SaveEmaHist(assetId uint, price float64) error {
 	return nil
 }
 
-/*
-a =  2/N+1
-EMAt = a*PRICEt + (1-a)EMAy
-*/
-func ema(tp float64, emay float64) float64 {
+func (s Storage) RetrieveDailyPrices(assetId uint, from string, to string) ([]m.DailyPrice, error) {
+
+	query := s.db.Model(&m.DailyPrice{}).Where("asset_id = ?", assetId)
+
+	if from != "" {
+		query.Where("date >= ?", from)
+	}
+	if to != "" {
+		query.Where("date <= ?", to)
+	}
+
+	var prices []m.DailyPrice
+
+	result := query.Order("date").Find(&prices)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return prices, nil
+}
+
+func (s Storage) RetrieveScoreWeights(fundId uint) ([]m.ScoreWeight, error) {
+
+	var weights []m.ScoreWeight
+
+	result := s.db.Where("fund_id = ?", fundId).Find(&weights)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return weights, nil
+}
+
+// 자금의 점수 산정 가중치 전체 교체
+func (s Storage) SaveScoreWeights(fundId uint, weights []m.ScoreWeight) error {
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Where("fund_id = ?", fundId).Delete(&m.ScoreWeight{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(weights) == 0 {
+			return nil
+		}
+
+		for i := range weights {
+			weights[i].FundID = fundId
+		}
+
+		return tx.Create(&weights).Error
+	})
+}
+
+func (s Storage) RetrieveMonitors() ([]m.Monitor, error) {
+
+	var monitors []m.Monitor
+
+	result := s.db.Order("id").Find(&monitors)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return monitors, nil
+}
+
+func (s Storage) RetrieveMonitor(id uint) (*m.Monitor, error) {
+
+	var monitor m.Monitor
+
+	result := s.db.First(&monitor, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &monitor, nil
+}
+
+func (s Storage) SaveMonitor(monitor m.Monitor) (uint, error) {
+
+	monitor.ID = 0
+	result := s.db.Create(&monitor)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return monitor.ID, nil
+}
+
+// 감시 설정 필드만 갱신. 빈 값, false도 반영
+func (s Storage) UpdateMonitor(monitor m.Monitor) error {
+
+	result := s.db.Model(&monitor).
+		Select("name", "url", "kind", "selector", "spec", "expected", "active").
+		Updates(&monitor)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errNotFound
+	}
+
+	return nil
+}
+
+func (s Storage) DeleteMonitor(id uint) error {
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Where("monitor_id = ?", id).Delete(&m.MonitorHist{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Delete(&m.Monitor{}, id).Error
+	})
+}
+
+// 확인 이력 저장. 조회 성공 시에만 최근 값 갱신
+func (s Storage) SaveMonitorCheck(hist m.MonitorHist) error {
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Create(&hist)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		updates := map[string]any{"last_checked_at": hist.CheckedAt}
+		if hist.Error == "" {
+			updates["last_value"] = hist.Value
+		}
+
+		return tx.Model(&m.Monitor{ID: hist.MonitorID}).Updates(updates).Error
+	})
+}
+
+// 최근 이력부터 limit 건
+func (s Storage) RetrieveMonitorHist(id uint, limit int) ([]m.MonitorHist, error) {
+
+	var hist []m.MonitorHist
+
+	result := s.db.Where("monitor_id = ?", id).Order("checked_at desc").Limit(limit).Find(&hist)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return hist, nil
+}
+
+func (s Storage) RetrieveIndicators() ([]m.Indicator, error) {
+
+	var indicators []m.Indicator
+
+	result := s.db.Order("id").Find(&indicators)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return indicators, nil
+}
+
+func (s Storage) RetrieveIndicator(id uint) (*m.Indicator, error) {
+
+	var indicator m.Indicator
+
+	result := s.db.First(&indicator, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &indicator, nil
+}
+
+func (s Storage) SaveIndicator(indicator m.Indicator) (uint, error) {
+
+	indicator.ID = 0
+	result := s.db.Create(&indicator)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return indicator.ID, nil
+}
+
+// 지표 설정 필드만 갱신. 빈 값, false도 반영
+func (s Storage) UpdateIndicator(indicator m.Indicator) error {
+
+	result := s.db.Model(&indicator).
+		Select("name", "provider", "code", "spec", "unit", "active").
+		Updates(&indicator)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errNotFound
+	}
+
+	return nil
+}
+
+func (s Storage) DeleteIndicator(id uint) error {
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Where("indicator_id = ?", id).Delete(&m.IndicatorValue{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		result = tx.Where("indicator_id = ?", id).Delete(&m.IndicatorAlert{})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Delete(&m.Indicator{}, id).Error
+	})
+}
+
+// 지표 값 저장. 같은 날 재수집 시 갱신
+func (s Storage) SaveIndicatorValue(value m.IndicatorValue, collectedAt time.Time) error {
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+
+		result := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&value)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		return tx.Model(&m.Indicator{ID: value.IndicatorID}).Update("last_collected_at", collectedAt).Error
+	})
+}
+
+// 수집 실패 시 시도 시각만 저장. 다음 수집 주기 판단 기준
+func (s Storage) SaveIndicatorAttempt(id uint, at time.Time) error {
+	return s.db.Model(&m.Indicator{ID: id}).Update("last_collected_at", at).Error
+}
+
+func (s Storage) RetrieveIndicatorValues(id uint, from string, to string) ([]m.IndicatorValue, error) {
+
+	var values []m.IndicatorValue
+
+	query := s.db.Where("indicator_id = ?", id)
+	if from != "" {
+		query = query.Where("date >= ?", from)
+	}
+	if to != "" {
+		query = query.Where("date <= ?", to)
+	}
+
+	result := query.Order("date").Find(&values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return values, nil
+}
+
+// 최근 limit 건. 날짜 오름차순 반환
+func (s Storage) RetrieveLatestIndicatorValues(id uint, limit int) ([]m.IndicatorValue, error) {
+
+	var values []m.IndicatorValue
+
+	result := s.db.Where("indicator_id = ?", id).Order("date desc").Limit(limit).Find(&values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+
+	return values, nil
+}
+
+func (s Storage) RetrieveIndicatorAlerts(indicatorId uint) ([]m.IndicatorAlert, error) {
+
+	var alerts []m.IndicatorAlert
+
+	result := s.db.Where("indicator_id = ?", indicatorId).Order("id").Find(&alerts)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return alerts, nil
+}
+
+func (s Storage) SaveIndicatorAlert(alert m.IndicatorAlert) (uint, error) {
+
+	alert.ID = 0
+	result := s.db.Create(&alert)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return alert.ID, nil
+}
+
+// 알림 조건 필드만 갱신. 조건이 바뀌므로 충족 상태 초기화
+func (s Storage) UpdateIndicatorAlert(alert m.IndicatorAlert) error {
+
+	alert.Triggered = false
+	result := s.db.Model(&alert).
+		Where("indicator_id = ?", alert.IndicatorID).
+		Select("kind", "threshold", "days", "period", "active", "triggered").
+		Updates(&alert)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errNotFound
+	}
+
+	return nil
+}
+
+func (s Storage) DeleteIndicatorAlert(indicatorId uint, id uint) error {
+
+	result := s.db.Where("indicator_id = ?", indicatorId).Delete(&m.IndicatorAlert{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errNotFound
+	}
+
+	return nil
+}
+
+// 조건 충족 상태 저장. 충족으로 바뀐 경우 알림 시각 기록
+func (s Storage) UpdateIndicatorAlertState(id uint, triggered bool, at time.Time) error {
+
+	updates := map[string]any{"triggered": triggered}
+	if triggered {
+		updates["last_triggered_at"] = at
+	}
+
+	return s.db.Model(&m.IndicatorAlert{ID: id}).Updates(updates).Error
+}
+
+func (s Storage) SaveFundValues(values []m.FundValue) error {
+
+	if len(values) == 0 {
+		return nil
+	}
+	return s.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&values).Error
+}
+
+// 자금별 date 이하 가장 최근 평가 금액
+func (s Storage) RetrieveFundValues(date string) ([]m.FundValue, error) {
+
+	var values []m.FundValue
+
+	latest := s.db.Model(&m.FundValue{}).Select("fund_id, MAX(date)").Where("date <= ?", date).Group("fund_id")
+	result := s.db.Where("(fund_id, date) IN (?)", latest).Order("fund_id").Find(&values)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return values, nil
+}
+
+func (s Storage) SaveAlertLog(log m.AlertLog) error {
+	return s.db.Create(&log).Error
+}
+
+// from 이후, to 이전 발송 알림
+func (s Storage) RetrieveAlertLogs(from time.Time, to time.Time) ([]m.AlertLog, error) {
+
+	var logs []m.AlertLog
+
+	result := s.db.Where("created_at > ? AND created_at <= ?", from, to).Order("created_at").Find(&logs)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return logs, nil
+}
+
+func (s Storage) SaveDigestHist(hist m.DigestHist) error {
+	return s.db.Create(&hist).Error
+}
+
+// 종류별 가장 최근 리포트. 미존재 시 nil
+func (s Storage) RetrieveLatestDigestHist(kind m.DigestKind) (*m.DigestHist, error) {
+
+	var hist m.DigestHist
+
+	result := s.db.Where("kind = ?", kind).Order("until DESC").Limit(1).Find(&hist)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	return &hist, nil
+}
+
+func (s Storage) SaveApiKey(key m.ApiKey) (uint, error) {
+
+	key.ID = 0
+	result := s.db.Create(&key)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return key.ID, nil
+}
+
+// 폐기되지 않은 키. 미존재 시 m.ErrNotFound
+func (s Storage) RetrieveApiKeyByHash(hash string) (*m.ApiKey, error) {
+
+	var key m.ApiKey
+
+	result := s.db.Where("hash = ? AND revoked_at IS NULL", hash).Take(&key)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &key, nil
+}
+
+// 폐기된 키 포함 전체
+func (s Storage) RetrieveApiKeys() ([]m.ApiKey, error) {
+
+	var keys []m.ApiKey
+
+	result := s.db.Order("id").Find(&keys)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return keys, nil
+}
+
+func (s Storage) RevokeApiKey(id uint) error {
+
+	result := s.db.Model(&m.ApiKey{}).Where("id = ? AND revoked_at IS NULL", id).Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errNotFound
+	}
+
+	return nil
+}
 
-	a := 2.0 / (200 + 1)
-	return math.Round((a*tp+(1-a)*emay)*100) / 100
+func (s Storage) TouchApiKey(id uint, at time.Time) error {
+	return s.db.Model(&m.ApiKey{}).Where("id = ?", id).Update("last_used_at", at).Error
 }
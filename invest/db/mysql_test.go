@@ -1,11 +1,14 @@
 package db
 
 import (
+	"errors"
 	"fmt"
 	m "invest/model"
 	"log"
 	"testing"
+	"time"
 
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -74,6 +77,15 @@ func TestSaveFund(t *testing.T) {
 	stg.db.Delete(&fund)
 }
 
+func TestRetrieveFunds(t *testing.T) {
+
+	rtn, err := stg.RetrieveFunds()
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(rtn)
+}
+
 func TestRetrieveAssetList(t *testing.T) {
 
 	rtn, err := stg.RetrieveAssetList()
@@ -186,6 +198,22 @@ func TestRetrieveMarketStatus(t *testing.T) {
 	})
 
 }
+func TestRetrieveMarketStatuses(t *testing.T) {
+
+	t.Run("기간 지정", func(t *testing.T) {
+		rtn, err := stg.RetrieveMarketStatuses("2024-08-01", "2024-08-31")
+		if err != nil {
+			t.Error(err)
+		}
+		for i := 1; i < len(rtn); i++ {
+			if !time.Time(rtn[i-1].CreatedAt).Before(time.Time(rtn[i].CreatedAt)) {
+				t.Errorf("일자 순 정렬 오류. %v", rtn)
+			}
+		}
+		t.Log(rtn)
+	})
+}
+
 func TestRetrieveMarketIndicator(t *testing.T) {
 
 	t.Run("날짜 미지정", func(t *testing.T) {
@@ -202,18 +230,31 @@ func TestRetrieveMarketIndicator(t *testing.T) {
 		if err != nil {
 			t.Error(t)
 		}
-		t.Log(*rtn1)
-		t.Log(*rtn2)
+		t.Log(rtn1)
+		t.Log(rtn2)
 	})
 }
 
-func TestSaveDailyMarketIndicator(t *testing.T) {
+func TestSaveIndicatorAttempt(t *testing.T) {
+
+	err := stg.SaveIndicatorAttempt(1, time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSaveIndicatorValue(t *testing.T) {
 
-	err := stg.SaveDailyMarketIndicator(20, 183.35)
+	err := stg.SaveIndicatorValue(m.IndicatorValue{IndicatorID: 1, Date: datatypes.Date(time.Now()), Value: 20}, time.Now())
 	if err != nil {
 		t.Error(err)
 	}
 
+	values, err := stg.RetrieveIndicatorValues(1, time.Now().Format("2006-01-02"), "")
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(values)
 }
 func TestSaveMarketStatus(t *testing.T) {
 
@@ -234,20 +275,39 @@ func TestSaveMarketStatus(t *testing.T) {
 	stg.db.Delete(&mk)
 
 }
-func TestRetrieveInvestHist(t *testing.T) {
+func TestRetrieveInvests(t *testing.T) {
 
-	t.Run("날짜 미지정", func(t *testing.T) {
-		rtn, err := stg.RetrieveInvestHist(1, 0, "", "")
+	t.Run("조건 미지정", func(t *testing.T) {
+		rtn, err := stg.RetrieveInvests(m.InvestQuery{FundID: 1})
 		if err != nil {
-			t.Error(t)
+			t.Error(err)
 		}
 		t.Log(rtn)
 	})
 
-	t.Run("날짜 지정", func(t *testing.T) {
-		rtn, err := stg.RetrieveInvestHist(1, 0, "2024-05-01", "")
+	t.Run("날짜, 카테고리, 매도 지정", func(t *testing.T) {
+		from, _ := time.ParseInLocation("2006-01-02", "2024-05-01", time.Local)
+		rtn, err := stg.RetrieveInvests(m.InvestQuery{Category: m.DomesticETF, Side: m.SellSide, From: from, Sort: m.SortAmount, Desc: true})
 		if err != nil {
-			t.Error(t)
+			t.Error(err)
+		}
+		t.Log(rtn)
+	})
+
+	t.Run("다음 페이지", func(t *testing.T) {
+		page, err := stg.RetrieveInvests(m.InvestQuery{Desc: true, Limit: 2})
+		if err != nil || len(page) == 0 {
+			t.Fatal(err)
+		}
+		last := page[len(page)-1]
+		rtn, err := stg.RetrieveInvests(m.InvestQuery{Desc: true, Limit: 2, After: &m.InvestCursor{Value: last.CreatedAt, ID: last.ID}})
+		if err != nil {
+			t.Error(err)
+		}
+		for _, iv := range rtn {
+			if iv.ID == last.ID {
+				t.Errorf("이전 페이지 이력 중복. %d", iv.ID)
+			}
 		}
 		t.Log(rtn)
 	})
@@ -273,17 +333,22 @@ func TestSaveInvest(t *testing.T) {
 
 }
 
-func TestRetreiveLatestEma(t *testing.T) {
-	rtn, err := stg.RetreiveLatestEma(2)
+func TestRetrieveLatestAverage(t *testing.T) {
+	rtn, err := stg.RetrieveLatestAverage(2, m.DefaultAverage)
 	if err != nil {
 		t.Error(err)
 	}
 	t.Logf("%+v", rtn)
+
+	rtn, err = stg.RetrieveLatestAverage(0, m.DefaultAverage)
+	if err != nil || rtn != nil {
+		t.Error("이력 미존재 시 nil 반환 필요", rtn, err)
+	}
 }
 
-func TestSaveEmaHist(t *testing.T) {
+func TestSaveAssetAverages(t *testing.T) {
 
-	// err := stg.SaveEmaHist(1, 64425.30)
+	// err := stg.SaveAssetAverages(1, []m.AssetAverage{{Kind: m.EMA, Period: 200, Reference: true}})
 	// if err != nil {
 	// 	t.Error(err)
 	// }
@@ -306,3 +371,79 @@ func TestRetrieveInvestSummary(t *testing.T) {
 	}
 	t.Log(investSummary)
 }
+
+func TestFundValues(t *testing.T) {
+
+	today := datatypes.Date(time.Now())
+	err := stg.SaveFundValues([]m.FundValue{{FundID: 1, Date: today, Value: 10000000}})
+	if err != nil {
+		t.Error(err)
+	}
+
+	rtn, err := stg.RetrieveFundValues(time.Now().AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(rtn)
+}
+
+func TestAlertLogs(t *testing.T) {
+
+	err := stg.SaveAlertLog(m.AlertLog{Source: m.PriceSource, Message: "테스트 알림", CreatedAt: time.Now()})
+	if err != nil {
+		t.Error(err)
+	}
+
+	rtn, err := stg.RetrieveAlertLogs(time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+	if len(rtn) == 0 {
+		t.Error("RowsAffected : 0")
+	}
+	t.Log(rtn)
+}
+
+func TestRetrieveLatestDigestHist(t *testing.T) {
+
+	rtn, err := stg.RetrieveLatestDigestHist(m.DailyDigest)
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(rtn)
+}
+
+func TestApiKeys(t *testing.T) {
+
+	id, err := stg.SaveApiKey(m.ApiKey{Name: "테스트", Prefix: "inv_test", Hash: "test-hash", Scope: m.ReadScope})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := stg.RetrieveApiKeyByHash("test-hash")
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(key)
+
+	err = stg.TouchApiKey(id, time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+
+	err = stg.RevokeApiKey(id)
+	if err != nil {
+		t.Error(err)
+	}
+
+	_, err = stg.RetrieveApiKeyByHash("test-hash")
+	if !errors.Is(err, m.ErrNotFound) {
+		t.Errorf("폐기된 키 조회됨. %v", err)
+	}
+
+	rtn, err := stg.RetrieveApiKeys()
+	if err != nil {
+		t.Error(err)
+	}
+	t.Log(rtn)
+}
@@ -0,0 +1,109 @@
+package event
+
+import (
+	"invest/bus"
+	m "invest/model"
+	"time"
+)
+
+/*
+지표 값 갱신 후 알림 규칙 판단
+조건 충족 상태가 미충족 -> 충족으로 바뀔 때만 알림 전송. 충족 -> 미충족이면 상태만 초기화
+*/
+func (e Event) checkIndicatorAlerts(p Publisher, indicator m.Indicator, now time.Time) {
+
+	alerts, err := e.stg.RetrieveIndicatorAlerts(indicator.ID)
+	if err != nil {
+		p.Publish(jobError("IndexEvent", "RetrieveIndicatorAlerts", indicator.Name, err))
+		return
+	}
+
+	window := 0
+	for _, a := range alerts {
+		if a.Active && a.Window() > window {
+			window = a.Window()
+		}
+	}
+	if window == 0 {
+		return
+	}
+
+	values, err := e.stg.RetrieveLatestIndicatorValues(indicator.ID, window)
+	if err != nil {
+		p.Publish(jobError("IndexEvent", "RetrieveLatestIndicatorValues", indicator.Name, err))
+		return
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	for _, a := range alerts {
+		if !a.Active {
+			continue
+		}
+
+		hit, ok := alertHit(a, values)
+		if !ok || hit == a.Triggered {
+			continue
+		}
+
+		err = e.stg.UpdateIndicatorAlertState(a.ID, hit, now)
+		if err != nil {
+			p.Publish(jobError("IndexEvent", "UpdateIndicatorAlertState", indicator.Name, err))
+			continue
+		}
+
+		if hit {
+			p.Publish(bus.IndicatorAlert{Indicator: indicator, Rule: a, Value: values[len(values)-1].Value})
+		}
+	}
+}
+
+/*
+values : 날짜 오름차순, 마지막 값이 현재 값
+ok : 판단에 필요한 값이 부족하면 false
+*/
+func alertHit(a m.IndicatorAlert, values []m.IndicatorValue) (hit bool, ok bool) {
+
+	closes := make([]float64, len(values))
+	for i, v := range values {
+		closes[i] = v.Value
+	}
+	n := len(closes)
+	if n == 0 {
+		return false, false
+	}
+	cur := closes[n-1]
+
+	switch a.Kind {
+	case m.AboveAlert:
+		return cur >= a.Threshold, true
+	case m.BelowAlert:
+		return cur <= a.Threshold, true
+	case m.ChangeAlert:
+		if a.Days <= 0 || n < a.Days+1 {
+			return false, false
+		}
+		base := closes[n-1-a.Days]
+		if base == 0 {
+			return false, false
+		}
+		rate := (cur - base) / base * 100
+		if a.Threshold < 0 {
+			return rate <= a.Threshold, true
+		}
+		return rate >= a.Threshold, true
+	case m.CrossAboveAlert, m.CrossBelowAlert:
+		if a.Period <= 0 || n < a.Period+1 {
+			return false, false
+		}
+		avg := sma(closes, a.Period)
+		prev, prevAvg, curAvg := closes[n-2], avg[len(avg)-2], avg[len(avg)-1]
+		if a.Kind == m.CrossAboveAlert {
+			return prev < prevAvg && cur >= curAvg, true
+		}
+		return prev > prevAvg && cur <= curAvg, true
+	}
+
+	return false, false
+}
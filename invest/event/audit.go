@@ -0,0 +1,36 @@
+package event
+
+import (
+	"invest/bus"
+	m "invest/model"
+	"log"
+	"time"
+)
+
+// 발송 기록 대상 이벤트. 정기 리포트 알림 항목에 사용
+var AlertKinds = []bus.Kind{bus.KindPriceAlert, bus.KindIndicatorAlert, bus.KindMonitorChange}
+
+/*
+LogAlert
+알림 이벤트 발송 기록. 버스에 AlertKinds 구독으로 등록
+  - 기록 실패 시 로그만 남김
+*/
+func (e Event) LogAlert(ev bus.Event) {
+
+	var source m.AlertSource
+	switch ev.Kind() {
+	case bus.KindPriceAlert:
+		source = m.PriceSource
+	case bus.KindIndicatorAlert:
+		source = m.IndicatorSource
+	case bus.KindMonitorChange:
+		source = m.MonitorSource
+	default:
+		return
+	}
+
+	err := e.stg.SaveAlertLog(m.AlertLog{Source: source, Message: ev.String(), CreatedAt: time.Now()})
+	if err != nil {
+		log.Printf("[LogAlert] SaveAlertLog 시, 에러 발생. %s", err)
+	}
+}
@@ -0,0 +1,216 @@
+package event
+
+import (
+	"fmt"
+	m "invest/model"
+	"time"
+)
+
+// 일봉 소급 조회 시 휴장일 여유 일수
+const averageBackfillMargin = 30
+
+/*
+RecomputeAverages
+저장된 일봉 종가로 자산의 이동평균 이력 재산출. 일봉이 부족하면 일봉 API로 소급 저장 후 산출
+  - 당일 일봉은 장중 값이므로 제외
+  - 설정된 이동평균이 없으면 기본 이동평균(EMA200) 산출
+  - 일봉이 기간보다 적으면 해당 이동평균 이력은 비워둠
+  - 일봉 조회 미지원 종목(현금, 단기채권, 레버리지 등)은 산출 생략
+*/
+func (e Event) RecomputeAverages(assetId uint) error {
+
+	a, err := e.stg.RetrieveAsset(assetId)
+	if err != nil {
+		return fmt.Errorf("[RecomputeAverages] RetrieveAsset 시, 에러 발생. %w", err)
+	}
+	if !a.Category.HasDailyCandles() {
+		return nil
+	}
+
+	specs, err := e.averageSpecs(assetId)
+	if err != nil {
+		return err
+	}
+
+	var maxPeriod uint
+	for _, s := range specs {
+		maxPeriod = max(maxPeriod, s.Period)
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1)
+	prices, err := e.stg.RetrieveDailyPrices(assetId, "", yesterday.Format("2006-01-02"))
+	if err != nil {
+		return fmt.Errorf("[RecomputeAverages] RetrieveDailyPrices 시, 에러 발생. %w", err)
+	}
+
+	if len(prices) < int(maxPeriod) {
+		from := yesterday.AddDate(0, 0, -int(maxPeriod*3/2+averageBackfillMargin))
+		_, err = e.PriceBackfill(assetId, from, yesterday)
+		if err != nil {
+			return err
+		}
+
+		prices, err = e.stg.RetrieveDailyPrices(assetId, "", yesterday.Format("2006-01-02"))
+		if err != nil {
+			return fmt.Errorf("[RecomputeAverages] RetrieveDailyPrices 시, 에러 발생. %w", err)
+		}
+	}
+
+	closes := make([]float64, len(prices))
+	for i, p := range prices {
+		closes[i] = p.Close
+	}
+
+	for _, s := range specs {
+		var values []float64
+		switch s.Kind {
+		case m.SMA:
+			values = sma(closes, int(s.Period))
+		case m.EMA:
+			values = ema(closes, int(s.Period))
+		}
+
+		// values[i]는 prices[Period-1+i] 일자의 이동평균
+		hist := make([]m.AverageHist, len(values))
+		offset := int(s.Period) - 1
+		for i, v := range values {
+			hist[i] = m.AverageHist{
+				AssetID: assetId,
+				Kind:    s.Kind,
+				Period:  s.Period,
+				Date:    prices[offset+i].Date,
+				Value:   v,
+			}
+		}
+
+		err = e.stg.SaveAverageHist(assetId, s, hist)
+		if err != nil {
+			return fmt.Errorf("[RecomputeAverages] SaveAverageHist 시, 에러 발생. %s. %w", s, err)
+		}
+	}
+
+	return nil
+}
+
+/*
+AverageUpdateEvent
+전 영업일 일봉 저장 후 전체 자산 이동평균 재산출
+*/
+func (e Event) AverageUpdateEvent(p Publisher) {
+
+	assetList, err := e.stg.RetrieveAssetList()
+	if err != nil {
+		p.Publish(jobError("AverageUpdateEvent", "RetrieveAssetList", "", err))
+		return
+	}
+
+	// 최근 일봉을 함께 저장하여 누락된 일자 보정
+	to := time.Now().AddDate(0, 0, -1)
+	from := to.AddDate(0, 0, -10)
+
+	for _, a := range assetList {
+		if !a.Category.HasDailyCandles() {
+			continue
+		}
+
+		_, err = e.PriceBackfill(a.ID, from, to)
+		if err != nil {
+			p.Publish(jobError("AverageUpdateEvent", "PriceBackfill", "", err))
+			continue
+		}
+
+		err = e.RecomputeAverages(a.ID)
+		if err != nil {
+			p.Publish(jobError("AverageUpdateEvent", "RecomputeAverages", "", err))
+		}
+	}
+}
+
+// 우선순위 계산 시 기준 이동평균 값. 산출 이력이 없으면 0 반환
+func (e Event) referenceAverage(assetId uint) (float64, error) {
+
+	averages, err := e.stg.RetrieveAssetAverages(assetId)
+	if err != nil {
+		return 0, fmt.Errorf("RetrieveAssetAverages 시, 에러 발생. ID: %d. %w", assetId, err)
+	}
+
+	spec := m.DefaultAverage
+	for _, a := range averages {
+		if a.Reference {
+			spec = a.Spec()
+			break
+		}
+	}
+
+	hist, err := e.stg.RetrieveLatestAverage(assetId, spec)
+	if err != nil {
+		return 0, fmt.Errorf("RetrieveLatestAverage 시, 에러 발생. ID: %d. %w", assetId, err)
+	}
+	if hist == nil {
+		return 0, nil
+	}
+
+	return hist.Value, nil
+}
+
+func (e Event) averageSpecs(assetId uint) ([]m.AverageSpec, error) {
+
+	averages, err := e.stg.RetrieveAssetAverages(assetId)
+	if err != nil {
+		return nil, fmt.Errorf("[RecomputeAverages] RetrieveAssetAverages 시, 에러 발생. %w", err)
+	}
+
+	if len(averages) == 0 {
+		return []m.AverageSpec{m.DefaultAverage}, nil
+	}
+
+	specs := make([]m.AverageSpec, len(averages))
+	for i, a := range averages {
+		specs[i] = a.Spec()
+	}
+	return specs, nil
+}
+
+/*
+단순 이동평균. rtn[i] = closes[i : i+n] 평균
+*/
+func sma(closes []float64, n int) []float64 {
+
+	if n <= 0 || len(closes) < n {
+		return nil
+	}
+
+	rtn := make([]float64, 0, len(closes)-n+1)
+	var sum float64
+	for i, c := range closes {
+		sum += c
+		if i >= n {
+			sum -= closes[i-n]
+		}
+		if i >= n-1 {
+			rtn = append(rtn, sum/float64(n))
+		}
+	}
+	return rtn
+}
+
+/*
+지수 이동평균. 첫 값은 최초 n일 단순 이동평균으로 시작
+a = 2/(N+1)
+EMAt = a*PRICEt + (1-a)EMAy
+*/
+func ema(closes []float64, n int) []float64 {
+
+	if n <= 0 || len(closes) < n {
+		return nil
+	}
+
+	a := 2.0 / float64(n+1)
+
+	rtn := make([]float64, 0, len(closes)-n+1)
+	rtn = append(rtn, sma(closes[:n], n)[0])
+	for _, c := range closes[n:] {
+		rtn = append(rtn, a*c+(1-a)*rtn[len(rtn)-1])
+	}
+	return rtn
+}
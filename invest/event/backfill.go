@@ -0,0 +1,60 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	m "invest/model"
+	"time"
+)
+
+/*
+PriceBackfill
+일봉 API로 from ~ to 기간의 일별 시세 저장. assetId가 0이면 전체 자산 대상
+저장한 일봉 건수 반환
+  - 일봉 조회 미지원 종목은 제외
+  - 자산별 실패 시 나머지 자산 진행 후 실패 내용을 모아 반환
+*/
+func (e Event) PriceBackfill(assetId uint, from time.Time, to time.Time) (int, error) {
+
+	var assets []m.Asset
+	if assetId == 0 {
+		li, err := e.stg.RetrieveTotalAssets()
+		if err != nil {
+			return 0, fmt.Errorf("[PriceBackfill] RetrieveTotalAssets 시, 에러 발생. %w", err)
+		}
+		assets = li
+	} else {
+		a, err := e.stg.RetrieveAsset(assetId)
+		if err != nil {
+			return 0, fmt.Errorf("[PriceBackfill] RetrieveAsset 시, 에러 발생. %w", err)
+		}
+		assets = []m.Asset{*a}
+	}
+
+	cnt := 0
+	var errs []error
+	for _, a := range assets {
+		if !a.Category.HasDailyCandles() {
+			continue
+		}
+
+		candles, err := e.dp.DailyCandles(a.Category, a.Code, from, to)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("[PriceBackfill] DailyCandles 시, 에러 발생. ID: %d. %w", a.ID, err))
+			continue
+		}
+
+		for i := range candles {
+			candles[i].AssetID = a.ID
+		}
+
+		err = e.stg.SaveDailyPrices(candles)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("[PriceBackfill] SaveDailyPrices 시, 에러 발생. ID: %d. %w", a.ID, err))
+			continue
+		}
+		cnt += len(candles)
+	}
+
+	return cnt, errors.Join(errs...)
+}
@@ -1,6 +1,9 @@
 package event
 
-import "time"
+import (
+	"sync"
+	"time"
+)
 
 type assetMsg struct {
 	assetId uint
@@ -13,6 +16,8 @@ type assetMsgSentInfo struct {
 	sentTime  time.Time
 }
 
+// 실시간 시세 수신과 주기 작업이 동시에 접근. 아래 캐시 모두 cacheMu 보유 후 접근
+var cacheMu sync.Mutex
 var assetMsgCache map[assetMsg]*assetMsgSentInfo
 var portMsgCache map[bool]time.Time
 var dailyCache time.Time
@@ -23,6 +28,8 @@ func init() {
 }
 
 func hasMsgCache(assetId uint, isSell bool, price float64) bool {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
 
 	cache := assetMsgCache[assetMsg{
 		assetId: assetId,
@@ -42,6 +49,8 @@ func hasMsgCache(assetId uint, isSell bool, price float64) bool {
 }
 
 func setMsgCache(assetId uint, isSell bool, price float64) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
 
 	k := assetMsg{
 		assetId: assetId,
@@ -64,6 +73,8 @@ func setMsgCache(assetId uint, isSell bool, price float64) {
 }
 
 func hasPortCache(isSell bool) bool {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
 
 	sendTime := portMsgCache[isSell]
 
@@ -75,10 +86,15 @@ func hasPortCache(isSell bool) bool {
 }
 
 func setPortCache(isSell bool) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
 	portMsgCache[isSell] = time.Now()
 }
 
 func hasDailyCache() bool {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
 
 	if (dailyCache == time.Time{} || dailyCache.Before(time.Now().Add(-24*time.Hour))) {
 		return false
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"sync"
 	"testing"
 	"time"
 )
@@ -63,3 +64,24 @@ func TestCache(t *testing.T) {
 		}
 	})
 }
+
+func TestCacheConcurrent(t *testing.T) {
+
+	// go test -race 로 포트폴리오/일일 캐시 동시 접근 확인
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(isSell bool) {
+			defer wg.Done()
+			setPortCache(isSell)
+			hasPortCache(isSell)
+			hasDailyCache()
+			setMsgCache(uint(10), isSell, 1000)
+		}(i%2 == 0)
+	}
+	wg.Wait()
+
+	if !hasPortCache(true) || !hasPortCache(false) {
+		t.Error("포트폴리오 캐시 미갱신")
+	}
+}
@@ -0,0 +1,343 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"invest/chart"
+	m "invest/model"
+	"invest/render"
+	"sort"
+	"time"
+)
+
+// 시세 차트 기본 기간 (영업일)
+const DefaultChartDays = 120
+
+/*
+FundChart
+자금의 자산별 비중 원형 차트. 자산 요약의 최근 평가 금액(원화 환산) 기준
+  - 비중 큰 순서. 색상 수 초과분은 기타로 합산
+*/
+func (e Event) FundChart(fundId uint) (*m.Chart, error) {
+
+	ivsmLi, err := e.stg.RetreiveFundsSummaryOrderByFundId()
+	if err != nil {
+		return nil, fmt.Errorf("RetreiveFundsSummaryOrderByFundId 시 오류 발생. %w", err)
+	}
+
+	ex := e.dp.ExchageRate()
+	if ex == 0 {
+		return nil, errors.New("ExchageRate 시 환율 값 0 반환")
+	}
+
+	return fundChart(fundId, ivsmLi, ex)
+}
+
+func fundChart(fundId uint, ivsmLi []m.InvestSummary, ex float64) (*m.Chart, error) {
+
+	type slice struct {
+		name  string
+		value float64
+	}
+	slices := make([]slice, 0)
+	var total float64
+	for i := range ivsmLi {
+		ivsm := &ivsmLi[i]
+		if ivsm.FundID != fundId {
+			continue
+		}
+		v := krwValue(ivsm, ex)
+		if v <= 0 {
+			continue
+		}
+		slices = append(slices, slice{ivsm.Asset.Name, v})
+		total += v
+	}
+	if len(slices) == 0 {
+		return nil, fmt.Errorf("자금 %d 평가 금액 있는 자산 미존재", fundId)
+	}
+
+	sort.SliceStable(slices, func(i, j int) bool { return slices[i].value > slices[j].value })
+	if n := chart.PaletteSize(); len(slices) > n {
+		etc := slice{}
+		for _, s := range slices[n-1:] {
+			etc.value += s.value
+		}
+		slices = append(slices[:n-1], etc)
+	}
+
+	values := make([]float64, len(slices))
+	caption := fundCaption{FundID: fundId, Total: total}
+	for i, s := range slices {
+		values[i] = s.value
+		caption.Slices = append(caption.Slices, pieSlice{Legend: chart.Legend(i), Name: s.name, Value: s.value, Rate: s.value / total})
+	}
+
+	img, err := chart.Pie(values)
+	if err != nil {
+		return nil, fmt.Errorf("Pie 시 오류 발생. %w", err)
+	}
+
+	return &m.Chart{Image: img, Caption: render.Text("FundChart", caption)}, nil
+}
+
+/*
+차트 설명 템플릿 데이터. 기본 언어 일반 텍스트(FundChart, RatioChart, PriceChart)로 작성
+  - pieSlice.Name : 빈 값이면 색상 수 초과분 합산(기타)
+  - averageLine.Missing : 일봉 부족으로 미표시
+*/
+type fundCaption struct {
+	FundID uint
+	Total  float64
+	Slices []pieSlice
+}
+
+type pieSlice struct {
+	Legend string
+	Name   string
+	Value  float64
+	Rate   float64
+}
+
+type ratioCaption struct {
+	Level m.MarketLevel
+	Min   float64
+	Max   float64
+	Funds []m.Allocation
+}
+
+type priceCaption struct {
+	Name     string
+	Days     int
+	Legend   string
+	Close    float64
+	Averages []averageLine
+}
+
+type averageLine struct {
+	Legend  string
+	Spec    m.AverageSpec
+	Value   float64
+	Missing bool
+}
+
+/*
+RatioChart
+자금별 변동 자산 비율과 현재 시장 단계의 변동 자산 비율 허용 범위
+*/
+func (e Event) RatioChart() (*m.Chart, error) {
+
+	allocs, err := e.Allocations()
+	if err != nil {
+		return nil, err
+	}
+
+	funds := make([]m.Allocation, 0, len(allocs))
+	for _, a := range allocs {
+		if a.Total() > 0 {
+			funds = append(funds, a)
+		}
+	}
+	if len(funds) == 0 {
+		return nil, errors.New("평가 금액 있는 자금 미존재")
+	}
+
+	caption := ratioCaption{Level: funds[0].Market, Min: funds[0].Min, Max: funds[0].Max, Funds: funds}
+
+	bands := make([]chart.Band, len(funds))
+	for i, a := range funds {
+		bands[i] = chart.Band{Label: fmt.Sprintf("#%d", a.Fund.ID), Value: a.Ratio(), Min: caption.Min, Max: caption.Max}
+	}
+
+	img, err := chart.Bands(bands)
+	if err != nil {
+		return nil, fmt.Errorf("Bands 시 오류 발생. %w", err)
+	}
+
+	return &m.Chart{Image: img, Caption: render.Text("RatioChart", caption)}, nil
+}
+
+/*
+Allocations
+자금별 안전/변동 자산 평가 금액과 현재 시장 단계 허용 범위. 자금 ID 순
+  - 자산 요약이 있는 자금만 포함
+*/
+func (e Event) Allocations() ([]m.Allocation, error) {
+
+	market, err := e.stg.RetrieveMarketStatus("")
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveMarketStatus 시 오류 발생. %w", err)
+	}
+
+	ivsmLi, err := e.stg.RetreiveFundsSummaryOrderByFundId()
+	if err != nil {
+		return nil, fmt.Errorf("RetreiveFundsSummaryOrderByFundId 시 오류 발생. %w", err)
+	}
+
+	ex := e.dp.ExchageRate()
+	if ex == 0 {
+		return nil, errors.New("ExchageRate 시 환율 값 0 반환")
+	}
+
+	return allocations(m.MarketLevel(market.Status), ivsmLi, ex), nil
+}
+
+func allocations(level m.MarketLevel, ivsmLi []m.InvestSummary, ex float64) []m.Allocation {
+
+	allocs := make(map[uint]*m.Allocation)
+	for i := range ivsmLi {
+		ivsm := &ivsmLi[i]
+		a, ok := allocs[ivsm.FundID]
+		if !ok {
+			a = &m.Allocation{
+				Fund:   m.Fund{ID: ivsm.FundID, Name: ivsm.Fund.Name},
+				Market: level,
+				Min:    level.MinVolatileAssetRate(),
+				Max:    level.MaxVolatileAssetRate(),
+			}
+			allocs[ivsm.FundID] = a
+		}
+
+		v := krwValue(ivsm, ex)
+		if ivsm.Asset.Category.IsStable() {
+			a.Stable += v
+		} else {
+			a.Volatile += v
+		}
+		a.Assets = append(a.Assets, m.AssetAllocation{Asset: ivsm.Asset, Count: ivsm.Count, Value: v})
+	}
+
+	rtn := make([]m.Allocation, 0, len(allocs))
+	for _, a := range allocs {
+		sort.SliceStable(a.Assets, func(i, j int) bool { return a.Assets[i].Value > a.Assets[j].Value })
+		rtn = append(rtn, *a)
+	}
+	sort.Slice(rtn, func(i, j int) bool { return rtn[i].Fund.ID < rtn[j].Fund.ID })
+	return rtn
+}
+
+/*
+PriceChart
+최근 days 영업일 종가와 자산에 설정된 이동평균 선 그래프
+  - 이동평균은 저장된 일봉 종가로 산출. 기간 시작 전 일봉이 부족하면 가능한 구간만 표시
+*/
+func (e Event) PriceChart(assetId uint, days int) (*m.Chart, error) {
+
+	if days <= 0 {
+		days = DefaultChartDays
+	}
+
+	a, err := e.stg.RetrieveAsset(assetId)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveAsset 시 오류 발생. %w", err)
+	}
+	if a.Category == m.Won || a.Category == m.Dollar {
+		return nil, fmt.Errorf("현금 자산은 시세 차트 미지원. ID : %d", assetId)
+	}
+
+	specs, err := e.averageSpecs(assetId)
+	if err != nil {
+		return nil, err
+	}
+	var maxPeriod int
+	for _, s := range specs {
+		maxPeriod = max(maxPeriod, int(s.Period))
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -(days+maxPeriod)*3/2-averageBackfillMargin) // 영업일 -> 달력일 여유
+	prices, err := e.stg.RetrieveDailyPrices(assetId, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveDailyPrices 시 오류 발생. %w", err)
+	}
+	if len(prices) < 2 {
+		return nil, fmt.Errorf("일봉 부족. 일봉 백필 필요. ID : %d", assetId)
+	}
+
+	closes := make([]float64, len(prices))
+	for i, p := range prices {
+		closes[i] = p.Close
+	}
+	start := max(0, len(prices)-days)
+
+	series := []chart.Series{{Points: points(prices, closes[start:], start)}}
+	caption := priceCaption{Name: a.Name, Days: len(prices) - start, Legend: chart.Legend(0), Close: closes[len(closes)-1]}
+
+	for _, s := range specs {
+		var values []float64
+		switch s.Kind {
+		case m.SMA:
+			values = sma(closes, int(s.Period))
+		case m.EMA:
+			values = ema(closes, int(s.Period))
+		}
+		if len(values) == 0 {
+			caption.Averages = append(caption.Averages, averageLine{Spec: s, Missing: true})
+			continue
+		}
+
+		// values[i]는 prices[Period-1+i] 일자의 이동평균
+		offset := int(s.Period) - 1
+		skip := max(0, start-offset)
+		i := len(series)
+		series = append(series, chart.Series{Points: points(prices, values[skip:], offset+skip)})
+		caption.Averages = append(caption.Averages, averageLine{Legend: chart.Legend(i), Spec: s, Value: values[len(values)-1]})
+	}
+
+	img, err := chart.Lines(series)
+	if err != nil {
+		return nil, fmt.Errorf("Lines 시 오류 발생. %w", err)
+	}
+
+	return &m.Chart{Image: img, Caption: render.Text("PriceChart", caption)}, nil
+}
+
+// values[i]는 prices[offset+i] 일자의 값
+func points(prices []m.DailyPrice, values []float64, offset int) []chart.Point {
+	rtn := make([]chart.Point, len(values))
+	for i, v := range values {
+		rtn[i] = chart.Point{Time: time.Time(prices[offset+i].Date), Value: v}
+	}
+	return rtn
+}
+
+/*
+ChartEvent
+변동 자산 비율 차트와 자금별 자산 비중 차트 전송
+*/
+func (e Event) ChartEvent(p Publisher, send func(m.Chart)) {
+
+	ratio, err := e.RatioChart()
+	if err != nil {
+		p.Publish(jobError("ChartEvent", "RatioChart", "", err))
+		return
+	}
+	send(*ratio)
+
+	ivsmLi, err := e.stg.RetreiveFundsSummaryOrderByFundId()
+	if err != nil {
+		p.Publish(jobError("ChartEvent", "RetreiveFundsSummaryOrderByFundId", "", err))
+		return
+	}
+
+	ex := e.dp.ExchageRate()
+	if ex == 0 {
+		p.Publish(jobError("ChartEvent", "ExchageRate", "", errors.New("환율 값 0 반환")))
+		return
+	}
+
+	sent := make(map[uint]bool)
+	for _, ivsm := range ivsmLi {
+		if sent[ivsm.FundID] {
+			continue
+		}
+		sent[ivsm.FundID] = true
+
+		fund, err := fundChart(ivsm.FundID, ivsmLi, ex)
+		if err != nil {
+			p.Publish(jobError("ChartEvent", "FundChart", "", err))
+			continue
+		}
+		send(*fund)
+	}
+}
@@ -0,0 +1,45 @@
+package event
+
+import (
+	"invest/bus"
+	"time"
+)
+
+/*
+OECD 경기선행지수(CLI) 갱신
+  - 월 1회 발표되나 과거 값도 수정되므로 조회된 시계열 전체를 저장
+  - 직전 저장분보다 새로운 월이 발표된 경우에만 알림
+*/
+func (e Event) CliEvent(p Publisher) {
+
+	series, err := e.dp.CliSeries()
+	if err != nil {
+		p.Publish(jobError("CliEvent", "CliSeries", "", err))
+		return
+	}
+	if len(series) == 0 {
+		return
+	}
+
+	_, former, err := e.stg.RetrieveMarketIndicator("")
+	if err != nil {
+		former = nil // 일일 지표 미존재 시에도 CLI 갱신은 진행
+	}
+
+	err = e.stg.SaveCliIndex(series)
+	if err != nil {
+		p.Publish(jobError("CliEvent", "SaveCliIndex", "", err))
+		return
+	}
+
+	latest := series[len(series)-1]
+	if former != nil && !time.Time(latest.CreatedAt).After(time.Time(former.CreatedAt)) {
+		return
+	}
+
+	update := bus.CliUpdate{Latest: latest}
+	if len(series) > 1 {
+		update.Previous = &series[len(series)-2]
+	}
+	p.Publish(update)
+}
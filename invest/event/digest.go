@@ -0,0 +1,313 @@
+package event
+
+import (
+	"fmt"
+	"invest/bus"
+	m "invest/model"
+	"invest/render"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+const (
+	digestLookback  = 10 // 기준일 값 조회 시 휴장일 여유 일수
+	digestDayFormat = "2006-01-02"
+)
+
+/*
+BuildDigest
+정기 리포트 작성. 항목별 조회 실패 시 Errors에 기록하고 나머지 항목 작성
+  - 평가 금액, 시세, 지표는 Kind 기간 이전 기준일 값과 비교
+  - 알림은 직전 리포트 이후 발송 건
+  - 자금 평가 금액은 당일 값으로 저장하여 다음 리포트의 비교 기준으로 사용
+*/
+func (e Event) BuildDigest(spec m.DigestSpec, now time.Time) *m.DigestReport {
+
+	base := now.AddDate(0, 0, -spec.Kind.Days())
+	d := &m.DigestReport{Spec: spec, From: base, To: now}
+
+	last, err := e.stg.RetrieveLatestDigestHist(spec.Kind)
+	if err != nil {
+		d.Errors = append(d.Errors, m.DigestError{Step: "RetrieveLatestDigestHist", Message: err.Error()})
+	} else if last != nil {
+		d.From = last.Until
+	}
+
+	var ivsmLi []m.InvestSummary
+	var fundErr error
+	var ex float64
+	if spec.Has(m.FundSection) || spec.Has(m.MoverSection) || spec.Has(m.RebalanceSection) {
+		ivsmLi, fundErr = e.stg.RetreiveFundsSummaryOrderByFundId()
+		if fundErr != nil {
+			d.Errors = append(d.Errors, m.DigestError{Step: "RetreiveFundsSummaryOrderByFundId", Message: fundErr.Error()})
+		}
+		ex = e.dp.ExchageRate()
+		if ex == 0 {
+			d.Errors = append(d.Errors, m.DigestError{Step: "ExchageRate"})
+		}
+	}
+	portfolio := fundErr == nil && ex != 0
+
+	steps := []struct {
+		sec   m.DigestSection
+		ready bool
+		run   func() error
+	}{
+		{m.FundSection, portfolio, func() error { return e.digestFunds(d, ivsmLi, ex, base, now) }},
+		{m.MoverSection, portfolio, func() error { return e.digestMovers(d, ivsmLi, base, now) }},
+		{m.RebalanceSection, portfolio, func() error { return e.digestRebalances(d, ivsmLi, ex) }},
+		{m.MarketSection, true, func() error { return e.digestMarket(d, base, now) }},
+		{m.AlertSection, true, func() error { return e.digestAlerts(d) }},
+	}
+
+	for _, st := range steps {
+		if !spec.Has(st.sec) || !st.ready {
+			continue
+		}
+		if err := st.run(); err != nil {
+			d.Errors = append(d.Errors, m.DigestError{Section: st.sec, Message: err.Error()})
+		}
+	}
+
+	return d
+}
+
+/*
+DigestEvent
+정기 리포트 전송 및 발송 이력 저장. 설정 시 파일 저장
+  - 차트는 리포트 이벤트에 담아 발행. 묶음 전송 대기 후에도 리포트 문구 다음에 전송
+*/
+func (e Event) DigestEvent(p Publisher, spec m.DigestSpec) {
+
+	d := e.BuildDigest(spec, time.Now())
+	digest := bus.Digest{Report: d}
+	if spec.Charts {
+		e.ChartEvent(p, func(ct m.Chart) { digest.Charts = append(digest.Charts, ct) })
+	}
+	p.Publish(digest)
+
+	if spec.Dir != "" {
+		_, err := writeDigest(d)
+		if err != nil {
+			p.Publish(jobError("DigestEvent", "writeDigest", "", err))
+		}
+	}
+
+	err := e.stg.SaveDigestHist(m.DigestHist{Kind: spec.Kind, Since: d.From, Until: d.To, Content: digest.String()})
+	if err != nil {
+		p.Publish(jobError("DigestEvent", "SaveDigestHist", "", err))
+	}
+}
+
+func (e Event) digestFunds(d *m.DigestReport, ivsmLi []m.InvestSummary, ex float64, base time.Time, now time.Time) error {
+
+	keySet, stable, volatile := fundValues(ivsmLi, ex)
+
+	prev, err := e.stg.RetrieveFundValues(base.Format(digestDayFormat))
+	if err != nil {
+		return fmt.Errorf("RetrieveFundValues 시 오류 발생. %w", err)
+	}
+	prevMap := make(map[uint]float64)
+	for _, v := range prev {
+		prevMap[v.FundID] = v.Value
+	}
+
+	today := make([]m.FundValue, 0, len(keySet))
+	for k := range keySet {
+		fd := m.FundDigest{FundID: k, Value: stable[k] + volatile[k]}
+		if p, ok := prevMap[k]; ok {
+			fd.Previous = &p
+		}
+		d.Funds = append(d.Funds, fd)
+		today = append(today, m.FundValue{FundID: k, Date: datatypes.Date(now), Value: fd.Value})
+	}
+	sort.Slice(d.Funds, func(i, j int) bool { return d.Funds[i].FundID < d.Funds[j].FundID })
+
+	err = e.stg.SaveFundValues(today)
+	if err != nil {
+		return fmt.Errorf("SaveFundValues 시 오류 발생. %w", err)
+	}
+	return nil
+}
+
+// 보유 자산의 기준일 종가 대비 최근 종가 등락. 변동률 절대값 큰 순서
+func (e Event) digestMovers(d *m.DigestReport, ivsmLi []m.InvestSummary, base time.Time, now time.Time) error {
+
+	held := make(map[uint]m.Asset)
+	for _, ivsm := range ivsmLi {
+		if ivsm.Count > 0 && ivsm.Asset.Category != m.Won && ivsm.Asset.Category != m.Dollar {
+			held[ivsm.AssetID] = ivsm.Asset
+		}
+	}
+
+	moves := make([]m.AssetMove, 0, len(held))
+	for id, a := range held {
+		prices, err := e.stg.RetrieveDailyPrices(id, base.AddDate(0, 0, -digestLookback).Format(digestDayFormat), now.Format(digestDayFormat))
+		if err != nil {
+			return fmt.Errorf("RetrieveDailyPrices 시 오류 발생. ID : %d. %w", id, err)
+		}
+
+		dates := make([]time.Time, len(prices))
+		closes := make([]float64, len(prices))
+		for i, p := range prices {
+			dates[i], closes[i] = time.Time(p.Date), p.Close
+		}
+		from, to, ok := baseAndLast(dates, closes, base)
+		if !ok || from == 0 {
+			continue
+		}
+		if a.ID == 0 {
+			a.ID = id
+		}
+		moves = append(moves, m.AssetMove{Asset: a, From: from, To: to})
+	}
+
+	sort.Slice(moves, func(i, j int) bool {
+		ri, rj := math.Abs(moves[i].Rate()), math.Abs(moves[j].Rate())
+		if ri == rj {
+			return moves[i].Asset.ID < moves[j].Asset.ID
+		}
+		return ri > rj
+	})
+	d.Movers = moves[:min(len(moves), m.DigestMovers)]
+
+	return nil
+}
+
+// 변동 자산 비율이 허용 범위 밖이거나 경계에 근접한 자금
+func (e Event) digestRebalances(d *m.DigestReport, ivsmLi []m.InvestSummary, ex float64) error {
+
+	market, err := e.stg.RetrieveMarketStatus("")
+	if err != nil {
+		return fmt.Errorf("RetrieveMarketStatus 시 오류 발생. %w", err)
+	}
+	level := m.MarketLevel(market.Status)
+	lo, hi := level.MinVolatileAssetRate(), level.MaxVolatileAssetRate()
+
+	keySet, stable, volatile := fundValues(ivsmLi, ex)
+	for k := range keySet {
+		total := stable[k] + volatile[k]
+		if total == 0 {
+			continue
+		}
+		r := volatile[k] / total
+
+		var state m.RebalanceState
+		switch {
+		case r > hi:
+			state = m.RebalanceOver
+		case r < lo:
+			state = m.RebalanceUnder
+		case r > hi-m.RebalanceMargin || r < lo+m.RebalanceMargin:
+			state = m.RebalanceNear
+		default:
+			continue
+		}
+		d.Rebalances = append(d.Rebalances, m.Rebalance{FundID: k, Rate: r, Min: lo, Max: hi, State: state})
+	}
+	sort.Slice(d.Rebalances, func(i, j int) bool { return d.Rebalances[i].FundID < d.Rebalances[j].FundID })
+
+	return nil
+}
+
+func (e Event) digestMarket(d *m.DigestReport, base time.Time, now time.Time) error {
+
+	market, err := e.stg.RetrieveMarketStatus("")
+	if err != nil {
+		return fmt.Errorf("RetrieveMarketStatus 시 오류 발생. %w", err)
+	}
+	level := m.MarketLevel(market.Status)
+	d.Market = &level
+
+	indicators, err := e.stg.RetrieveIndicators()
+	if err != nil {
+		return fmt.Errorf("RetrieveIndicators 시 오류 발생. %w", err)
+	}
+
+	for _, indicator := range indicators {
+		if !indicator.Active {
+			continue
+		}
+		values, err := e.stg.RetrieveIndicatorValues(indicator.ID, base.AddDate(0, 0, -digestLookback).Format(digestDayFormat), now.Format(digestDayFormat))
+		if err != nil {
+			return fmt.Errorf("%s RetrieveIndicatorValues 시 오류 발생. %w", indicator.Name, err)
+		}
+
+		dates := make([]time.Time, len(values))
+		vs := make([]float64, len(values))
+		for i, v := range values {
+			dates[i], vs[i] = time.Time(v.Date), v.Value
+		}
+		from, to, ok := baseAndLast(dates, vs, base)
+		if !ok {
+			continue
+		}
+		d.Indicators = append(d.Indicators, m.IndicatorMove{Indicator: indicator, From: from, To: to})
+	}
+
+	return nil
+}
+
+func (e Event) digestAlerts(d *m.DigestReport) error {
+
+	logs, err := e.stg.RetrieveAlertLogs(d.From, d.To)
+	if err != nil {
+		return fmt.Errorf("RetrieveAlertLogs 시 오류 발생. %w", err)
+	}
+	d.Alerts = logs
+	return nil
+}
+
+/*
+기준일 값과 최근 값. 일자 오름차순 입력
+  - 기준일 이하 가장 최근 값. 미존재 시 첫 값
+  - 값이 2개 미만이면 비교 불가
+*/
+func baseAndLast(dates []time.Time, values []float64, base time.Time) (from float64, to float64, ok bool) {
+
+	if len(values) < 2 {
+		return 0, 0, false
+	}
+
+	from = values[0]
+	day := base.Format(digestDayFormat)
+	for i, dt := range dates[:len(dates)-1] {
+		if dt.Format(digestDayFormat) > day {
+			break
+		}
+		from = values[i]
+	}
+	return from, values[len(values)-1], true
+}
+
+/*
+writeDigest
+Dir에 digest-{kind}-{일시}.{md|html} 저장 후 경로 반환
+  - 기본 언어의 Digest.file 템플릿으로 작성. md는 일반 텍스트 템플릿
+*/
+func writeDigest(d *m.DigestReport) (string, error) {
+
+	tpl := render.Default()
+	v, ext := render.Plain, "md"
+	if d.Spec.Format == "html" {
+		v, ext = render.HTML, "html"
+	}
+
+	content, err := tpl.Render(tpl.Locale(), v, "Digest.file", bus.Digest{Report: d})
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(d.Spec.Dir, 0o755)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(d.Spec.Dir, fmt.Sprintf("digest-%s-%s.%s", string(d.Spec.Kind), d.To.Format("20060102-1504"), ext))
+	return path, os.WriteFile(path, []byte(content), 0o644)
+}
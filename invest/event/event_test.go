@@ -1,9 +1,19 @@
 package event
 
 import (
+	"errors"
+	"invest/bus"
 	m "invest/model"
+	"invest/render"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
 )
 
 func TestEventbuySellMsg(t *testing.T) {
@@ -21,14 +31,14 @@ func TestEventbuySellMsg(t *testing.T) {
 			{ID: 1, Name: "종목1", Category: m.DomesticStock, Code: "code", Currency: "WON", SellPrice: 480, BuyPrice: 450},
 		}
 		scrp.pp = 400
-		msg, err := evt.buySellMsg(1, pm)
+		alert, err := evt.buySellAlert(1, pm)
 		if err != nil {
 			t.Error(err)
 		}
-		if strings.Contains(msg, "BUY") {
-			t.Log(msg)
+		if alert != nil && !alert.Sell {
+			t.Log(alert)
 		} else {
-			t.Error(msg)
+			t.Error(alert)
 		}
 	})
 
@@ -37,14 +47,14 @@ func TestEventbuySellMsg(t *testing.T) {
 			{ID: 1, Name: "종목1", Category: m.DomesticStock, Code: "code", Currency: "WON", SellPrice: 480, BuyPrice: 450},
 		}
 		scrp.pp = 490
-		msg, err := evt.buySellMsg(1, pm)
+		alert, err := evt.buySellAlert(1, pm)
 		if err != nil {
 			t.Error(err)
 		}
-		if strings.Contains(msg, "SELL") {
-			t.Log(msg)
+		if alert != nil && alert.Sell {
+			t.Log(alert)
 		} else {
-			t.Error(msg)
+			t.Error(alert)
 		}
 	})
 
@@ -53,14 +63,14 @@ func TestEventbuySellMsg(t *testing.T) {
 			{ID: 1, Name: "종목1", Category: m.DomesticStock, Code: "code", Currency: "WON", SellPrice: 480, BuyPrice: 450},
 		}
 		scrp.pp = 470
-		msg, err := evt.buySellMsg(1, pm)
+		alert, err := evt.buySellAlert(1, pm)
 		if err != nil {
 			t.Error(err)
 		}
-		if msg == "" {
-			t.Log(msg)
+		if alert == nil {
+			t.Log(alert)
 		} else {
-			t.Error(msg)
+			t.Error(alert)
 		}
 	})
 }
@@ -109,16 +119,892 @@ func TestEventportfolioMsg(t *testing.T) {
 			5: 1000,
 		}
 
-		msg, err := evt.portfolioMsg(ivsmLi, pm)
+		imbalances, err := evt.portfolioImbalances(ivsmLi, pm)
 
 		if err != nil {
 			t.Error(err)
 		}
-		if msg != "" {
-			t.Log("\n", msg)
+		if len(imbalances) != 0 {
+			t.Log("\n", imbalances[0])
 		} else {
-			t.Error(msg)
+			t.Error(imbalances)
 		}
 	})
 
 }
+
+func TestEventPriceTick(t *testing.T) {
+
+	stg := &StorageMock{}
+	scrp := &RtPollerMock{}
+	dp := &DailyPollerMock{}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	stg.assets = []m.Asset{
+		{ID: 11, Name: "종목11", Category: m.DomesticStock, Code: "005930", Currency: "WON", Top: 500, Bottom: 300, SellPrice: 480, BuyPrice: 450},
+		{ID: 12, Name: "비트코인", Category: m.DomesticCoin, Code: "KRW-BTC", Currency: "WON"},
+	}
+	st := &StreamerMock{subs: map[string]m.Category{"000660": m.DomesticStock}}
+
+	ch := make(PublisherMock, 10)
+
+	t.Run("구독 목록 동기화", func(t *testing.T) {
+		evt.StreamSyncEvent(st, ch)
+		assert.Equal(t, []string{"005930"}, st.Codes())
+		assert.Empty(t, ch)
+	})
+
+	t.Run("체결가 수신 시 매수 알림", func(t *testing.T) {
+		evt.PriceTick(ch, "005930", 440, time.Now())
+		ev := <-ch
+		assert.Equal(t, bus.KindPriceAlert, ev.Kind())
+		assert.Equal(t, bus.Warning, ev.Severity())
+		msg := ev.String()
+		assert.Contains(t, msg, "매수")
+
+		pp, ok := evt.prices.get(11, priceTTL)
+		assert.True(t, ok)
+		assert.Equal(t, 440.0, pp)
+	})
+
+	t.Run("수신 가격 재사용", func(t *testing.T) {
+		scrp.pp = 470
+		pm := make(map[uint]float64)
+		_, err := evt.buySellAlert(11, pm)
+		assert.NoError(t, err)
+		assert.Equal(t, 440.0, pm[11])
+	})
+
+	t.Run("미등록 종목 무시", func(t *testing.T) {
+		evt.PriceTick(ch, "000000", 100, time.Now())
+		assert.Empty(t, ch)
+	})
+}
+
+func TestEventPriceBackfill(t *testing.T) {
+
+	stg := &StorageMock{}
+	scrp := &RtPollerMock{}
+	dp := &DailyPollerMock{}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	stg.assets = []m.Asset{
+		{ID: 1, Name: "현금", Category: m.Won},
+		{ID: 2, Name: "삼성전자", Category: m.DomesticStock, Code: "005930"},
+		{ID: 3, Name: "비트코인", Category: m.DomesticCoin, Code: "KRW-BTC"},
+	}
+	dp.candles = []m.DailyPrice{
+		{Open: 100, High: 110, Low: 90, Close: 105},
+		{Open: 105, High: 120, Low: 100, Close: 115},
+	}
+
+	from := time.Now().AddDate(0, -1, 0)
+
+	t.Run("전체 자산 백필", func(t *testing.T) {
+		cnt, err := evt.PriceBackfill(0, from, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 4, cnt) // 현금 제외
+	})
+
+	t.Run("단일 자산 백필", func(t *testing.T) {
+		cnt, err := evt.PriceBackfill(2, from, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, cnt)
+	})
+
+	t.Run("미지원 종목 제외, 실패 자산 이후 계속 진행", func(t *testing.T) {
+		assets := stg.assets
+		stg.assets = []m.Asset{
+			{ID: 4, Name: "단기채", Category: m.ShortTermBond, Code: "153130"},
+			{ID: 2, Name: "삼성전자", Category: m.DomesticStock, Code: "005930"},
+			{ID: 3, Name: "비트코인", Category: m.DomesticCoin, Code: "KRW-BTC"},
+		}
+		dp.candleErr = map[string]error{"005930": errors.New("API 오류")}
+		defer func() { stg.assets, dp.candleErr = assets, nil }()
+
+		cnt, err := evt.PriceBackfill(0, from, time.Now())
+		assert.Equal(t, 2, cnt) // 비트코인
+		assert.ErrorContains(t, err, "ID: 2. API 오류")
+		assert.NotContains(t, err.Error(), "ID: 4")
+	})
+}
+
+func TestEventAverages(t *testing.T) {
+
+	t.Run("단순 이동평균", func(t *testing.T) {
+		assert.Equal(t, []float64{2, 3, 4}, sma([]float64{1, 2, 3, 4, 5}, 3))
+		assert.Nil(t, sma([]float64{1, 2}, 3))
+	})
+
+	t.Run("지수 이동평균 - 최초 값은 단순 이동평균", func(t *testing.T) {
+		rtn := ema([]float64{1, 2, 3, 4, 5}, 3) // a = 0.5
+		assert.Equal(t, []float64{2, 3, 4}, rtn)
+	})
+
+	stg := &StorageMock{hist: make(map[m.AverageSpec][]m.AverageHist)}
+	scrp := &RtPollerMock{}
+	dp := &DailyPollerMock{}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	stg.assets = []m.Asset{
+		{ID: 2, Name: "삼성전자", Category: m.DomesticStock, Code: "005930"},
+	}
+	for i := range 25 {
+		stg.prices = append(stg.prices, m.DailyPrice{
+			AssetID: 2,
+			Date:    datatypes.Date(time.Now().AddDate(0, 0, i-26)),
+			Close:   float64(100 + i),
+		})
+	}
+
+	t.Run("설정된 이동평균 재산출", func(t *testing.T) {
+		stg.averages = []m.AssetAverage{
+			{AssetID: 2, Kind: m.SMA, Period: 20, Reference: true},
+			{AssetID: 2, Kind: m.EMA, Period: 200},
+		}
+		err := evt.RecomputeAverages(2)
+		assert.NoError(t, err)
+
+		hist := stg.hist[m.AverageSpec{Kind: m.SMA, Period: 20}]
+		assert.Len(t, hist, 6)
+		assert.Equal(t, 109.5, hist[0].Value)
+		assert.Equal(t, stg.prices[19].Date, hist[0].Date)
+		assert.Empty(t, stg.hist[m.AverageSpec{Kind: m.EMA, Period: 200}]) // 일봉 부족
+
+		ap, err := evt.referenceAverage(2)
+		assert.NoError(t, err)
+		assert.Equal(t, 114.5, ap)
+	})
+
+	t.Run("기준 이동평균 산출 이력 미존재", func(t *testing.T) {
+		stg.averages = []m.AssetAverage{{AssetID: 2, Kind: m.EMA, Period: 200, Reference: true}}
+		defer func() { stg.averages = nil }()
+
+		ap, err := evt.referenceAverage(2)
+		assert.NoError(t, err)
+		assert.Equal(t, 0.0, ap)
+	})
+
+	t.Run("일봉 조회 실패", func(t *testing.T) {
+		dp.err = errors.New("API 오류")
+		defer func() { dp.err = nil }()
+
+		err := evt.RecomputeAverages(2)
+		assert.Error(t, err)
+	})
+
+	t.Run("자산 추가 후 이동평균 산출 실패해도 저장 성공", func(t *testing.T) {
+		dp.err = errors.New("API 오류")
+		defer func() { dp.err = nil }()
+
+		id, err := evt.AddAsset(m.Asset{Name: "삼성전자", Category: m.DomesticStock, Top: 1, Bottom: 1}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(2), id)
+	})
+
+	t.Run("일봉 조회 미지원 종목은 산출 생략", func(t *testing.T) {
+		stg.assets = append(stg.assets, m.Asset{ID: 3, Name: "단기채권", Category: m.ShortTermBond})
+		dp.err = errors.New("일봉 조회 미지원 종목")
+		defer func() { dp.err = nil }()
+
+		assert.NoError(t, evt.RecomputeAverages(3))
+	})
+}
+
+func TestEventPriorities(t *testing.T) {
+
+	rising := make([]float64, 30)
+	for i := range rising {
+		rising[i] = float64(100 + i)
+	}
+
+	t.Run("점수 산정 방식", func(t *testing.T) {
+		s, ok := deviationScore(scoreInput{pp: 1000, ap: 900, hp: 1100})
+		assert.True(t, ok)
+		assert.InDelta(t, 0.02, s, 1e-9)
+
+		s, ok = rsiScore(scoreInput{pp: 130, closes: rising})
+		assert.True(t, ok)
+		assert.Equal(t, 1.0, s) // 상승만 있으면 RSI 100
+
+		s, ok = zScore(scoreInput{pp: 119.5, closes: rising})
+		assert.True(t, ok)
+		assert.Equal(t, 0.0, s) // 20일 평균과 동일
+
+		s, ok = rangeScore(scoreInput{pp: 100, closes: rising})
+		assert.True(t, ok)
+		assert.Equal(t, -1.0, s) // 52주 최저
+	})
+
+	t.Run("데이터 부족 시 산정 제외", func(t *testing.T) {
+		_, ok := deviationScore(scoreInput{pp: 0, ap: 900, hp: 1100})
+		assert.False(t, ok)
+		_, ok = rsiScore(scoreInput{pp: 100, closes: rising[:5]})
+		assert.False(t, ok)
+
+		score, breakdown := weightedScore(map[m.ScoreStrategy]float64{m.Deviation: 1, m.RSI: 1}, scoreInput{pp: 1000, ap: 900, hp: 1100})
+		assert.Len(t, breakdown, 1)
+		assert.InDelta(t, 0.02, score, 1e-9)
+	})
+
+	stg := &StorageMock{}
+	scrp := &RtPollerMock{pp: 1000}
+	dp := &DailyPollerMock{}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	stg.ivsm = []m.InvestSummary{
+		{FundID: 1, AssetID: 1, Asset: m.Asset{ID: 1, Name: "현금", Category: m.Won}, Count: 1, Sum: 1000},
+		{FundID: 1, AssetID: 2, Asset: m.Asset{ID: 2, Name: "삼성전자", Category: m.DomesticStock, Top: 1100}, Count: 10},
+		{FundID: 1, AssetID: 3, Asset: m.Asset{ID: 3, Name: "금", Category: m.Gold, Top: 900}, Count: 10},
+		{FundID: 1, AssetID: 4, Asset: m.Asset{ID: 4, Name: "비트코인", Category: m.DomesticCoin, Top: 1000}, Count: 10},
+		{FundID: 2, AssetID: 5, Asset: m.Asset{ID: 5, Name: "하이닉스", Category: m.DomesticStock, Top: 1000}, Count: 10},
+	}
+	stg.ma = map[uint]float64{2: 1000, 3: 900, 4: 900}
+
+	t.Run("매도 우선순위", func(t *testing.T) {
+		ps, err := evt.FundPriorities(1, false)
+		assert.NoError(t, err)
+		assert.Len(t, ps, 3)
+		assert.Equal(t, uint(4), ps[0].Asset.ID) // 변동 자산 중 점수 최고
+		assert.Equal(t, uint(2), ps[1].Asset.ID)
+		assert.Equal(t, uint(3), ps[2].Asset.ID) // 안전 자산은 후순위
+		assert.Contains(t, ps[0].Breakdown, m.Deviation)
+	})
+
+	t.Run("가중치 설정", func(t *testing.T) {
+		stg.weights = []m.ScoreWeight{{FundID: 1, Strategy: m.Deviation, Weight: 1}, {FundID: 1, Strategy: m.Range52W, Weight: 1}}
+		stg.prices = make([]m.DailyPrice, len(rising))
+		for i, c := range rising {
+			stg.prices[i].Close = c * 10
+		}
+		defer func() { stg.weights, stg.prices = nil, nil }()
+
+		ps, err := evt.FundPriorities(1, false)
+		assert.NoError(t, err)
+		assert.Len(t, ps[0].Breakdown, 2)
+		assert.Equal(t, -1.0, ps[0].Breakdown[m.Range52W])
+	})
+
+	t.Run("현재가 조회 실패 시 제외", func(t *testing.T) {
+		scrp.err = errors.New("API 오류")
+		defer func() { scrp.err = nil }()
+
+		ps, err := evt.FundPriorities(1, false)
+		assert.NoError(t, err)
+		assert.Empty(t, ps)
+	})
+
+	t.Run("이동평균 조회 실패 시 현재가 기준 산정", func(t *testing.T) {
+		stg.avgErr = map[uint]error{2: errors.New("DB 오류")}
+		defer func() { stg.avgErr = nil }()
+
+		ps, err := evt.FundPriorities(1, false)
+		assert.NoError(t, err)
+		assert.Len(t, ps, 3)
+		for _, p := range ps {
+			if p.Asset.ID == 2 {
+				assert.Equal(t, 1000.0, p.Average)
+			}
+		}
+	})
+}
+
+func TestEventMonitor(t *testing.T) {
+
+	checks := make([]m.MonitorHist, 0)
+	stg := &StorageMock{checks: &checks}
+	scrp := &RtPollerMock{}
+	dp := &DailyPollerMock{}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	c := make(PublisherMock, 10)
+
+	t.Run("기대 값 일치 시 알림 없음", func(t *testing.T) {
+		stg.monitors = []m.Monitor{
+			{ID: 1, Name: "재개발", Url: "http://test", Kind: m.CssMonitor, Selector: "div", Spec: "0 */15 * * * *", Expected: "예정지구 지정", Active: true},
+		}
+		scrp.value = "예정지구 지정"
+
+		evt.MonitorEvent(c)
+		assert.Empty(t, c)
+		assert.Len(t, checks, 1)
+		assert.False(t, checks[0].Changed)
+	})
+
+	t.Run("기대 값과 다르면 변경 내역과 함께 알림", func(t *testing.T) {
+		stg.monitors[0].LastValue = "예정지구 지정"
+		stg.monitors[0].LastCheckedAt = time.Now().Add(-time.Hour)
+		scrp.value = "구역 지정"
+
+		evt.MonitorEvent(c)
+		msg := (<-c).String()
+		assert.Contains(t, msg, "기대 값과 다름")
+		assert.Contains(t, msg, "- 예정지구 지정\n+ 구역 지정")
+		assert.True(t, checks[len(checks)-1].Changed)
+	})
+
+	t.Run("확인 주기 미도래", func(t *testing.T) {
+		stg.monitors[0].LastCheckedAt = time.Now()
+		cnt := len(checks)
+
+		evt.MonitorEvent(c)
+		assert.Len(t, checks, cnt)
+	})
+
+	t.Run("기대 값 미지정 시 변경마다 알림", func(t *testing.T) {
+		stg.monitors = []m.Monitor{
+			{ID: 2, Name: "공지", Url: "http://test", Kind: m.JsonMonitor, Selector: "data.title", Spec: "0 * * * * *", Active: true},
+		}
+		scrp.value = "공지1"
+
+		_, msg, err := evt.CheckMonitor(2)
+		assert.NoError(t, err)
+		assert.Empty(t, msg) // 최초 확인은 기준 값 저장
+
+		stg.monitors[0].LastValue = "공지1"
+		stg.monitors[0].LastCheckedAt = time.Now()
+		scrp.value = "공지2"
+
+		hist, msg, err := evt.CheckMonitor(2)
+		assert.NoError(t, err)
+		assert.True(t, hist.Changed)
+		assert.Contains(t, msg, "변동 사항 존재")
+	})
+
+	t.Run("조회 실패 시 오류 이력 저장", func(t *testing.T) {
+		scrp.err = errors.New("status code error: 404")
+		defer func() { scrp.err = nil }()
+
+		_, _, err := evt.CheckMonitor(2)
+		assert.Error(t, err)
+		assert.NotEmpty(t, checks[len(checks)-1].Error)
+	})
+
+	t.Run("줄 단위 변경 내역", func(t *testing.T) {
+		assert.Equal(t, "- B\n+ C\n+ D", diffLines("A\nB", "A\nC\nD"))
+	})
+}
+
+func TestEventCli(t *testing.T) {
+
+	saved := make([]m.CliIndex, 0)
+	stg := &StorageMock{cliSaved: &saved}
+	scrp := &RtPollerMock{}
+	dp := &DailyPollerMock{}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	c := make(PublisherMock, 10)
+
+	month := func(y int, mon time.Month) datatypes.Date {
+		return datatypes.Date(time.Date(y, mon, 1, 0, 0, 0, 0, time.Local))
+	}
+
+	dp.cli = []m.CliIndex{
+		{CreatedAt: month(2024, time.November), Index: 100.45},
+		{CreatedAt: month(2024, time.December), Index: 100.52},
+	}
+
+	t.Run("신규 월 발표 시 저장 후 알림", func(t *testing.T) {
+		stg.cli = &m.CliIndex{CreatedAt: month(2024, time.November), Index: 100.45}
+
+		evt.CliEvent(c)
+		msg := (<-c).String()
+		assert.Contains(t, msg, "100.52 (2024-12)")
+		assert.Contains(t, msg, "전월 : 100.45")
+		assert.Len(t, saved, 2)
+	})
+
+	t.Run("이미 저장된 월이면 알림 없음", func(t *testing.T) {
+		stg.cli = &m.CliIndex{CreatedAt: month(2024, time.December), Index: 100.52}
+
+		evt.CliEvent(c)
+		assert.Empty(t, c)
+	})
+
+	t.Run("조회 실패 시 에러 알림", func(t *testing.T) {
+		dp.err = errors.New("timeout")
+		defer func() { dp.err = nil }()
+
+		evt.CliEvent(c)
+		ev := <-c
+		assert.Equal(t, bus.KindJobError, ev.Kind())
+		assert.Equal(t, bus.Error, ev.Severity())
+		assert.Contains(t, ev.String(), "[CliEvent]")
+	})
+}
+
+func TestEventIndex(t *testing.T) {
+
+	values := make([]m.IndicatorValue, 0)
+	stg := &StorageMock{values: &values, attempts: map[uint]time.Time{}}
+	scrp := &RtPollerMock{}
+	dp := &DailyPollerMock{indicators: map[m.IndicatorProvider]float64{
+		m.FearGreedProvider:      23,
+		m.KisOverseasIdxProvider: 17556.03,
+	}}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	c := make(PublisherMock, 10)
+
+	fgi := m.Indicator{ID: 1, Name: "공포 탐욕 지수", Provider: m.FearGreedProvider, Spec: "0 3 9 * * *", Active: true}
+	nasdaq := m.Indicator{ID: 2, Name: "Nasdaq", Provider: m.KisOverseasIdxProvider, Code: "COMP", Spec: "0 3 9 * * *", Active: true}
+	prev := 20.0
+
+	t.Run("주기 도래한 지표만 수집 후 전일 대비 알림", func(t *testing.T) {
+		nasdaq.LastCollectedAt = time.Now()
+		stg.indicators = []m.Indicator{fgi, nasdaq}
+		stg.changes = []m.IndicatorChange{
+			{Indicator: fgi, Value: 23, Previous: &prev},
+			{Indicator: nasdaq, Value: 17556.03},
+		}
+
+		evt.IndexEvent(c)
+		msg := (<-c).String()
+		assert.Len(t, values, 1)
+		assert.Equal(t, 23.0, values[0].Value)
+		assert.Contains(t, msg, "금일 공포 탐욕 지수 : 23.00")
+		assert.Contains(t, msg, "전일 : 20.00, +15.00%")
+		assert.NotContains(t, msg, "Nasdaq")
+	})
+
+	t.Run("비활성 지표는 수집하지 않음", func(t *testing.T) {
+		values = values[:0]
+		fgi.Active = false
+		stg.indicators = []m.Indicator{fgi}
+
+		evt.IndexEvent(c)
+		assert.Empty(t, c)
+		assert.Empty(t, values)
+	})
+
+	t.Run("조회 실패 시 에러 알림", func(t *testing.T) {
+		dp.err = errors.New("timeout")
+		defer func() { dp.err = nil }()
+		stg.indicators = []m.Indicator{nasdaq}
+		stg.indicators[0].LastCollectedAt = time.Time{}
+
+		evt.IndexEvent(c)
+		msg := (<-c).String()
+		assert.Equal(t, "[IndexEvent] IndicatorValue 시, 에러 발생. Nasdaq. timeout", msg)
+		assert.False(t, stg.attempts[2].IsZero()) // 다음 주기까지 재시도 없음
+
+		stg.indicators[0].LastCollectedAt = stg.attempts[2]
+		evt.IndexEvent(c)
+		assert.Empty(t, c)
+	})
+}
+
+func TestEventIndicatorAlert(t *testing.T) {
+
+	series := func(vs ...float64) []m.IndicatorValue {
+		rtn := make([]m.IndicatorValue, len(vs))
+		for i, v := range vs {
+			rtn[i] = m.IndicatorValue{IndicatorID: 1, Value: v}
+		}
+		return rtn
+	}
+
+	t.Run("알림 조건 판단", func(t *testing.T) {
+		t.Run("임계값 이하", func(t *testing.T) {
+			hit, ok := alertHit(m.IndicatorAlert{Kind: m.BelowAlert, Threshold: 20}, series(25, 18))
+			assert.True(t, ok)
+			assert.True(t, hit)
+		})
+
+		t.Run("1일 3% 하락", func(t *testing.T) {
+			a := m.IndicatorAlert{Kind: m.ChangeAlert, Threshold: -3, Days: 1}
+			hit, _ := alertHit(a, series(100, 96.5))
+			assert.True(t, hit)
+			hit, _ = alertHit(a, series(100, 98))
+			assert.False(t, hit)
+		})
+
+		t.Run("변동률 비교 값 부족", func(t *testing.T) {
+			_, ok := alertHit(m.IndicatorAlert{Kind: m.ChangeAlert, Threshold: -3, Days: 5}, series(100, 96))
+			assert.False(t, ok)
+		})
+
+		t.Run("이동평균 상향 돌파", func(t *testing.T) {
+			a := m.IndicatorAlert{Kind: m.CrossAboveAlert, Period: 3}
+			hit, ok := alertHit(a, series(10, 10, 10, 9, 12))
+			assert.True(t, ok)
+			assert.True(t, hit)
+			hit, _ = alertHit(a, series(10, 10, 10, 11, 12))
+			assert.False(t, hit)
+		})
+
+		t.Run("이동평균 하향 돌파", func(t *testing.T) {
+			hit, _ := alertHit(m.IndicatorAlert{Kind: m.CrossBelowAlert, Period: 3}, series(10, 10, 10, 11, 8))
+			assert.True(t, hit)
+		})
+	})
+
+	values := make([]m.IndicatorValue, 0)
+	stg := &StorageMock{values: &values, triggered: map[uint]bool{}}
+	scrp := &RtPollerMock{}
+	dp := &DailyPollerMock{indicators: map[m.IndicatorProvider]float64{m.FearGreedProvider: 18}}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	c := make(PublisherMock, 10)
+
+	fgi := m.Indicator{ID: 1, Name: "공포 탐욕 지수", Provider: m.FearGreedProvider, Spec: "0 3 9 * * *", Active: true}
+	stg.indicators = []m.Indicator{fgi}
+	stg.changes = []m.IndicatorChange{{Indicator: fgi, Value: 18}}
+	stg.history = series(25)
+
+	t.Run("지표 갱신 후 조건 충족 시 알림", func(t *testing.T) {
+		stg.alerts = []m.IndicatorAlert{
+			{ID: 1, IndicatorID: 1, Kind: m.BelowAlert, Threshold: 20, Active: true},
+			{ID: 2, IndicatorID: 1, Kind: m.AboveAlert, Threshold: 80, Active: true},
+		}
+
+		evt.IndexEvent(c)
+		alert := (<-c).String()
+		assert.Contains(t, alert, "[지표 알림] 공포 탐욕 지수 : 18.00")
+		assert.Contains(t, alert, "20.00 이하")
+		assert.True(t, stg.triggered[1])
+		assert.NotContains(t, stg.triggered, uint(2))
+		<-c // 일일 지표 메시지
+	})
+
+	t.Run("이미 충족 상태면 재알림 없음", func(t *testing.T) {
+		values = values[:0]
+		stg.alerts = []m.IndicatorAlert{
+			{ID: 1, IndicatorID: 1, Kind: m.BelowAlert, Threshold: 20, Active: true, Triggered: true},
+		}
+
+		evt.IndexEvent(c)
+		msg := (<-c).String()
+		assert.NotContains(t, msg, "[지표 알림]")
+		assert.Empty(t, c)
+	})
+
+	t.Run("조건 해제 시 상태만 초기화", func(t *testing.T) {
+		values = values[:0]
+		dp.indicators[m.FearGreedProvider] = 30
+
+		evt.IndexEvent(c)
+		<-c
+		assert.False(t, stg.triggered[1])
+		assert.Empty(t, c)
+	})
+}
+
+func TestEventRecordInvest(t *testing.T) {
+
+	invests := make([]m.Invest, 0)
+	stg := &StorageMock{invests: &invests, summaries: map[[2]uint]float64{}}
+	scrp := &RtPollerMock{}
+	dp := &DailyPollerMock{}
+
+	evt := NewEvent(stg, scrp, dp)
+
+	stg.assets = []m.Asset{
+		{ID: 1, Name: "WON", Category: m.Won, Currency: "WON"},
+		{ID: 2, Name: "USD", Category: m.Dollar, Currency: "USD"},
+		{ID: 3, Name: "TIGER 미국S&P500", Category: m.DomesticETF, Code: "360750", Currency: "WON"},
+		{ID: 4, Name: "MSFT", Category: m.ForeignStock, Code: "NAS-MSFT", Currency: "USD"},
+	}
+
+	t.Run("원화 자산 매수 시 원화 차감", func(t *testing.T) {
+		err := evt.RecordInvest(1, 3, 12500, 10)
+		assert.NoError(t, err)
+		assert.Len(t, invests, 1)
+		assert.Equal(t, 10.0, stg.summaries[[2]uint{1, 3}])
+		assert.Equal(t, -125000.0, stg.summaries[[2]uint{1, 1}])
+	})
+
+	t.Run("달러 자산 매도 시 달러 증가", func(t *testing.T) {
+		err := evt.RecordInvest(1, 4, 400, -2)
+		assert.NoError(t, err)
+		assert.Equal(t, -2.0, stg.summaries[[2]uint{1, 4}])
+		assert.Equal(t, 800.0, stg.summaries[[2]uint{1, 2}])
+	})
+
+	t.Run("달러 충전 시 환율 적용 원화 차감", func(t *testing.T) {
+		err := evt.RecordInvest(2, 2, 1, 100)
+		assert.NoError(t, err)
+		assert.Equal(t, -130000.0, stg.summaries[[2]uint{2, 1}])
+	})
+
+	t.Run("현금 자산 미존재 시 저장하지 않음", func(t *testing.T) {
+		invests = invests[:0]
+		stg.assets = stg.assets[2:]
+		err := evt.RecordInvest(1, 3, 12500, 10)
+		assert.ErrorContains(t, err, "현금 자산 미존재")
+		assert.Empty(t, invests)
+	})
+}
+
+func TestEventChart(t *testing.T) {
+
+	stg := &StorageMock{
+		market: &m.Market{Status: 4}, // BULL. 변동 자산 50~60%
+		assets: []m.Asset{
+			{ID: 1, Name: "현금", Category: m.Won, Currency: "WON"},
+			{ID: 2, Name: "MSFT", Category: m.ForeignStock, Currency: "USD"},
+			{ID: 3, Name: "TIGER 미국S&P500", Category: m.DomesticETF, Currency: "WON", Code: "360750"},
+		},
+	}
+	stg.ivsm = []m.InvestSummary{
+		{FundID: 1, AssetID: 1, Asset: stg.assets[0], Sum: 4000000},
+		{FundID: 1, AssetID: 2, Asset: stg.assets[1], Sum: 1000}, // 1,300,000원
+		{FundID: 1, AssetID: 3, Asset: stg.assets[2], Sum: 4700000},
+		{FundID: 2, AssetID: 1, Asset: stg.assets[0], Sum: 1000000},
+		{FundID: 2, AssetID: 3, Asset: stg.assets[2], Sum: 0},
+	}
+	dp := &DailyPollerMock{}
+	evt := NewEvent(stg, &RtPollerMock{}, dp)
+
+	t.Run("자금 자산 비중", func(t *testing.T) {
+		ct, err := evt.FundChart(1)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, ct.Image)
+		assert.Equal(t, "자금 1 자산 비중 (총 10000000원)\n🟥 TIGER 미국S&P500 47.0% (4700000원)\n🟦 현금 40.0% (4000000원)\n🟩 MSFT 13.0% (1300000원)", ct.Caption)
+
+		_, err = evt.FundChart(3)
+		assert.ErrorContains(t, err, "자금 3 평가 금액 있는 자산 미존재")
+	})
+
+	t.Run("변동 자산 비율", func(t *testing.T) {
+		ct, err := evt.RatioChart()
+		assert.NoError(t, err)
+		assert.NotEmpty(t, ct.Image)
+		assert.Equal(t, "현재 시장 단계 : BULL\n변동 자산 비율 허용 범위 : 50%~60%\n자금 1 : 60.0% (범위 내)\n자금 2 : 0.0% (부족)", ct.Caption)
+	})
+
+	t.Run("기본 언어 설명", func(t *testing.T) {
+		en, err := render.New("", render.English)
+		assert.NoError(t, err)
+		ko := render.Default()
+		render.SetDefault(en)
+		defer render.SetDefault(ko)
+
+		ct, err := evt.RatioChart()
+		assert.NoError(t, err)
+		assert.Equal(t, "Market level : BULL\nAllowed volatile asset rate : 50%~60%\nFund 1 : 60.0% (within range)\nFund 2 : 0.0% (under)", ct.Caption)
+
+		ct, err = evt.FundChart(1)
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(ct.Caption, "Fund 1 asset weights (total 10000000 KRW)\n🟥 TIGER 미국S&P500 47.0% (4700000 KRW)"))
+	})
+
+	t.Run("자금별 자산 배분", func(t *testing.T) {
+		allocs, err := evt.Allocations()
+		assert.NoError(t, err)
+		assert.Len(t, allocs, 2)
+
+		a := allocs[0]
+		assert.Equal(t, uint(1), a.Fund.ID)
+		assert.Equal(t, m.BULL, a.Market)
+		assert.Equal(t, float64(10000000), a.Total())
+		assert.InDelta(t, 0.6, a.Ratio(), 1e-9)
+		assert.Equal(t, m.RebalanceWithin, a.State())
+		assert.Equal(t, "TIGER 미국S&P500", a.Assets[0].Asset.Name)
+		assert.Equal(t, float64(1300000), a.Assets[2].Value) // 달러 자산 원화 환산
+		assert.InDelta(t, 0.13, a.Weight(a.Assets[2]), 1e-9)
+
+		assert.Equal(t, m.RebalanceUnder, allocs[1].State())
+	})
+
+	t.Run("시세 및 이동평균", func(t *testing.T) {
+		day := time.Date(2024, 9, 2, 0, 0, 0, 0, time.Local)
+		stg.prices = make([]m.DailyPrice, 30)
+		for i := range stg.prices {
+			stg.prices[i] = m.DailyPrice{AssetID: 3, Date: datatypes.Date(day.AddDate(0, 0, i)), Close: float64(100 + i)}
+		}
+		stg.averages = []m.AssetAverage{{Kind: m.SMA, Period: 20}, {Kind: m.EMA, Period: 200}}
+		defer func() { stg.prices, stg.averages = nil, nil }()
+
+		ct, err := evt.PriceChart(3, 10)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, ct.Image)
+		assert.Equal(t, "TIGER 미국S&P500 최근 10일 종가 및 이동평균\n🟥 종가 129.00\n🟦 SMA20 119.50\nEMA200 일봉 부족으로 미표시", ct.Caption)
+
+		_, err = evt.PriceChart(1, 10)
+		assert.ErrorContains(t, err, "현금 자산은 시세 차트 미지원")
+	})
+
+	t.Run("일봉 미존재", func(t *testing.T) {
+		_, err := evt.PriceChart(3, 0)
+		assert.ErrorContains(t, err, "일봉 부족")
+	})
+
+	t.Run("일별 차트 전송", func(t *testing.T) {
+		c := make(PublisherMock, 10)
+		charts := make([]m.Chart, 0)
+		evt.ChartEvent(c, func(ct m.Chart) { charts = append(charts, ct) })
+
+		assert.Len(t, charts, 3)
+		assert.Contains(t, charts[0].Caption, "현재 시장 단계")
+		assert.Contains(t, charts[1].Caption, "자금 1 자산 비중")
+		assert.Contains(t, charts[2].Caption, "자금 2 자산 비중")
+		assert.Empty(t, c)
+	})
+
+	t.Run("환율 조회 실패", func(t *testing.T) {
+		dp.err = errors.New("timeout")
+		defer func() { dp.err = nil }()
+
+		c := make(PublisherMock, 10)
+		evt.ChartEvent(c, func(ct m.Chart) {})
+		assert.Contains(t, (<-c).String(), "[ChartEvent] RatioChart 시, 에러 발생")
+	})
+}
+
+func TestEventDigest(t *testing.T) {
+
+	now := time.Date(2024, 9, 10, 18, 0, 0, 0, time.Local)
+	day := func(d int) datatypes.Date { return datatypes.Date(time.Date(2024, 9, d, 0, 0, 0, 0, time.Local)) }
+
+	stg := &StorageMock{
+		market: &m.Market{Status: 4}, // BULL. 변동 자산 50~60%
+		assets: []m.Asset{
+			{ID: 1, Name: "현금", Category: m.Won, Currency: "WON"},
+			{ID: 2, Name: "MSFT", Category: m.ForeignStock, Currency: "USD"},
+			{ID: 3, Name: "TIGER 미국S&P500", Category: m.DomesticETF, Currency: "WON", Code: "360750"},
+		},
+		prices: []m.DailyPrice{
+			{Date: day(5), Close: 100},
+			{Date: day(6), Close: 104},
+			{Date: day(9), Close: 110},
+			{Date: day(10), Close: 121},
+		},
+		indicators: []m.Indicator{
+			{ID: 1, Name: "VIX", Active: true},
+			{ID: 2, Name: "DXY", Active: false},
+		},
+		history: []m.IndicatorValue{
+			{IndicatorID: 1, Date: day(6), Value: 20},
+			{IndicatorID: 1, Date: day(9), Value: 16},
+			{IndicatorID: 1, Date: day(10), Value: 20},
+			{IndicatorID: 2, Date: day(10), Value: 101},
+		},
+		fundValues: []m.FundValue{{FundID: 1, Date: day(9), Value: 9500000}},
+		fundSaved:  &[]m.FundValue{},
+		alertLogs: &[]m.AlertLog{
+			{Source: m.PriceSource, Message: "[AssetEvent] 이전 알림", CreatedAt: now.AddDate(0, 0, -2)},
+			{Source: m.MonitorSource, Message: "[MonitorEvent] 공시 변경\n상세 내역", CreatedAt: now.Add(-8 * time.Hour)},
+		},
+		digest:  &m.DigestHist{Kind: m.DailyDigest, Until: now.AddDate(0, 0, -1)},
+		digests: &[]m.DigestHist{},
+	}
+	stg.ivsm = []m.InvestSummary{
+		{FundID: 1, AssetID: 1, Asset: stg.assets[0], Count: 1, Sum: 4000000},
+		{FundID: 1, AssetID: 2, Asset: stg.assets[1], Count: 3, Sum: 1000}, // 1,300,000원
+		{FundID: 1, AssetID: 3, Asset: stg.assets[2], Count: 400, Sum: 4700000},
+		{FundID: 2, AssetID: 1, Asset: stg.assets[0], Count: 1, Sum: 1000000},
+	}
+	evt := NewEvent(stg, &RtPollerMock{}, &DailyPollerMock{})
+
+	t.Run("전체 항목", func(t *testing.T) {
+		*stg.fundSaved = nil
+
+		d := evt.BuildDigest(m.DigestSpec{Kind: m.DailyDigest}, now)
+		assert.Empty(t, d.Errors)
+		assert.Equal(t, `[DigestEvent] 일간 리포트 (2024-09-09 18:00 ~ 2024-09-10 18:00)
+
+■ 자금 평가 금액
+자금 1 : 10,000,000원 (+500,000원, +5.26%)
+자금 2 : 1,000,000원 (비교 기준 없음)
+합계 : 11,000,000원
+
+■ 보유 자산 등락 (상위 5)
+MSFT : 110 → 121 (+10.00%)
+TIGER 미국S&P500 : 110 → 121 (+10.00%)
+
+■ 리밸런싱
+자금 1 변동 자산 비율 60.0% (허용 50%~60%, 근접)
+자금 2 변동 자산 비율 0.0% (허용 50%~60%, 부족)
+
+■ 시장
+시장 단계 : BULL
+VIX : 16 → 20 (+25.00%)
+
+■ 알림 (1건)
+09-10 10:00 [monitor] [MonitorEvent] 공시 변경`, bus.Digest{Report: d}.String())
+
+		assert.Equal(t, []m.FundValue{
+			{FundID: 1, Date: datatypes.Date(now), Value: 10000000},
+			{FundID: 2, Date: datatypes.Date(now), Value: 1000000},
+		}, sortFundValues(*stg.fundSaved))
+	})
+
+	t.Run("선택 항목만 작성", func(t *testing.T) {
+		d := evt.BuildDigest(m.DigestSpec{Kind: m.WeeklyDigest, Sections: []m.DigestSection{m.MarketSection}}, now)
+		assert.Nil(t, d.Funds)
+		assert.Equal(t, "[DigestEvent] 주간 리포트 (2024-09-09 18:00 ~ 2024-09-10 18:00)\n\n■ 시장\n시장 단계 : BULL\nVIX : 20 → 20 (+0.00%)", bus.Digest{Report: d}.String())
+	})
+
+	t.Run("조회 실패 시 실패 항목 표기", func(t *testing.T) {
+		stg.err = errors.New("db down")
+		defer func() { stg.err = nil }()
+
+		d := evt.BuildDigest(m.DigestSpec{Kind: m.DailyDigest, Sections: []m.DigestSection{m.MarketSection, m.AlertSection}}, now)
+		assert.Equal(t, []m.DigestError{
+			{Step: "RetrieveLatestDigestHist", Message: "db down"},
+			{Section: m.MarketSection, Message: "RetrieveMarketStatus 시 오류 발생. db down"},
+			{Section: m.AlertSection, Message: "RetrieveAlertLogs 시 오류 발생. db down"},
+		}, d.Errors)
+		assert.True(t, strings.HasSuffix(bus.Digest{Report: d}.String(), "■ 조회 실패\nRetrieveLatestDigestHist 조회 실패. db down\nmarket 항목 작성 실패. RetrieveMarketStatus 시 오류 발생. db down\nalerts 항목 작성 실패. RetrieveAlertLogs 시 오류 발생. db down"))
+	})
+
+	t.Run("리포트 전송, 파일 및 이력 저장", func(t *testing.T) {
+		dir := t.TempDir()
+		c := make(PublisherMock, 10)
+
+		evt.DigestEvent(c, m.DigestSpec{Kind: m.DailyDigest, Sections: []m.DigestSection{m.MarketSection}, Charts: true, Dir: dir, Format: "html"})
+
+		digest := (<-c).(bus.Digest)
+		assert.Contains(t, digest.String(), "■ 시장")
+		assert.Empty(t, c)
+		assert.Len(t, digest.Charts, 3) // 리포트와 같은 이벤트로 발행
+
+		assert.Len(t, *stg.digests, 1)
+		assert.Equal(t, m.DailyDigest, (*stg.digests)[0].Kind)
+		assert.Contains(t, (*stg.digests)[0].Content, "시장 단계 : BULL")
+
+		files, _ := os.ReadDir(dir)
+		assert.Len(t, files, 1)
+		assert.True(t, strings.HasPrefix(files[0].Name(), "digest-daily-"))
+		assert.True(t, strings.HasSuffix(files[0].Name(), ".html"))
+		b, _ := os.ReadFile(filepath.Join(dir, files[0].Name()))
+		assert.Contains(t, string(b), "<li>시장 단계 : BULL</li>")
+	})
+}
+
+func sortFundValues(values []m.FundValue) []m.FundValue {
+	sort.Slice(values, func(i, j int) bool { return values[i].FundID < values[j].FundID })
+	return values
+}
+
+func TestEventLogAlert(t *testing.T) {
+
+	logs := make([]m.AlertLog, 0)
+	stg := &StorageMock{alertLogs: &logs}
+	evt := NewEvent(stg, &RtPollerMock{}, &DailyPollerMock{})
+
+	t.Run("알림 이벤트 발송 기록", func(t *testing.T) {
+		evt.LogAlert(bus.PriceAlert{Asset: m.Asset{ID: 1, Name: "종목1"}, Bound: 450, Price: 440})
+		evt.LogAlert(bus.MonitorChange{Monitor: m.Monitor{Name: "공지"}, Changed: true})
+
+		assert.Len(t, logs, 2)
+		assert.Equal(t, m.PriceSource, logs[0].Source)
+		assert.Equal(t, "매수 종목1. ID : 1. 하한 : 450.00. 현재가 : 440.00", logs[0].Message)
+		assert.Equal(t, m.MonitorSource, logs[1].Source)
+	})
+
+	t.Run("기록 대상 외 이벤트 무시", func(t *testing.T) {
+		logs = logs[:0]
+		evt.LogAlert(bus.JobError{Job: "AssetEvent", Message: "timeout"})
+		assert.Empty(t, logs)
+	})
+}
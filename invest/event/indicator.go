@@ -0,0 +1,93 @@
+package event
+
+import (
+	"invest/bus"
+	m "invest/model"
+	"log"
+	"time"
+
+	"github.com/robfig/cron"
+	"gorm.io/datatypes"
+)
+
+/*
+IndexEvent
+활성화된 시장 지표 중 수집 주기가 도래한 지표 수집 후 당일 값 저장. 저장 직후 지표별 알림 규칙 판단
+수집된 지표들의 전일 대비 등락을 한 메시지로 전송
+  - 수집 실패 시에도 시도 시각 저장. 다음 수집 주기까지 재시도(에러 알림) 없음
+*/
+func (e Event) IndexEvent(p Publisher) {
+
+	indicators, err := e.stg.RetrieveIndicators()
+	if err != nil {
+		p.Publish(jobError("IndexEvent", "RetrieveIndicators", "", err))
+		return
+	}
+
+	now := time.Now()
+	collected := make(map[uint]bool)
+	for _, indicator := range indicators {
+		if !indicator.Active {
+			continue
+		}
+
+		sched, err := cron.Parse(indicator.Spec)
+		if err != nil {
+			p.Publish(jobError("IndexEvent", "cron.Parse", indicator.Name, err))
+			continue
+		}
+		if !indicator.LastCollectedAt.IsZero() && sched.Next(indicator.LastCollectedAt).After(now) {
+			continue
+		}
+
+		value, err := e.dp.IndicatorValue(indicator.Provider, indicator.Code)
+		if err != nil {
+			p.Publish(jobError("IndexEvent", "IndicatorValue", indicator.Name, err))
+			e.saveIndicatorAttempt(indicator, now)
+			continue
+		}
+
+		err = e.stg.SaveIndicatorValue(m.IndicatorValue{
+			IndicatorID: indicator.ID,
+			Date:        datatypes.Date(now),
+			Value:       value,
+		}, now)
+		if err != nil {
+			p.Publish(jobError("IndexEvent", "SaveIndicatorValue", indicator.Name, err))
+			e.saveIndicatorAttempt(indicator, now)
+			continue
+		}
+		collected[indicator.ID] = true
+
+		e.checkIndicatorAlerts(p, indicator, now)
+	}
+
+	if len(collected) == 0 {
+		return
+	}
+
+	changes, _, err := e.stg.RetrieveMarketIndicator("")
+	if err != nil {
+		p.Publish(jobError("IndexEvent", "RetrieveMarketIndicator", "", err))
+		return
+	}
+
+	filtered := make([]m.IndicatorChange, 0, len(collected))
+	for _, change := range changes {
+		if collected[change.Indicator.ID] {
+			filtered = append(filtered, change)
+		}
+	}
+
+	if len(filtered) > 0 {
+		p.Publish(bus.IndicatorUpdate{Changes: filtered})
+	}
+}
+
+// 실패한 수집 시도 시각 저장. 매 실행마다 같은 지표 재시도 방지
+func (e Event) saveIndicatorAttempt(indicator m.Indicator, at time.Time) {
+	err := e.stg.SaveIndicatorAttempt(indicator.ID, at)
+	if err != nil {
+		log.Printf("[IndexEvent] %s SaveIndicatorAttempt 시, 에러 발생. %s", indicator.Name, err)
+	}
+}
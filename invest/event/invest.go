@@ -0,0 +1,125 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	m "invest/model"
+	"log"
+)
+
+/*
+RecordInvest
+투자 이력 저장 후 자금의 종목 요약 갱신. 거래 대금만큼 현금(원화/달러) 요약 차감. count 음수면 매도
+  - 달러 충전 : 환율 적용한 원화 차감
+  - 원화 자산 : 원화 차감
+  - 달러 자산 : 달러 차감
+*/
+func (e Event) RecordInvest(fundId uint, assetId uint, price float64, count float64) error {
+
+	asset, err := e.stg.RetrieveAsset(assetId)
+	if err != nil {
+		return fmt.Errorf("RetrieveAsset 시 오류 발생. %w", err)
+	}
+
+	cash, err := e.cashAssetIds()
+	if err != nil {
+		return err
+	}
+
+	// 투자 이력 저장
+	err = e.stg.SaveInvest(fundId, assetId, price, count)
+	if err != nil {
+		return fmt.Errorf("SaveInvest 시 오류 발생. %w", err)
+	}
+
+	// 투자 요약 갱신
+	err = e.stg.UpdateInvestSummary(fundId, assetId, count, price)
+	if err != nil {
+		return fmt.Errorf("UpdateInvestSummary 시 오류 발생. %w", err)
+	}
+
+	// 현금/달러 갱신
+	if assetId == cash[m.USD] { // 달러 충전
+		exRate := e.dp.ExchageRate()
+		err = e.stg.UpdateInvestSummary(fundId, cash[m.KRW], -1*exRate*count, 1)
+	} else if asset.Currency == m.KRW.String() && asset.Name != m.KRW.String() { // 원화 자산
+		err = e.stg.UpdateInvestSummary(fundId, cash[m.KRW], -1*price*count, 1)
+	} else if asset.Currency == m.USD.String() && asset.Name != m.USD.String() { // 달러 자산
+		err = e.stg.UpdateInvestSummary(fundId, cash[m.USD], -1*price*count, 1)
+	}
+	if err != nil {
+		return fmt.Errorf("현금 UpdateInvestSummary 시 오류 발생. %w", err)
+	}
+
+	return nil
+}
+
+/*
+AddAsset
+자산 정보 저장. 최고/최저가 미입력 시 시세 API 값 사용, 매수 기준 미입력 시 최저가 사용
+이동평균 설정 저장 후 과거 일봉으로 이동평균 산출
+  - 이동평균 산출 실패는 로그만 남김. 자산 저장 성공 시 ID 반환
+*/
+func (e Event) AddAsset(asset m.Asset, averages []m.AssetAverage) (uint, error) {
+
+	if asset.Top == 0 || asset.Bottom == 0 {
+		top, bottom, err := e.rt.TopBottomPrice(asset.Category, asset.Code)
+		if err != nil {
+			return 0, fmt.Errorf("TopBottomPrice 시 오류 발생. %w", err)
+		}
+		if asset.Top == 0 {
+			asset.Top = top
+		}
+		if asset.Bottom == 0 {
+			asset.Bottom = bottom
+		}
+	}
+
+	if asset.BuyPrice == 0 {
+		asset.BuyPrice = asset.Bottom
+	}
+
+	id, err := e.stg.SaveAssetInfo(asset.Name, asset.Category, asset.Code, asset.Currency, asset.Top, asset.Bottom, asset.SellPrice, asset.BuyPrice)
+	if err != nil {
+		return 0, fmt.Errorf("SaveAssetInfo 시 오류 발생. %w", err)
+	}
+
+	if len(averages) != 0 {
+		err = e.stg.SaveAssetAverages(id, averages)
+		if err != nil {
+			return id, fmt.Errorf("SaveAssetAverages 시 오류 발생. %w", err)
+		}
+	}
+
+	// 자산은 저장된 상태. 산출 실패는 일일 이동평균 갱신(AverageUpdateEvent)에서 재시도
+	err = e.RecomputeAverages(id)
+	if err != nil {
+		log.Printf("[AddAsset] RecomputeAverages 시 오류 발생. ID: %d. %s", id, err)
+	}
+
+	return id, nil
+}
+
+// 원화/달러 자산 ID
+func (e Event) cashAssetIds() (map[m.Currency]uint, error) {
+
+	list, err := e.stg.RetrieveAssetList()
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveAssetList 시 오류 발생. %w", err)
+	}
+
+	cash := make(map[m.Currency]uint)
+	for _, a := range list {
+		if a.Name == m.KRW.String() {
+			cash[m.KRW] = a.ID
+		} else if a.Name == m.USD.String() {
+			cash[m.USD] = a.ID
+		}
+	}
+
+	if cash[m.KRW] == 0 {
+		return nil, errors.New("현금 자산 미존재")
+	}
+
+	return cash, nil
+}
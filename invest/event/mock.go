@@ -1,16 +1,42 @@
 package event
 
 import (
+	"errors"
+	"invest/bus"
 	m "invest/model"
 	md "invest/model"
+	"time"
 )
 
 type StorageMock struct {
-	ma     map[uint]float64
-	market *md.Market
-	assets []md.Asset
-	ivsm   []md.InvestSummary
-	err    error
+	ma         map[uint]float64
+	market     *md.Market
+	assets     []md.Asset
+	ivsm       []md.InvestSummary
+	prices     []md.DailyPrice
+	averages   []md.AssetAverage
+	hist       map[md.AverageSpec][]md.AverageHist
+	avgErr     map[uint]error // 자산별 이동평균 조회 오류
+	weights    []md.ScoreWeight
+	monitors   []md.Monitor
+	checks     *[]md.MonitorHist
+	cli        *md.CliIndex
+	cliSaved   *[]md.CliIndex
+	indicators []md.Indicator
+	changes    []md.IndicatorChange
+	values     *[]md.IndicatorValue
+	attempts   map[uint]time.Time
+	history    []md.IndicatorValue
+	alerts     []md.IndicatorAlert
+	triggered  map[uint]bool
+	invests    *[]md.Invest
+	summaries  map[[2]uint]float64
+	fundValues []md.FundValue
+	fundSaved  *[]md.FundValue
+	alertLogs  *[]md.AlertLog
+	digest     *md.DigestHist
+	digests    *[]md.DigestHist
+	err        error
 }
 
 func (m StorageMock) RetrieveMarketStatus(date string) (*md.Market, error) {
@@ -40,6 +66,41 @@ func (m StorageMock) RetrieveAsset(id uint) (*md.Asset, error) {
 	return &md.Asset{}, nil
 }
 
+func (m StorageMock) SaveAssetInfo(name string, category md.Category, code string, currency string, top float64, bottom float64, selPrice float64, buyPrice float64) (uint, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return uint(len(m.assets) + 1), nil
+}
+
+func (m StorageMock) SaveAssetAverages(assetId uint, averages []md.AssetAverage) error {
+	if m.err != nil {
+		return m.err
+	}
+	return nil
+}
+
+func (m StorageMock) SaveInvest(fundId uint, assetId uint, price float64, count float64) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.invests != nil {
+		*m.invests = append(*m.invests, md.Invest{FundID: fundId, AssetID: assetId, Price: price, Count: count})
+	}
+	return nil
+}
+
+// 자금/자산별 수량 변동 누적
+func (m StorageMock) UpdateInvestSummary(fundId uint, assetId uint, change float64, price float64) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.summaries != nil {
+		m.summaries[[2]uint{fundId, assetId}] += change
+	}
+	return nil
+}
+
 func (m StorageMock) UpdateAssetInfo(id uint, name string, category md.Category, code string, currency string, top float64, bottom float64, selPrice float64, buyPrice float64) error {
 	return nil
 }
@@ -59,26 +120,184 @@ func (m StorageMock) UpdateInvestSummarySum(fundId uint, assetId uint, sum float
 }
 
 // todo. 목 수정
-func (m StorageMock) RetrieveMarketIndicator(date string) (*md.DailyIndex, *md.CliIndex, error) {
-	return nil, nil, nil
+func (m StorageMock) RetrieveMarketIndicator(date string) ([]md.IndicatorChange, *md.CliIndex, error) {
+	if m.err != nil {
+		return nil, nil, m.err
+	}
+	return m.changes, m.cli, nil
+}
+
+func (m StorageMock) RetrieveIndicators() ([]md.Indicator, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.indicators, nil
+}
+
+func (m StorageMock) SaveIndicatorValue(value md.IndicatorValue, collectedAt time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.values != nil {
+		*m.values = append(*m.values, value)
+	}
+	return nil
+}
+
+func (m StorageMock) SaveIndicatorAttempt(id uint, at time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.attempts != nil {
+		m.attempts[id] = at
+	}
+	return nil
+}
+
+// 이전 값(history) 뒤에 저장된 값(values)을 이어 최근 limit 건 반환
+func (m StorageMock) RetrieveLatestIndicatorValues(id uint, limit int) ([]md.IndicatorValue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	all := append([]md.IndicatorValue{}, m.history...)
+	if m.values != nil {
+		all = append(all, *m.values...)
+	}
+	if len(all) > limit {
+		all = all[len(all)-limit:]
+	}
+	return all, nil
+}
+
+func (m StorageMock) RetrieveIndicatorAlerts(indicatorId uint) ([]md.IndicatorAlert, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	rtn := make([]md.IndicatorAlert, 0)
+	for _, a := range m.alerts {
+		if a.IndicatorID == indicatorId {
+			rtn = append(rtn, a)
+		}
+	}
+	return rtn, nil
+}
+
+func (m StorageMock) UpdateIndicatorAlertState(id uint, triggered bool, at time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.triggered != nil {
+		m.triggered[id] = triggered
+	}
+	return nil
 }
 
-func (m StorageMock) SaveDailyMarketIndicator(fearGreedIndex uint, nasdaq float64) error {
+func (m StorageMock) SaveCliIndex(series []md.CliIndex) error {
 	if m.err != nil {
 		return m.err
 	}
+	if m.cliSaved != nil {
+		*m.cliSaved = append(*m.cliSaved, series...)
+	}
 	return nil
 }
 
-func (m StorageMock) RetreiveLatestEma(assetId uint) (float64, error) {
-	return m.ma[assetId], nil
+func (m StorageMock) RetrieveAssetAverages(assetId uint) ([]md.AssetAverage, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.averages, nil
+}
+
+func (m StorageMock) SaveAverageHist(assetId uint, spec md.AverageSpec, hist []md.AverageHist) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.hist != nil {
+		m.hist[spec] = hist
+	}
+	return nil
+}
+
+func (m StorageMock) RetrieveLatestAverage(assetId uint, spec md.AverageSpec) (*md.AverageHist, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if err := m.avgErr[assetId]; err != nil {
+		return nil, err
+	}
+	if m.hist != nil {
+		hist := m.hist[spec]
+		if len(hist) == 0 {
+			return nil, nil // 산출 이력 미존재
+		}
+		return &hist[len(hist)-1], nil
+	}
+	return &md.AverageHist{AssetID: assetId, Kind: spec.Kind, Period: spec.Period, Value: m.ma[assetId]}, nil
+}
+
+func (m StorageMock) UpdateDailyPrice(assetId uint, price float64) error {
+	if m.err != nil {
+		return m.err
+	}
+	return nil
+}
+
+func (m StorageMock) SaveDailyPrices(prices []md.DailyPrice) error {
+	if m.err != nil {
+		return m.err
+	}
+	return nil
+}
+
+func (m StorageMock) RetrieveScoreWeights(fundId uint) ([]md.ScoreWeight, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.weights, nil
+}
+
+func (m StorageMock) RetrieveMonitors() ([]md.Monitor, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.monitors, nil
+}
+
+func (m StorageMock) RetrieveMonitor(id uint) (*md.Monitor, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, mo := range m.monitors {
+		if mo.ID == id {
+			return &mo, nil
+		}
+	}
+	return nil, errors.New("record not found")
 }
-func (m StorageMock) SaveEmaHist(assetId uint, price float64) error {
+
+func (m StorageMock) SaveMonitorCheck(hist md.MonitorHist) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.checks != nil {
+		*m.checks = append(*m.checks, hist)
+	}
 	return nil
 }
 
+func (m StorageMock) RetrieveDailyPrices(assetId uint, from string, to string) ([]md.DailyPrice, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.prices, nil
+}
+
 func (m StorageMock) RetrieveTotalAssets() ([]md.Asset, error) {
-	return nil, nil
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.assets, nil
 }
 
 func (m StorageMock) RetreiveFundSummaryByAssetId(id uint) ([]m.InvestSummary, error) {
@@ -86,9 +305,9 @@ func (m StorageMock) RetreiveFundSummaryByAssetId(id uint) ([]m.InvestSummary, e
 }
 
 type RtPollerMock struct {
-	pp     float64
-	estate string
-	err    error
+	pp    float64
+	value string
+	err   error
 }
 
 func (m RtPollerMock) PresentPrice(category md.Category, code string) (float64, error) {
@@ -98,15 +317,26 @@ func (m RtPollerMock) PresentPrice(category md.Category, code string) (float64,
 	return m.pp, nil
 }
 
-func (m RtPollerMock) RealEstateStatus() (string, error) {
+func (m RtPollerMock) TopBottomPrice(category md.Category, code string) (float64, float64, error) {
+	if m.err != nil {
+		return 0, 0, m.err
+	}
+	return m.pp * 1.2, m.pp * 0.8, nil
+}
+
+func (m RtPollerMock) Extract(url string, kind md.MonitorKind, selector string) (string, error) {
 	if m.err != nil {
 		return "", m.err
 	}
-	return m.estate, nil
+	return m.value, nil
 }
 
 type DailyPollerMock struct {
-	err error
+	candles    []md.DailyPrice
+	cli        []md.CliIndex
+	indicators map[md.IndicatorProvider]float64
+	candleErr  map[string]error // 종목 코드별 일봉 조회 오류
+	err        error
 }
 
 func (m DailyPollerMock) ExchageRate() float64 {
@@ -117,16 +347,131 @@ func (m DailyPollerMock) ExchageRate() float64 {
 	return 1300
 }
 
-func (m DailyPollerMock) FearGreedIndex() (uint, error) {
-	return 0, nil
+func (m DailyPollerMock) IndicatorValue(provider md.IndicatorProvider, code string) (float64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	return m.indicators[provider], nil
+}
+func (m DailyPollerMock) CliSeries() ([]md.CliIndex, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.cli, nil
+}
+
+func (m DailyPollerMock) DailyCandles(category md.Category, code string, from time.Time, to time.Time) ([]md.DailyPrice, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if err := m.candleErr[code]; err != nil {
+		return nil, err
+	}
+	return m.candles, nil
+}
+
+type StreamerMock struct {
+	subs map[string]md.Category
+}
+
+func (m *StreamerMock) Supports(category md.Category) bool {
+	return category == md.DomesticStock
+}
+
+func (m *StreamerMock) Subscribe(category md.Category, code string) error {
+	m.subs[code] = category
+	return nil
+}
+
+func (m *StreamerMock) Unsubscribe(code string) error {
+	delete(m.subs, code)
+	return nil
+}
+
+func (m *StreamerMock) Codes() []string {
+	codes := make([]string, 0, len(m.subs))
+	for code := range m.subs {
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+func (m StorageMock) RetrieveIndicatorValues(id uint, from string, to string) ([]md.IndicatorValue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	rtn := make([]md.IndicatorValue, 0)
+	for _, v := range m.history {
+		d := time.Time(v.Date).Format("2006-01-02")
+		if v.IndicatorID == id && (from == "" || d >= from) && (to == "" || d <= to) {
+			rtn = append(rtn, v)
+		}
+	}
+	return rtn, nil
 }
-func (m DailyPollerMock) Nasdaq() (float64, error) {
-	return 0, nil
+
+func (m StorageMock) SaveFundValues(values []md.FundValue) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.fundSaved != nil {
+		*m.fundSaved = append(*m.fundSaved, values...)
+	}
+	return nil
 }
-func (m DailyPollerMock) CliIdx() (float64, error) {
-	return 0, nil
+
+func (m StorageMock) RetrieveFundValues(date string) ([]md.FundValue, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.fundValues, nil
 }
 
-func (m DailyPollerMock) ClosingPrice(category md.Category, code string) (float64, error) {
-	return 0, nil
+func (m StorageMock) SaveAlertLog(log md.AlertLog) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.alertLogs != nil {
+		*m.alertLogs = append(*m.alertLogs, log)
+	}
+	return nil
+}
+
+func (m StorageMock) RetrieveAlertLogs(from time.Time, to time.Time) ([]md.AlertLog, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	rtn := make([]md.AlertLog, 0)
+	if m.alertLogs != nil {
+		for _, l := range *m.alertLogs {
+			if l.CreatedAt.After(from) && !l.CreatedAt.After(to) {
+				rtn = append(rtn, l)
+			}
+		}
+	}
+	return rtn, nil
+}
+
+func (m StorageMock) SaveDigestHist(hist md.DigestHist) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.digests != nil {
+		*m.digests = append(*m.digests, hist)
+	}
+	return nil
+}
+
+func (m StorageMock) RetrieveLatestDigestHist(kind md.DigestKind) (*md.DigestHist, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.digest, nil
+}
+
+// 발행 이벤트 전달
+type PublisherMock chan bus.Event
+
+func (p PublisherMock) Publish(e bus.Event) {
+	p <- e
 }
@@ -0,0 +1,166 @@
+package event
+
+import (
+	"fmt"
+	"invest/bus"
+	m "invest/model"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+/*
+MonitorEvent
+활성화된 감시 대상 중 확인 주기가 도래한 대상 확인. 변동 시 알림 전송
+*/
+func (e Event) MonitorEvent(p Publisher) {
+
+	monitors, err := e.stg.RetrieveMonitors()
+	if err != nil {
+		p.Publish(jobError("MonitorEvent", "RetrieveMonitors", "", err))
+		return
+	}
+
+	now := time.Now()
+	for i := range monitors {
+		mo := &monitors[i]
+		if !mo.Active {
+			continue
+		}
+
+		sched, err := cron.Parse(mo.Spec)
+		if err != nil {
+			p.Publish(jobError("MonitorEvent", "cron.Parse", mo.Name, err))
+			continue
+		}
+		if !mo.LastCheckedAt.IsZero() && sched.Next(mo.LastCheckedAt).After(now) {
+			continue
+		}
+
+		_, change, err := e.checkMonitor(mo, now)
+		if err != nil {
+			p.Publish(jobError("MonitorEvent", "checkMonitor", "", err))
+			continue
+		}
+		if change != nil {
+			p.Publish(*change)
+		}
+	}
+}
+
+/*
+CheckMonitor
+감시 대상 즉시 확인. 확인 이력과 알림 메시지(변동 없으면 빈 값) 반환
+*/
+func (e Event) CheckMonitor(id uint) (*m.MonitorHist, string, error) {
+
+	mo, err := e.stg.RetrieveMonitor(id)
+	if err != nil {
+		return nil, "", fmt.Errorf("RetrieveMonitor 시, 에러 발생. %w", err)
+	}
+
+	hist, change, err := e.checkMonitor(mo, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+	if change == nil {
+		return &hist, "", nil
+	}
+	return &hist, change.String(), nil
+}
+
+/*
+값 추출 후 직전 값과 비교하여 이력 저장
+  - 기대 값 지정 : 값이 바뀌었을 때(최초 확인 포함) 기대 값과 다르면 알림, 기대 값으로 돌아오면 알림
+  - 기대 값 미지정 : 값이 바뀔 때마다 알림. 최초 확인은 기준 값으로만 저장
+  - 알림 대상이 아니면 변동 이벤트는 nil
+*/
+func (e Event) checkMonitor(mo *m.Monitor, now time.Time) (m.MonitorHist, *bus.MonitorChange, error) {
+
+	hist := m.MonitorHist{
+		MonitorID: mo.ID,
+		CheckedAt: now,
+	}
+
+	value, err := e.rt.Extract(mo.Url, mo.Kind, mo.Selector)
+	if err != nil {
+		hist.Error = err.Error()
+		serr := e.stg.SaveMonitorCheck(hist)
+		if serr != nil {
+			log.Printf("[MonitorEvent] SaveMonitorCheck 시, 에러 발생. %s", serr)
+		}
+		return hist, nil, fmt.Errorf("%s 조회 시 오류 발생. %w", mo.Name, err)
+	}
+
+	first := mo.LastCheckedAt.IsZero() && mo.LastValue == ""
+	hist.Value = value
+	hist.Changed = !first && value != mo.LastValue
+
+	err = e.stg.SaveMonitorCheck(hist)
+	if err != nil {
+		return hist, nil, fmt.Errorf("%s SaveMonitorCheck 시, 에러 발생. %w", mo.Name, err)
+	}
+
+	notify := (mo.Expected != "" && (first || hist.Changed) && value != mo.Expected) || hist.Changed
+	if !notify {
+		log.Printf("[MonitorEvent] %s 변동 사항 없음. 현재 값: %s", mo.Name, value)
+		return hist, nil, nil
+	}
+
+	change := &bus.MonitorChange{
+		Monitor:  *mo,
+		Value:    value,
+		Previous: mo.LastValue,
+		Changed:  hist.Changed,
+	}
+	if hist.Changed {
+		change.Diff = diffLines(mo.LastValue, value)
+	}
+
+	mo.LastValue = value
+	mo.LastCheckedAt = now
+	return hist, change, nil
+}
+
+/*
+줄 단위 변경 내역. 공통 줄은 생략하고 삭제 줄은 -, 추가 줄은 + 표기
+*/
+func diffLines(before string, after string) string {
+
+	a, b := strings.Split(before, "\n"), strings.Split(after, "\n")
+
+	// lcs[i][j] : a[i:], b[j:]의 최장 공통 부분 수열 길이
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			sb.WriteString("- " + a[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+ " + b[j] + "\n")
+			j++
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
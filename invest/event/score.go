@@ -0,0 +1,358 @@
+package event
+
+import (
+	"cmp"
+	"fmt"
+	m "invest/model"
+	"log"
+	"math"
+	"slices"
+	"time"
+)
+
+// 점수 산정 방식별 필요 일봉 수
+const (
+	rsiPeriod    = 14
+	zScorePeriod = 20
+	rangeMinDays = 20
+)
+
+type scoreInput struct {
+	pp     float64   // 현재가
+	ap     float64   // 기준 이동평균
+	hp     float64   // 최고가
+	closes []float64 // 최근 52주 종가. 날짜 오름차순
+}
+
+// 산정 불가 시(데이터 부족 등) false 반환. 점수는 대략 -1 ~ 1 범위
+type scorer func(in scoreInput) (float64, bool)
+
+var scorers = map[m.ScoreStrategy]scorer{
+	m.Deviation: deviationScore,
+	m.RSI:       rsiScore,
+	m.ZScore:    zScore,
+	m.Range52W:  rangeScore,
+}
+
+/*
+[판단]
+현재가가 고점 및 이평가보다 낮을수록 저평가(조정) => 매수
+현재가가 고점 및 이평가보다 높을수록 고평가 => 매도
+
+[수식]
+pp - 현재가
+ap - 평균가
+hp - 최고가
+
+매도매수지수 = 0.6*((pp-ap)/pp) + 0.4*((pp-hp))/pp)
+매도매수지수 클수록 매도 우선 순위
+매도매수지수 낮을수록 매수 우선순위
+*/
+func deviationScore(in scoreInput) (float64, bool) {
+
+	if in.pp <= 0 {
+		return 0, false
+	}
+
+	ap, hp := in.ap, in.hp
+	if ap <= 0 { // 이동평균 산출 전이면 현재가 기준
+		ap = in.pp
+	}
+	if hp <= 0 {
+		hp = in.pp
+	}
+
+	return 0.6*((in.pp-ap)/in.pp) + 0.4*((in.pp-hp)/in.pp), true
+}
+
+/*
+RSI(14). 과매수(100)일수록 매도, 과매도(0)일수록 매수 우선
+점수 = (RSI - 50) / 50
+*/
+func rsiScore(in scoreInput) (float64, bool) {
+
+	closes := in.closes
+	if in.pp > 0 {
+		closes = append(slices.Clip(closes), in.pp)
+	}
+	if len(closes) < rsiPeriod+1 {
+		return 0, false
+	}
+
+	// Wilder 평활. 최초 평균은 14일 단순 평균
+	var gain, loss float64
+	for i := 1; i < len(closes); i++ {
+		d := closes[i] - closes[i-1]
+		var g, l float64
+		if d > 0 {
+			g = d
+		} else {
+			l = -d
+		}
+
+		if i <= rsiPeriod {
+			gain += g / rsiPeriod
+			loss += l / rsiPeriod
+		} else {
+			gain = (gain*(rsiPeriod-1) + g) / rsiPeriod
+			loss = (loss*(rsiPeriod-1) + l) / rsiPeriod
+		}
+	}
+
+	if gain+loss == 0 {
+		return 0, true
+	}
+	rsi := 100 * gain / (gain + loss)
+
+	return (rsi - 50) / 50, true
+}
+
+/*
+20일 이동평균 대비 표준점수. ±3σ를 ±1로 환산
+점수 = clamp((pp - 평균) / 표준편차 / 3)
+*/
+func zScore(in scoreInput) (float64, bool) {
+
+	if in.pp <= 0 || len(in.closes) < zScorePeriod {
+		return 0, false
+	}
+
+	recent := in.closes[len(in.closes)-zScorePeriod:]
+
+	var mean float64
+	for _, c := range recent {
+		mean += c
+	}
+	mean /= zScorePeriod
+
+	var variance float64
+	for _, c := range recent {
+		variance += (c - mean) * (c - mean)
+	}
+	std := math.Sqrt(variance / zScorePeriod)
+	if std == 0 {
+		return 0, true
+	}
+
+	return clamp((in.pp-mean)/std/3, -1, 1), true
+}
+
+/*
+52주 최저가~최고가 구간 내 현재가 위치. 최저가 -1, 최고가 1
+*/
+func rangeScore(in scoreInput) (float64, bool) {
+
+	if in.pp <= 0 || len(in.closes) < rangeMinDays {
+		return 0, false
+	}
+
+	low, high := in.pp, in.pp
+	for _, c := range in.closes {
+		low = min(low, c)
+		high = max(high, c)
+	}
+	if high == low {
+		return 0, false
+	}
+
+	return 2*(in.pp-low)/(high-low) - 1, true
+}
+
+func clamp(v float64, lo float64, hi float64) float64 {
+	return max(lo, min(hi, v))
+}
+
+/*
+FundPriorities
+자금의 매도/매수 우선순위 목록
+  - 매도 : 자금 보유 자산 대상. 변동 자산 먼저, 점수 높은 순
+  - 매수 : 전체 자산 대상. 점수 낮은 순
+*/
+func (e Event) FundPriorities(fundId uint, buy bool) ([]m.AssetPriority, error) {
+
+	var assets []m.Asset
+	if buy {
+		li, err := e.stg.RetrieveTotalAssets()
+		if err != nil {
+			return nil, fmt.Errorf("RetrieveTotalAssets 시, 에러 발생. %w", err)
+		}
+		assets = li
+	} else {
+		ivsmLi, err := e.stg.RetreiveFundsSummaryOrderByFundId()
+		if err != nil {
+			return nil, fmt.Errorf("RetreiveFundsSummaryOrderByFundId 시, 에러 발생. %w", err)
+		}
+		for _, ivsm := range ivsmLi {
+			if ivsm.FundID == fundId && ivsm.Count > 0 {
+				assets = append(assets, ivsm.Asset)
+			}
+		}
+	}
+
+	ps, err := e.priorities(fundId, assets, make(map[uint]float64))
+	if err != nil {
+		return nil, err
+	}
+
+	if buy {
+		sortForBuy(ps)
+	} else {
+		sortForSell(ps)
+	}
+	return ps, nil
+}
+
+/*
+자산별 우선순위 점수 산정. 자금별 가중치로 산정 가능한 방식들의 가중 평균
+현재가를 구할 수 없거나 산정 가능한 방식이 없는 자산은 제외
+  - 이동평균 조회 실패 시 현재가를 이동평균으로 간주
+*/
+func (e Event) priorities(fundId uint, assets []m.Asset, pm map[uint]float64) ([]m.AssetPriority, error) {
+
+	weights, err := e.scoreWeights(fundId)
+	if err != nil {
+		return nil, err
+	}
+
+	// 종가 이력이 필요한 방식이 있을 때만 일봉 조회
+	needHist := false
+	for st := range weights {
+		needHist = needHist || st != m.Deviation
+	}
+
+	rtn := make([]m.AssetPriority, 0, len(assets))
+	for i := range assets {
+		a := assets[i]
+		if a.Category == m.Won || a.Category == m.Dollar {
+			continue
+		}
+
+		pp := e.presentPrice(&a, pm)
+		if pp == 0 {
+			continue
+		}
+
+		// 이동평균 조회 실패 시 현재가 기준으로 산정
+		ap, err := e.referenceAverage(a.ID)
+		if err != nil {
+			log.Printf("[priorities] referenceAverage 시, 에러 발생. ID: %d. %s", a.ID, err)
+			ap = 0
+		}
+
+		in := scoreInput{pp: pp, ap: ap, hp: a.Top}
+		if needHist {
+			to := time.Now().AddDate(0, 0, -1)
+			prices, err := e.stg.RetrieveDailyPrices(a.ID, to.AddDate(-1, 0, 0).Format("2006-01-02"), to.Format("2006-01-02"))
+			if err != nil {
+				return nil, fmt.Errorf("RetrieveDailyPrices 시, 에러 발생. ID: %d. %w", a.ID, err)
+			}
+			in.closes = make([]float64, 0, len(prices))
+			for _, p := range prices {
+				if p.Close > 0 {
+					in.closes = append(in.closes, p.Close)
+				}
+			}
+		}
+
+		score, breakdown := weightedScore(weights, in)
+		if len(breakdown) == 0 {
+			continue
+		}
+
+		if ap == 0 {
+			ap = pp
+		}
+		rtn = append(rtn, m.AssetPriority{
+			Asset:     a,
+			Present:   pp,
+			Average:   ap,
+			Highest:   a.Top,
+			Score:     score,
+			Breakdown: breakdown,
+		})
+	}
+
+	return rtn, nil
+}
+
+func weightedScore(weights map[m.ScoreStrategy]float64, in scoreInput) (float64, map[m.ScoreStrategy]float64) {
+
+	breakdown := make(map[m.ScoreStrategy]float64)
+	var sum, total float64
+	for st, w := range weights {
+		f, ok := scorers[st]
+		if !ok || w <= 0 {
+			continue
+		}
+		s, ok := f(in)
+		if !ok || math.IsNaN(s) || math.IsInf(s, 0) {
+			continue
+		}
+		breakdown[st] = s
+		sum += w * s
+		total += w
+	}
+
+	if total == 0 {
+		return 0, breakdown
+	}
+	return sum / total, breakdown
+}
+
+// 자금별 가중치. 설정이 없으면 기본 가중치
+func (e Event) scoreWeights(fundId uint) (map[m.ScoreStrategy]float64, error) {
+
+	li, err := e.stg.RetrieveScoreWeights(fundId)
+	if err != nil {
+		return nil, fmt.Errorf("RetrieveScoreWeights 시, 에러 발생. ID: %d. %w", fundId, err)
+	}
+	if len(li) == 0 {
+		return m.DefaultScoreWeights, nil
+	}
+
+	weights := make(map[m.ScoreStrategy]float64, len(li))
+	for _, w := range li {
+		weights[w.Strategy] = w.Weight
+	}
+	return weights, nil
+}
+
+// pm에 없으면 실시간 시세 캐시, 현재가 API 순으로 조회. 실패 시 0
+func (e Event) presentPrice(a *m.Asset, pm map[uint]float64) float64 {
+
+	if pp := pm[a.ID]; pp > 0 {
+		return pp
+	}
+
+	pp, ok := e.prices.get(a.ID, priceTTL)
+	if !ok {
+		var err error
+		pp, err = e.rt.PresentPrice(a.Category, a.Code)
+		if err != nil {
+			log.Printf("[priorities] PresentPrice 시, 에러 발생. ID: %d. %s", a.ID, err)
+			return 0
+		}
+	}
+
+	pm[a.ID] = pp
+	return pp
+}
+
+// 변동 자산 먼저, 점수 큰 게 앞으로
+func sortForSell(ps []m.AssetPriority) {
+	slices.SortFunc(ps, func(a, b m.AssetPriority) int {
+		if a.Asset.Category.IsStable() == b.Asset.Category.IsStable() {
+			return cmp.Compare(b.Score, a.Score)
+		}
+		if a.Asset.Category.IsStable() {
+			return 1
+		}
+		return -1
+	})
+}
+
+func sortForBuy(ps []m.AssetPriority) {
+	slices.SortFunc(ps, func(a, b m.AssetPriority) int {
+		return cmp.Compare(a.Score, b.Score)
+	})
+}
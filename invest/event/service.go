@@ -1,7 +1,9 @@
 package event
 
 import (
+	"invest/bus"
 	m "invest/model"
+	"time"
 )
 
 type Storage interface {
@@ -10,28 +12,62 @@ type Storage interface {
 	RetrieveAssetList() ([]m.Asset, error)
 	RetrieveAsset(id uint) (*m.Asset, error)
 	RetrieveTotalAssets() ([]m.Asset, error)
+	SaveAssetInfo(name string, category m.Category, code string, currency string, top float64, bottom float64, selPrice float64, buyPrice float64) (uint, error)
 	UpdateAssetInfo(id uint, name string, category m.Category, code string, currency string, top float64, bottom float64, selPrice float64, buyPrice float64) error
 
 	RetreiveFundsSummaryOrderByFundId() ([]m.InvestSummary, error)
 	UpdateInvestSummarySum(fundId uint, assetId uint, sum float64) error
+	SaveInvest(fundId uint, assetId uint, price float64, count float64) error
+	UpdateInvestSummary(fundId uint, assetId uint, change float64, price float64) error
 	RetreiveFundSummaryByAssetId(id uint) ([]m.InvestSummary, error)
 
-	RetrieveMarketIndicator(date string) (*m.DailyIndex, *m.CliIndex, error)
-	SaveDailyMarketIndicator(fearGreedIndex uint, nasdaq float64) error
+	RetrieveMarketIndicator(date string) ([]m.IndicatorChange, *m.CliIndex, error)
+	RetrieveIndicators() ([]m.Indicator, error)
+	SaveIndicatorValue(value m.IndicatorValue, collectedAt time.Time) error
+	SaveIndicatorAttempt(id uint, at time.Time) error
+	RetrieveLatestIndicatorValues(id uint, limit int) ([]m.IndicatorValue, error)
+	RetrieveIndicatorValues(id uint, from string, to string) ([]m.IndicatorValue, error)
+	RetrieveIndicatorAlerts(indicatorId uint) ([]m.IndicatorAlert, error)
+	UpdateIndicatorAlertState(id uint, triggered bool, at time.Time) error
+	SaveCliIndex(series []m.CliIndex) error
 
-	RetreiveLatestEma(assetId uint) (float64, error)
-	SaveEmaHist(assetId uint, price float64) error
+	UpdateDailyPrice(assetId uint, price float64) error
+	SaveDailyPrices(prices []m.DailyPrice) error
+	RetrieveDailyPrices(assetId uint, from string, to string) ([]m.DailyPrice, error)
+
+	RetrieveAssetAverages(assetId uint) ([]m.AssetAverage, error)
+	SaveAssetAverages(assetId uint, averages []m.AssetAverage) error
+	SaveAverageHist(assetId uint, spec m.AverageSpec, hist []m.AverageHist) error
+	RetrieveLatestAverage(assetId uint, spec m.AverageSpec) (*m.AverageHist, error)
+
+	RetrieveScoreWeights(fundId uint) ([]m.ScoreWeight, error)
+
+	RetrieveMonitors() ([]m.Monitor, error)
+	RetrieveMonitor(id uint) (*m.Monitor, error)
+	SaveMonitorCheck(hist m.MonitorHist) error
+
+	SaveFundValues(values []m.FundValue) error
+	RetrieveFundValues(date string) ([]m.FundValue, error)
+	SaveAlertLog(log m.AlertLog) error
+	RetrieveAlertLogs(from time.Time, to time.Time) ([]m.AlertLog, error)
+	SaveDigestHist(hist m.DigestHist) error
+	RetrieveLatestDigestHist(kind m.DigestKind) (*m.DigestHist, error)
 }
 
 type RtPoller interface {
 	PresentPrice(category m.Category, code string) (float64, error)
-	RealEstateStatus() (string, error)
+	TopBottomPrice(category m.Category, code string) (float64, float64, error)
+	Extract(url string, kind m.MonitorKind, selector string) (string, error)
 }
 
 type DailyPoller interface {
 	ExchageRate() float64
-	ClosingPrice(category m.Category, code string) (float64, error)
-	FearGreedIndex() (uint, error)
-	Nasdaq() (float64, error)
-	CliIdx() (float64, error)
+	IndicatorValue(provider m.IndicatorProvider, code string) (float64, error)
+	CliSeries() ([]m.CliIndex, error)
+	DailyCandles(category m.Category, code string, from time.Time, to time.Time) ([]m.DailyPrice, error)
+}
+
+// 이벤트 발행. 구독자(알림 채널, 발송 기록 등)는 종류/심각도로 구독
+type Publisher interface {
+	Publish(e bus.Event)
 }
@@ -0,0 +1,136 @@
+package event
+
+import (
+	m "invest/model"
+	"sync"
+	"time"
+)
+
+// 실시간/주기 조회로 수집한 가격을 재사용하는 유효 시간
+const priceTTL = time.Minute
+
+type pricePoint struct {
+	price float64
+	at    time.Time
+}
+
+type priceCache struct {
+	mu     sync.RWMutex
+	prices map[uint]pricePoint
+}
+
+func newPriceCache() *priceCache {
+	return &priceCache{
+		prices: make(map[uint]pricePoint),
+	}
+}
+
+func (p *priceCache) set(assetId uint, price float64, at time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prices[assetId] = pricePoint{price: price, at: at}
+}
+
+// ttl 이내에 갱신된 가격만 반환
+func (p *priceCache) get(assetId uint, ttl time.Duration) (float64, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	pt, ok := p.prices[assetId]
+	if !ok || time.Since(pt.at) > ttl {
+		return 0, false
+	}
+	return pt.price, true
+}
+
+// 실시간 시세 수신 시 참조할 자산. code => asset
+type streamAssets struct {
+	mu     sync.RWMutex
+	byCode map[string]m.Asset
+}
+
+type Streamer interface {
+	Supports(category m.Category) bool
+	Subscribe(category m.Category, code string) error
+	Unsubscribe(code string) error
+	Codes() []string
+}
+
+/*
+StreamSyncEvent
+등록 자산 목록 기준으로 실시간 시세 구독 목록 동기화. 신규 자산 구독, 삭제된 자산 구독 해제
+*/
+func (e Event) StreamSyncEvent(st Streamer, p Publisher) {
+
+	assets, err := e.stg.RetrieveTotalAssets()
+	if err != nil {
+		p.Publish(jobError("StreamSyncEvent", "RetrieveTotalAssets", "", err))
+		return
+	}
+
+	// 실시간 시세 수신 시 참조할 자산 정보는 스트림 종류와 무관하게 전체 갱신
+	byCode := make(map[string]m.Asset)
+	targets := make(map[string]m.Asset)
+	for _, a := range assets {
+		if a.Code == "" {
+			continue
+		}
+		byCode[a.Code] = a
+		if st.Supports(a.Category) {
+			targets[a.Code] = a
+		}
+	}
+
+	e.live.mu.Lock()
+	e.live.byCode = byCode
+	e.live.mu.Unlock()
+
+	for _, code := range st.Codes() {
+		if _, ok := targets[code]; !ok {
+			err = st.Unsubscribe(code)
+			if err != nil {
+				p.Publish(jobError("StreamSyncEvent", "Unsubscribe", code, err))
+			}
+		}
+	}
+
+	for code, a := range targets {
+		err = st.Subscribe(a.Category, code)
+		if err != nil {
+			p.Publish(jobError("StreamSyncEvent", "Subscribe", code, err))
+		}
+	}
+}
+
+/*
+PriceTick
+실시간 체결가 수신 시 가격 캐시 갱신 후 매수/매도 기준 비교하여 알림 전송
+*/
+func (e Event) PriceTick(p Publisher, code string, price float64, at time.Time) {
+
+	e.live.mu.RLock()
+	a, ok := e.live.byCode[code]
+	e.live.mu.RUnlock()
+	if !ok || price == 0 {
+		return
+	}
+
+	e.prices.set(a.ID, price, at)
+
+	if alert := e.evaluate(&a, price); alert != nil {
+		p.Publish(*alert)
+	}
+
+	// 최고가/최저가 갱신 시 구독 정보에도 반영하여 매 체결마다 갱신하지 않도록 함
+	if a.Top < price {
+		a.Top = price
+	} else if a.Bottom > price {
+		a.Bottom = price
+	} else {
+		return
+	}
+	e.live.mu.Lock()
+	if _, ok := e.live.byCode[code]; ok {
+		e.live.byCode[code] = a
+	}
+	e.live.mu.Unlock()
+}
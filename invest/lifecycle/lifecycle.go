@@ -0,0 +1,143 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type hook struct {
+	name string
+	stop func(ctx context.Context) error
+}
+
+/*
+Manager
+루트 컨텍스트와 종료 절차
+  - SIGINT, SIGTERM 혹은 Shutdown 호출 시 컨텍스트 종료
+  - 종료 시 OnStop 등록 순서대로 실행. 전체 timeout 초과 시 남은 절차는 컨텍스트 만료 상태로 실행
+*/
+type Manager struct {
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	stopSig context.CancelFunc
+	timeout time.Duration
+
+	mu    sync.Mutex
+	hooks []hook
+}
+
+func New(parent context.Context, timeout time.Duration) *Manager {
+
+	sigCtx, stopSig := signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+	ctx, cancel := context.WithCancelCause(sigCtx)
+	return &Manager{ctx: ctx, cancel: cancel, stopSig: stopSig, timeout: timeout}
+}
+
+// 종료 시작 시 Done. 장기 실행 작업(실시간 시세 수신 등)에 전달
+func (m *Manager) Context() context.Context {
+	return m.ctx
+}
+
+// 종료 절차 등록. 먼저 등록한 절차부터 실행
+func (m *Manager) OnStop(name string, stop func(ctx context.Context) error) {
+	m.mu.Lock()
+	m.hooks = append(m.hooks, hook{name: name, stop: stop})
+	m.mu.Unlock()
+}
+
+// 종료 요청. 종료 사유는 Wait에서 로그로 남김
+func (m *Manager) Shutdown(reason string) {
+	m.cancel(errors.New(reason))
+}
+
+/*
+Wait
+종료 요청까지 대기 후 종료 절차 실행
+  - 절차별 실패는 나머지 절차 실행 후 모아서 반환
+*/
+func (m *Manager) Wait() error {
+
+	<-m.ctx.Done()
+	m.stopSig()
+
+	reason := context.Cause(m.ctx)
+	if errors.Is(reason, context.Canceled) {
+		reason = errors.New("종료 신호 수신")
+	}
+	log.Printf("[Lifecycle] 종료 시작. %s", reason)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	m.mu.Lock()
+	hooks := m.hooks
+	m.mu.Unlock()
+
+	errs := make([]error, 0)
+	for _, h := range hooks {
+		start := time.Now()
+		err := h.stop(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s 종료 시 오류 발생. %w", h.name, err))
+			continue
+		}
+		log.Printf("[Lifecycle] %s 종료. %s", h.name, time.Since(start).Round(time.Millisecond))
+	}
+
+	return errors.Join(errs...)
+}
+
+/*
+Jobs
+실행 중인 작업 추적. 종료 시 진행 중 작업 완료 대기
+  - Stop 이후 시작하려는 작업은 실행하지 않음
+*/
+type Jobs struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	stopped bool
+}
+
+// 추적 대상으로 감싼 작업
+func (j *Jobs) Wrap(job func()) func() {
+	return func() {
+		j.mu.Lock()
+		if j.stopped {
+			j.mu.Unlock()
+			return
+		}
+		j.wg.Add(1)
+		j.mu.Unlock()
+
+		defer j.wg.Done()
+		job()
+	}
+}
+
+// 신규 작업 실행 중단 후 진행 중 작업 완료 대기. ctx 만료 시 대기 중단
+func (j *Jobs) Stop(ctx context.Context) error {
+
+	j.mu.Lock()
+	j.stopped = true
+	j.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		j.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("진행 중 작업 완료 대기 시간 초과. %w", ctx.Err())
+	}
+}
@@ -0,0 +1,130 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager(t *testing.T) {
+
+	t.Run("종료 요청 시 등록 순서대로 종료", func(t *testing.T) {
+		m := New(context.Background(), time.Second)
+		stopped := make([]string, 0)
+		for _, name := range []string{"http", "cron", "db"} {
+			m.OnStop(name, func(ctx context.Context) error {
+				stopped = append(stopped, name)
+				return nil
+			})
+		}
+
+		assert.NoError(t, m.Context().Err())
+		m.Shutdown("/shutdown 요청")
+		assert.ErrorIs(t, m.Context().Err(), context.Canceled)
+		assert.EqualError(t, context.Cause(m.Context()), "/shutdown 요청")
+
+		assert.NoError(t, m.Wait())
+		assert.Equal(t, []string{"http", "cron", "db"}, stopped)
+	})
+
+	t.Run("종료 신호 수신", func(t *testing.T) {
+		m := New(context.Background(), time.Second)
+		assert.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGTERM))
+
+		select {
+		case <-m.Context().Done():
+		case <-time.After(time.Second):
+			t.Fatal("종료 신호 미수신")
+		}
+		assert.NoError(t, m.Wait())
+	})
+
+	t.Run("일부 절차 실패 시 나머지 절차 진행", func(t *testing.T) {
+		m := New(context.Background(), time.Second)
+		closed := false
+		m.OnStop("bus", func(ctx context.Context) error { return errors.New("전송 실패") })
+		m.OnStop("db", func(ctx context.Context) error {
+			closed = true
+			return nil
+		})
+
+		m.Shutdown("test")
+		assert.EqualError(t, m.Wait(), "bus 종료 시 오류 발생. 전송 실패")
+		assert.True(t, closed)
+	})
+
+	t.Run("전체 대기 시간 공유", func(t *testing.T) {
+		m := New(context.Background(), 10*time.Millisecond)
+		m.OnStop("cron", func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		var remaining error
+		m.OnStop("db", func(ctx context.Context) error {
+			remaining = ctx.Err()
+			return nil
+		})
+
+		m.Shutdown("test")
+		assert.ErrorIs(t, m.Wait(), context.DeadlineExceeded)
+		assert.ErrorIs(t, remaining, context.DeadlineExceeded)
+	})
+}
+
+func TestJobs(t *testing.T) {
+
+	t.Run("진행 중 작업 완료 대기", func(t *testing.T) {
+		var jobs Jobs
+		started, release := make(chan struct{}), make(chan struct{})
+		done := false
+		go jobs.Wrap(func() {
+			close(started)
+			<-release
+			done = true
+		})()
+		<-started
+
+		var wg sync.WaitGroup
+		var err error
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err = jobs.Stop(context.Background())
+		}()
+		close(release)
+		wg.Wait()
+
+		assert.NoError(t, err)
+		assert.True(t, done)
+	})
+
+	t.Run("종료 후 작업 미실행", func(t *testing.T) {
+		var jobs Jobs
+		assert.NoError(t, jobs.Stop(context.Background()))
+
+		ran := false
+		jobs.Wrap(func() { ran = true })()
+		assert.False(t, ran)
+	})
+
+	t.Run("대기 시간 초과", func(t *testing.T) {
+		var jobs Jobs
+		release := make(chan struct{})
+		defer close(release)
+		started := make(chan struct{})
+		go jobs.Wrap(func() {
+			close(started)
+			<-release
+		})()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorContains(t, jobs.Stop(ctx), "진행 중 작업 완료 대기 시간 초과")
+	})
+}
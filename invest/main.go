@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"invest/app"
+	"invest/auth"
 
 	"invest/bot"
+	"invest/bus"
+	"invest/client"
 	"invest/config"
 	"invest/db"
 	"invest/event"
+	"invest/lifecycle"
+	"invest/model"
+	"invest/notify"
+	"invest/render"
 	"invest/scrape"
+	"os"
 	"strconv"
+	"time"
 
 	"log"
 
@@ -16,34 +27,50 @@ import (
 )
 
 const (
-	AssetSpec  = "0 */15 8-23 * * 1-5"
-	CoinSpec   = "0 */15 8-23 * * 0,6"
-	EstateSpec = "0 */15 9-17 * * 1-5"
-	IndexSpec  = "0 3 9 * * 1-5" // todo. 9시 3분이랑 8시 3분이랑 값이 같은지 확인
-	EmaSpec    = "0 3 9 * * 2-6" // 화~토
+	AssetSpec   = "0 */15 8-23 * * 1-5"
+	CoinSpec    = "0 */15 * * * *" // 실시간 시세 미수신 대비
+	MonitorSpec = "0 * * * * *"    // 감시 대상별 주기는 DB 설정
+	IndexSpec   = "0 * * * * *"    // 지표별 수집 주기는 DB 설정
+	AvgSpec     = "0 3 9 * * 2-6"  // 화~토
+	CliSpec     = "0 10 9 * * 1"   // 월별 발표. 주 1회 확인
+	DailySpec   = "0 0 18 * * 1-5" // 정기 리포트 미설정 시 기본 일간 리포트
+	WeeklySpec  = "0 0 10 * * 6"   // 정기 리포트 미설정 시 기본 주간 리포트
+	StreamSpec  = "0 */15 * * * *"
+	ReloadSpec  = "30 * * * * *" // 문구 템플릿 재적재
+
+	KisTokenPath    = ".kis_token"
+	APIAddr         = ":3000"
+	ShutdownTimeout = 30 * time.Second // 종료 절차 전체 대기 시간
+	BotTokenTTL     = time.Minute      // 봇 API 요청용 JWT 유효 시간. 요청마다 발급
 )
 
 func main() {
-	// Create a new instance of the server
 
 	conf, err := config.NewConfig()
 	if err != nil {
 		panic(err)
 	}
 
-	ch := make(chan string)
-
 	chatId, err := strconv.ParseInt(conf.Telegram.ChatId, 10, 64)
 	if err != nil {
 		panic(err)
 	}
-	teleBot, err := bot.NewTeleBot(conf.Telegram.Token, chatId)
+	users := make([]func(*bot.TeleBot), 0, len(conf.Telegram.Users))
+	for _, u := range conf.Telegram.Users {
+		role, err := bot.ToRole(u.Role)
+		if err != nil {
+			panic(err)
+		}
+		users = append(users, bot.WithUser(u.Id, role))
+	}
+	teleBot, err := bot.NewTeleBot(conf.Telegram.Token, chatId, users...)
 	if err != nil {
 		panic(err)
 	}
 
+	var key string
 	for {
-		key := teleBot.InitKey()
+		key = teleBot.InitKey()
 		err = conf.InitKIS(key)
 
 		if err != nil {
@@ -53,35 +80,313 @@ func main() {
 		}
 	}
 
-	go func() {
-		teleBot.Listen(ch)
-	}()
+	// 복호화 키 입력 대기 중에는 기본 신호 처리(즉시 종료) 유지
+	lc := lifecycle.New(context.Background(), ShutdownTimeout)
 
 	scraper := scrape.NewScraper(conf,
 		scrape.WithKIS(conf.KisAppKey(), conf.KisAppSecret()),
+		scrape.WithTokenStore(scrape.NewFileTokenStore(KisTokenPath, []byte(key))),
 	)
 
 	db, err := db.NewStorage(conf.Dsn())
 	if err != nil {
 		panic(err)
 	}
-	event := event.NewEvent(db, scraper, scraper)
+	evt := event.NewEvent(db, scraper, scraper)
+
+	locale, err := render.ToLocale(conf.Notify.Locale)
+	if err != nil {
+		panic(err)
+	}
+	tpl, err := render.New(conf.Notify.Templates, locale)
+	if err != nil {
+		panic(err)
+	}
+	render.SetDefault(tpl)
+
+	notifier, err := notifyRouter(conf, teleBot, tpl, locale)
+	if err != nil {
+		panic(err)
+	}
+	events := bus.New()
+	events.Subscribe(bus.Filter{}, func(e bus.Event) {
+		log.Println(e)
+		err := notifier.Send(e)
+		if err != nil {
+			log.Println(err)
+		}
+	})
+	events.Subscribe(bus.Filter{Kinds: event.AlertKinds}, evt.LogAlert)
+
+	go func() {
+		api := client.New("http://localhost"+APIAddr, client.WithCredential(botCredential(conf)))
+		teleBot.Listen(bot.NewRouter(db, evt, bot.WithClient(api)))
+	}()
+
+	// 실시간 시세 수신. 체결마다 매수/매도 기준 비교
+	onTick := func(t scrape.Tick) {
+		evt.PriceTick(events, t.Code, t.Price, t.Time)
+	}
+	kisStream := scraper.KisStream(onTick)
+	upbitStream := scraper.UpbitStream(onTick)
+	go func() {
+		evt.StreamSyncEvent(kisStream, events)
+		evt.StreamSyncEvent(upbitStream, events)
+	}()
+	go kisStream.Run(lc.Context())
+	go upbitStream.Run(lc.Context())
 
+	// 종료 시 진행 중인 작업 완료 대기
+	var jobs lifecycle.Jobs
 	c := cron.New()
-	c.AddFunc(AssetSpec, func() { event.AssetEvent(ch) })
-	c.AddFunc(CoinSpec, func() { event.CoinEvent(ch) })
-	c.AddFunc(EstateSpec, func() { event.RealEstateEvent(ch) })
-	c.AddFunc(IndexSpec, func() { event.IndexEvent(ch) })
-	c.AddFunc(EmaSpec, func() { event.EmaUpdateEvent(ch) })
+	c.AddFunc(AssetSpec, jobs.Wrap(func() { evt.AssetEvent(events) }))
+	c.AddFunc(CoinSpec, jobs.Wrap(func() { evt.CoinEvent(events) }))
+	c.AddFunc(MonitorSpec, jobs.Wrap(func() { evt.MonitorEvent(events) }))
+	c.AddFunc(IndexSpec, jobs.Wrap(func() { evt.IndexEvent(events) }))
+	c.AddFunc(AvgSpec, jobs.Wrap(func() { evt.AverageUpdateEvent(events) }))
+	c.AddFunc(CliSpec, jobs.Wrap(func() { evt.CliEvent(events) }))
+	for _, job := range digestJobs(conf) {
+		c.AddFunc(job.spec, jobs.Wrap(func() {
+			evt.DigestEvent(events, job.digest)
+		}))
+	}
+	c.AddFunc(StreamSpec, jobs.Wrap(func() {
+		evt.StreamSyncEvent(kisStream, events)
+		evt.StreamSyncEvent(upbitStream, events)
+	}))
+	if conf.Notify.Templates != "" {
+		c.AddFunc(ReloadSpec, func() {
+			err := tpl.Reload()
+			if err != nil {
+				log.Printf("[Templates] %s", err)
+			}
+		})
+	}
 	c.Start()
 
+	server := app.New(db, scraper, evt, []byte(conf.Auth.Secret), func() { lc.Shutdown("/shutdown 요청") })
 	go func() {
-		app.Run(db, scraper)
+		err := server.Listen(APIAddr)
+		if err != nil {
+			log.Printf("[API] %s", err)
+			lc.Shutdown("API 서버 수신 실패")
+		}
 	}()
 
-	for true {
-		msg := <-ch
-		teleBot.SendMessage(msg)
-		log.Println(msg)
+	// 요청 수신 중단 > 예약 작업 완료 대기 > 대기 알림 전송 > DB 종료 순
+	lc.OnStop("API 서버", server.ShutdownWithContext)
+	lc.OnStop("텔레그램 수신", func(ctx context.Context) error {
+		teleBot.Stop()
+		return nil
+	})
+	lc.OnStop("예약 작업", func(ctx context.Context) error {
+		c.Stop()
+		return jobs.Stop(ctx)
+	})
+	lc.OnStop("이벤트 버스", func(ctx context.Context) error {
+		events.Close()
+		return nil
+	})
+	lc.OnStop("알림", func(ctx context.Context) error { return notifier.Close() })
+	lc.OnStop("DB", func(ctx context.Context) error { return db.Close() })
+
+	err = lc.Wait()
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+	log.Println("[Lifecycle] 종료 완료")
+}
+
+type digestJob struct {
+	spec   string
+	digest model.DigestSpec
+}
+
+// 정기 리포트 설정. 미설정 시 차트 포함 일간 리포트와 주간 리포트
+func digestJobs(conf *config.Config) []digestJob {
+
+	if len(conf.Digest) == 0 {
+		return []digestJob{
+			{DailySpec, model.DigestSpec{Kind: model.DailyDigest, Charts: true}},
+			{WeeklySpec, model.DigestSpec{Kind: model.WeeklyDigest}},
+		}
+	}
+
+	rtn := make([]digestJob, 0, len(conf.Digest))
+	for _, d := range conf.Digest {
+		kind, err := model.ToDigestKind(d.Kind)
+		if err != nil {
+			panic(err)
+		}
+		sections := make([]model.DigestSection, len(d.Sections))
+		for i, s := range d.Sections {
+			sections[i], err = model.ToDigestSection(s)
+			if err != nil {
+				panic(err)
+			}
+		}
+		rtn = append(rtn, digestJob{d.Spec, model.DigestSpec{Kind: kind, Sections: sections, Charts: d.Charts, Dir: d.Dir, Format: d.Format}})
+	}
+	return rtn
+}
+
+/*
+텔레그램 봇의 API 인증 정보
+  - auth.bot-key 설정 시 해당 API 키
+  - 미설정 시 auth.jwt-secret으로 write 권한 토큰 발급
+*/
+func botCredential(conf *config.Config) func() (string, error) {
+
+	if conf.Auth.BotKey != "" {
+		return func() (string, error) { return conf.Auth.BotKey, nil }
+	}
+	if conf.Auth.Secret == "" {
+		log.Println("[API] auth.bot-key, auth.jwt-secret 미설정. 텔레그램 봇의 API 요청 인증 불가")
+		return nil
+	}
+
+	secret := []byte(conf.Auth.Secret)
+	return func() (string, error) {
+		return auth.Issue(secret, "telegram", model.WriteScope, BotTokenTTL, time.Now())
+	}
+}
+
+// 설정 파일 알림 채널과 라우팅 규칙. 텔레그램은 telegram 이름으로 기본 등록
+func notifyRouter(conf *config.Config, teleBot *bot.TeleBot, tpl *render.Templates, locale render.Locale) (*notify.Router, error) {
+
+	// 채널별 언어. 미설정 시 기본 언어
+	view := func(l string) (notify.View, error) {
+		if l == "" {
+			return notify.View{Templates: tpl, Locale: locale}, nil
+		}
+		cl, err := render.ToLocale(l)
+		if err != nil {
+			return notify.View{}, err
+		}
+		return notify.View{Templates: tpl, Locale: cl}, nil
+	}
+
+	v, err := view(conf.Notify.Telegram.Locale)
+	if err != nil {
+		return nil, err
+	}
+	format := conf.Notify.Telegram.Format
+	if format != "" && format != "plain" && format != "markdown" {
+		return nil, fmt.Errorf("존재하지 않는 텔레그램 서식. plain, markdown. 입력 값 : %s", format)
+	}
+	channels := []notify.Notifier{notify.NewTelegram(teleBot, v, format == "markdown")}
+	for _, c := range conf.Notify.Channels {
+		v, err := view(c.Locale)
+		if err != nil {
+			return nil, err
+		}
+		switch c.Type {
+		case "slack":
+			channels = append(channels, notify.NewSlack(c.Name, c.Url, v))
+		case "discord":
+			channels = append(channels, notify.NewDiscord(c.Name, c.Url, v))
+		case "webhook":
+			channels = append(channels, notify.NewWebhook(c.Name, c.Url, c.Header, v))
+		case "email":
+			channels = append(channels, notify.NewEmail(c.Name, c.Host, c.Port, c.User, c.Pwd, c.From, c.To, v))
+		default:
+			return nil, fmt.Errorf("존재하지 않는 알림 채널 종류. %s", c.Type)
+		}
+	}
+
+	channels, err = dispatchers(conf, channels)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]notify.Rule, len(conf.Notify.Routes))
+	for i, r := range conf.Notify.Routes {
+		f, err := notifyFilter(r.Kinds, r.Severity)
+		if err != nil {
+			return nil, err
+		}
+		rules[i] = notify.Rule{Filter: f, Channels: r.Channels}
+	}
+
+	fallback := conf.Notify.Default
+	if len(fallback) == 0 {
+		fallback = []string{"telegram"}
+	}
+
+	return notify.NewRouter(channels, rules, fallback)
+}
+
+/*
+채널별 전송 정책 적용
+  - telegram 미설정 시 30초 묶음 전송, error 이상 즉시 전송
+*/
+func dispatchers(conf *config.Config, channels []notify.Notifier) ([]notify.Notifier, error) {
+
+	policies := make(map[string]notify.Policy)
+	policies["telegram"] = notify.Policy{Window: 30 * time.Second, Urgent: &bus.Filter{Severity: bus.Error}}
+
+	for _, d := range conf.Notify.Dispatch {
+		var p notify.Policy
+		var err error
+		if d.Quiet != "" {
+			p.Quiet, err = notify.ParseQuietHours(d.Quiet)
+			if err != nil {
+				return nil, err
+			}
+		}
+		p.Limit = d.Limit
+		if d.Per != "" {
+			p.Per, err = time.ParseDuration(d.Per)
+			if err != nil {
+				return nil, fmt.Errorf("%s 전송 한도 기간 형식 오류. %w", d.Channel, err)
+			}
+		}
+		if d.Window != "" {
+			p.Window, err = time.ParseDuration(d.Window)
+			if err != nil {
+				return nil, fmt.Errorf("%s 묶음 대기 시간 형식 오류. %w", d.Channel, err)
+			}
+		}
+		if d.Urgent != nil {
+			f, err := notifyFilter(d.Urgent.Kinds, d.Urgent.Severity)
+			if err != nil {
+				return nil, err
+			}
+			p.Urgent = &f
+		}
+		policies[d.Channel] = p
+	}
+
+	rtn := make([]notify.Notifier, len(channels))
+	for i, c := range channels {
+		rtn[i] = c
+		if p, ok := policies[c.Name()]; ok {
+			rtn[i] = notify.NewDispatcher(c, p)
+			delete(policies, c.Name())
+		}
+	}
+	for name := range policies {
+		return nil, fmt.Errorf("존재하지 않는 알림 채널. %s", name)
+	}
+	return rtn, nil
+}
+
+func notifyFilter(kinds []string, severity string) (bus.Filter, error) {
+
+	f := bus.Filter{Kinds: make([]bus.Kind, len(kinds))}
+	for i, k := range kinds {
+		kind, err := bus.ToKind(k)
+		if err != nil {
+			return bus.Filter{}, err
+		}
+		f.Kinds[i] = kind
+	}
+
+	var err error
+	f.Severity, err = bus.ToSeverity(severity)
+	if err != nil {
+		return bus.Filter{}, err
 	}
+	return f, nil
 }
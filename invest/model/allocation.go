@@ -0,0 +1,64 @@
+package model
+
+/*
+Allocation
+자금별 자산 배분. 평가 금액은 원화 환산
+  - Min, Max : 현재 시장 단계의 변동 자산 비율 허용 범위
+  - Assets : 평가 금액 큰 순
+*/
+type Allocation struct {
+	Fund     Fund
+	Market   MarketLevel
+	Stable   float64
+	Volatile float64
+	Min      float64
+	Max      float64
+	Assets   []AssetAllocation
+}
+
+type AssetAllocation struct {
+	Asset Asset
+	Count float64
+	Value float64
+}
+
+func (a Allocation) Total() float64 {
+	return a.Stable + a.Volatile
+}
+
+// 변동 자산 비율. 평가 금액 미존재 시 0
+func (a Allocation) Ratio() float64 {
+	if a.Total() == 0 {
+		return 0
+	}
+	return a.Volatile / a.Total()
+}
+
+// 변동 자산 비율의 허용 범위 대비 상태. 표기 문구는 언어별 템플릿(state)에서 작성
+type RebalanceState string
+
+const (
+	RebalanceOver   RebalanceState = "over"   // 허용 범위 초과
+	RebalanceUnder  RebalanceState = "under"  // 허용 범위 미만
+	RebalanceNear   RebalanceState = "near"   // 범위 내. 경계와 차이 RebalanceMargin 이내
+	RebalanceWithin RebalanceState = "within" // 범위 내
+)
+
+// 허용 범위 대비 상태. 초과, 부족, 범위 내
+func (a Allocation) State() RebalanceState {
+	r := a.Ratio()
+	if r > a.Max {
+		return RebalanceOver
+	} else if r < a.Min {
+		return RebalanceUnder
+	}
+	return RebalanceWithin
+}
+
+// 자금 내 자산 비중
+func (a Allocation) Weight(asset AssetAllocation) float64 {
+	if a.Total() == 0 {
+		return 0
+	}
+	return asset.Value / a.Total()
+}
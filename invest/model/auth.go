@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// API 권한 범위. 상위 범위는 하위 범위 포함
+type Scope string
+
+const (
+	ReadScope  Scope = "read"  // 조회(GET)
+	WriteScope Scope = "write" // 저장/변경/삭제
+	AdminScope Scope = "admin" // 서버 종료 등 관리 기능
+)
+
+var scopeLevel = map[Scope]int{ReadScope: 1, WriteScope: 2, AdminScope: 3}
+
+func ToScope(s string) (Scope, error) {
+	scope := Scope(strings.ToLower(s))
+	if _, ok := scopeLevel[scope]; !ok {
+		return "", fmt.Errorf("존재하지 않는 권한 범위. read, write, admin. 입력 값 : %s", s)
+	}
+	return scope, nil
+}
+
+// required 범위 요청 허용 여부
+func (s Scope) Allows(required Scope) bool {
+	return scopeLevel[s] > 0 && scopeLevel[s] >= scopeLevel[required]
+}
+
+/*
+API 키
+  - Prefix : 키 앞부분. 목록 조회 시 식별용
+  - Hash : 키 SHA-256 해시. 원문은 발급 시에만 출력하고 저장하지 않음
+  - RevokedAt : 폐기 시각. 폐기된 키는 인증 불가
+*/
+type ApiKey struct {
+	ID         uint
+	Name       string `gorm:"size:50"`
+	Prefix     string `gorm:"size:16"`
+	Hash       string `gorm:"size:64;uniqueIndex"`
+	Scope      Scope  `gorm:"size:10"`
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type AverageKind string
+
+const (
+	EMA AverageKind = "EMA"
+	SMA AverageKind = "SMA"
+)
+
+var averagePeriods = []uint{20, 60, 120, 200}
+
+// 이동평균 종류. ex) EMA200, SMA60
+type AverageSpec struct {
+	Kind   AverageKind
+	Period uint
+}
+
+// 별도 설정이 없는 자산의 기준 이동평균
+var DefaultAverage = AverageSpec{Kind: EMA, Period: 200}
+
+func (a AverageSpec) String() string {
+	return fmt.Sprintf("%s%d", a.Kind, a.Period)
+}
+
+func ToAverageSpec(s string) (AverageSpec, error) {
+
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 4 {
+		return AverageSpec{}, fmt.Errorf("올바르지 않은 이동평균. 입력 값 : %s", s)
+	}
+
+	kind := AverageKind(s[:3])
+	period, err := strconv.ParseUint(s[3:], 10, 64)
+	if err != nil || (kind != EMA && kind != SMA) || !slices.Contains(averagePeriods, uint(period)) {
+		return AverageSpec{}, fmt.Errorf("올바르지 않은 이동평균. 입력 값 : %s", s)
+	}
+
+	return AverageSpec{Kind: kind, Period: uint(period)}, nil
+}
@@ -20,7 +20,7 @@ const (
 var categoryList = []string{"현금", "달러", "금", "단기채권", "국내ETF", "국내주식", "국내코인", "해외주식", "해외ETF", "레버리지"}
 
 func (c Category) String() string {
-	if c == 0 || int(c) >= len(categoryList) {
+	if c == 0 || int(c) > len(categoryList) {
 		return ""
 	}
 	return categoryList[c-1]
@@ -44,6 +44,15 @@ func (c Category) IsStable() bool {
 	}
 }
 
+// 일봉 API 조회 가능 여부. 이동평균 산출 대상
+func (c Category) HasDailyCandles() bool {
+	switch c {
+	case Gold, DomesticETF, DomesticStock, DomesticCoin, ForeignStock, ForeignETF:
+		return true
+	}
+	return false
+}
+
 func CategoryLength() uint64 {
 	return uint64(len(categoryList))
 }
@@ -0,0 +1,7 @@
+package model
+
+// PNG 차트와 사진 설명(범례 포함)
+type Chart struct {
+	Image   []byte
+	Caption string
+}
@@ -24,12 +24,44 @@ type Asset struct {
 	BuyPrice  float64
 }
 
-type EmaHist struct {
-	ID      uint
-	AssetID uint
-	Asset   Asset
-	Date    datatypes.Date
-	Ema     float64
+// 자산별 산출 대상 이동평균. Reference는 우선순위 계산 시 기준 이동평균 여부
+type AssetAverage struct {
+	ID        uint
+	AssetID   uint
+	Kind      AverageKind
+	Period    uint
+	Reference bool
+}
+
+func (a AssetAverage) Spec() AverageSpec {
+	return AverageSpec{Kind: a.Kind, Period: a.Period}
+}
+
+// 자금별 우선순위 점수 산정 방식 가중치
+type ScoreWeight struct {
+	FundID   uint          `gorm:"primaryKey;autoIncrement:false"`
+	Strategy ScoreStrategy `gorm:"primaryKey;size:20"`
+	Weight   float64
+}
+
+// 일별 종가 기준 이동평균
+type AverageHist struct {
+	AssetID uint           `gorm:"primaryKey;autoIncrement:false"`
+	Kind    AverageKind    `gorm:"primaryKey;size:3"`
+	Period  uint           `gorm:"primaryKey;autoIncrement:false"`
+	Date    datatypes.Date `gorm:"primaryKey"`
+	Value   float64
+}
+
+// 일별 시세. 주기 조회 시 당일 값 갱신, 과거분은 일봉 API로 백필
+type DailyPrice struct {
+	AssetID uint           `gorm:"primaryKey;autoIncrement:false"`
+	Date    datatypes.Date `gorm:"primaryKey"`
+	Open    float64
+	High    float64
+	Low     float64
+	Close   float64
+	Volume  float64
 }
 
 type Invest struct {
@@ -58,10 +90,45 @@ type Market struct {
 	Status    uint
 }
 
-type DailyIndex struct {
-	CreatedAt      datatypes.Date `gorm:"primaryKey"`
-	FearGreedIndex uint
-	NasDaq         float64
+/*
+시장 지표 설정
+  - Spec : 수집 주기 (cron 형식, 초 단위 포함)
+  - Code : 수집처별 지표 코드
+*/
+type Indicator struct {
+	ID              uint
+	Name            string            `gorm:"size:50;unique"`
+	Provider        IndicatorProvider `gorm:"size:20"`
+	Code            string
+	Spec            string
+	Unit            string `gorm:"size:10"`
+	Active          bool
+	LastCollectedAt time.Time
+}
+
+/*
+지표 알림 규칙
+  - Days : change 조건의 비교 기간 (영업일)
+  - Period : cross 조건의 이동평균 기간
+  - Triggered : 조건 충족 상태. 미충족 -> 충족으로 바뀔 때만 알림
+*/
+type IndicatorAlert struct {
+	ID              uint
+	IndicatorID     uint      `gorm:"index"`
+	Kind            AlertKind `gorm:"size:20"`
+	Threshold       float64
+	Days            int
+	Period          int
+	Active          bool
+	Triggered       bool
+	LastTriggeredAt time.Time
+}
+
+// 지표 일별 값 (long format)
+type IndicatorValue struct {
+	IndicatorID uint           `gorm:"primaryKey"`
+	Date        datatypes.Date `gorm:"primaryKey"`
+	Value       float64
 }
 
 type CliIndex struct {
@@ -69,6 +136,57 @@ type CliIndex struct {
 	Index     float64
 }
 
+/*
+웹 페이지 변동 감시 대상
+  - Spec : 확인 주기 (cron 형식, 초 단위 포함)
+  - Expected : 기대 값. 추출 값이 기대 값과 다르면 알림. 비어 있으면 값이 바뀔 때마다 알림
+*/
+type Monitor struct {
+	ID            uint
+	Name          string
+	Url           string
+	Kind          MonitorKind `gorm:"size:10"`
+	Selector      string
+	Spec          string
+	Expected      string
+	Active        bool
+	LastValue     string `gorm:"type:text"`
+	LastCheckedAt time.Time
+}
+
+type MonitorHist struct {
+	ID        uint
+	MonitorID uint   `gorm:"index"`
+	Value     string `gorm:"type:text"`
+	Changed   bool
+	Error     string
+	CheckedAt time.Time
+}
+
+// 자금별 일별 평가 금액 (원화 환산). 리포트 기간 비교 기준
+type FundValue struct {
+	FundID uint           `gorm:"primaryKey;autoIncrement:false"`
+	Date   datatypes.Date `gorm:"primaryKey"`
+	Value  float64
+}
+
+// 발송 알림 기록. 리포트 기간 내 알림 목록
+type AlertLog struct {
+	ID        uint
+	Source    AlertSource `gorm:"size:20"`
+	Message   string      `gorm:"type:text"`
+	CreatedAt time.Time   `gorm:"index"`
+}
+
+// 리포트 발송 이력. 다음 리포트의 알림 조회 시작 시점
+type DigestHist struct {
+	ID      uint
+	Kind    DigestKind `gorm:"size:10;index"`
+	Since   time.Time
+	Until   time.Time
+	Content string `gorm:"type:text"`
+}
+
 type Sample struct {
 	ID   uint `gorm:"primaryKey"`
 	Date datatypes.Date
@@ -0,0 +1,236 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// 알림 발생처
+type AlertSource string
+
+const (
+	PriceSource     AlertSource = "price"     // 매수/매도 기준가 도달
+	IndicatorSource AlertSource = "indicator" // 지표 알림 규칙 충족
+	MonitorSource   AlertSource = "monitor"   // 웹 페이지 감시 대상 변동
+)
+
+// 정기 리포트 종류
+type DigestKind string
+
+const (
+	DailyDigest  DigestKind = "daily"
+	WeeklyDigest DigestKind = "weekly"
+)
+
+func ToDigestKind(s string) (DigestKind, error) {
+	switch k := DigestKind(s); k {
+	case DailyDigest, WeeklyDigest:
+		return k, nil
+	}
+	return "", fmt.Errorf("올바르지 않은 리포트 종류. daily 혹은 weekly. 입력 값 : %s", s)
+}
+
+// 평가 금액, 시세 등 비교 기간 (달력일)
+func (k DigestKind) Days() int {
+	if k == WeeklyDigest {
+		return 7
+	}
+	return 1
+}
+
+func (k DigestKind) String() string {
+	if k == WeeklyDigest {
+		return "주간"
+	}
+	return "일간"
+}
+
+// 리포트 구성 항목
+type DigestSection string
+
+const (
+	FundSection      DigestSection = "funds"     // 자금별 평가 금액과 기간 변동
+	MoverSection     DigestSection = "movers"    // 보유 자산 등락 상위
+	RebalanceSection DigestSection = "rebalance" // 변동 자산 비율 허용 범위 초과/부족/근접 자금
+	MarketSection    DigestSection = "market"    // 시장 단계와 지표 기간 변동
+	AlertSection     DigestSection = "alerts"    // 직전 리포트 이후 발송 알림
+)
+
+var DigestSections = []DigestSection{FundSection, MoverSection, RebalanceSection, MarketSection, AlertSection}
+
+func ToDigestSection(s string) (DigestSection, error) {
+	sec := DigestSection(s)
+	if !slices.Contains(DigestSections, sec) {
+		return "", fmt.Errorf("올바르지 않은 리포트 항목. 입력 값 : %s", s)
+	}
+	return sec, nil
+}
+
+/*
+정기 리포트 설정
+  - Sections : 미설정 시 전체 항목
+  - Charts : 리포트 후 변동 자산 비율, 자금별 비중 차트 전송
+  - Dir : 리포트 파일 저장 경로. 미설정 시 파일 미저장
+  - Format : 파일 형식. md 혹은 html
+*/
+type DigestSpec struct {
+	Kind     DigestKind
+	Sections []DigestSection
+	Charts   bool
+	Dir      string
+	Format   string
+}
+
+func (s DigestSpec) Has(sec DigestSection) bool {
+	return len(s.Sections) == 0 || slices.Contains(s.Sections, sec)
+}
+
+/*
+정기 리포트
+  - From : 직전 리포트 발송 시점. 미존재 시 To에서 Kind 기간 이전
+  - Errors : 항목별 조회 실패 내용. 실패 항목 외에는 정상 작성
+  - 문구는 render 템플릿(Digest)으로 작성
+*/
+type DigestReport struct {
+	Spec       DigestSpec
+	From       time.Time
+	To         time.Time
+	Funds      []FundDigest
+	Movers     []AssetMove
+	Rebalances []Rebalance
+	Market     *MarketLevel
+	Indicators []IndicatorMove
+	Alerts     []AlertLog
+	Errors     []DigestError
+}
+
+const (
+	DigestMovers = 5  // 보유 자산 등락 표기 개수
+	DigestAlerts = 20 // 알림 표기 개수. 초과분은 건수만 표기
+)
+
+/*
+DigestError
+리포트 작성 중 조회 실패
+  - Section : 작성 실패 항목. 빈 값이면 여러 항목 공통 조회(Step) 실패
+  - Step : 실패한 조회 이름(RetrieveLatestDigestHist 등)
+*/
+type DigestError struct {
+	Section DigestSection
+	Step    string
+	Message string
+}
+
+// 항목 작성 실패 여부
+func (d DigestReport) Failed(sec DigestSection) bool {
+	for _, e := range d.Errors {
+		if e.Section == sec {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Shows
+항목 표기 여부. 설정에 포함된 항목 중
+  - 자금, 등락, 시장 : 작성된 경우
+  - 리밸런싱, 알림 : 작성 실패하지 않은 경우. 대상 없으면 없음 문구 표기
+*/
+func (d DigestReport) Shows(sec DigestSection) bool {
+
+	if !d.Spec.Has(sec) {
+		return false
+	}
+
+	switch sec {
+	case FundSection:
+		return d.Funds != nil
+	case MoverSection:
+		return d.Movers != nil
+	case MarketSection:
+		return d.Market != nil
+	default:
+		return !d.Failed(sec)
+	}
+}
+
+func (d DigestReport) FundTotal() float64 {
+	var total float64
+	for _, f := range d.Funds {
+		total += f.Value
+	}
+	return total
+}
+
+// 자금 평가 금액 합계 비교 기준. 비교 기준 없는 자금 존재 시 nil
+func (d DigestReport) FundPreviousTotal() *float64 {
+	var total float64
+	for _, f := range d.Funds {
+		if f.Previous == nil {
+			return nil
+		}
+		total += *f.Previous
+	}
+	return &total
+}
+
+func (d DigestReport) MoverLimit() int {
+	return DigestMovers
+}
+
+// 표기 대상 알림. 최대 DigestAlerts건
+func (d DigestReport) ShownAlerts() []AlertLog {
+	return d.Alerts[:min(len(d.Alerts), DigestAlerts)]
+}
+
+// 표기 생략 알림 건수
+func (d DigestReport) HiddenAlerts() int {
+	return max(len(d.Alerts)-DigestAlerts, 0)
+}
+
+// Previous : 기간 시작 이전 가장 최근 평가 금액. 미존재 시 nil
+type FundDigest struct {
+	FundID   uint
+	Value    float64
+	Previous *float64
+}
+
+type AssetMove struct {
+	Asset Asset
+	From  float64
+	To    float64
+}
+
+func (a AssetMove) Rate() float64 {
+	if a.From == 0 {
+		return 0
+	}
+	return (a.To - a.From) / a.From * 100
+}
+
+// State : 초과, 부족, 근접
+type Rebalance struct {
+	FundID uint
+	Rate   float64
+	Min    float64
+	Max    float64
+	State  RebalanceState
+}
+
+// 리밸런싱 근접 판단 기준 (변동 자산 비율 차이)
+const RebalanceMargin = 0.05
+
+type IndicatorMove struct {
+	Indicator Indicator
+	From      float64
+	To        float64
+}
+
+func (i IndicatorMove) Rate() float64 {
+	if i.From == 0 {
+		return 0
+	}
+	return (i.To - i.From) / i.From * 100
+}
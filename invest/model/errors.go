@@ -0,0 +1,48 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// 도메인 오류 분류. errors.Is로 확인하여 API 응답 상태 코드 결정
+var (
+	ErrInvalid  = errors.New("올바르지 않은 요청")
+	ErrNotFound = errors.New("대상 미존재")
+	ErrConflict = errors.New("데이터 충돌")
+
+	ErrUnauthorized = errors.New("인증 실패")
+	ErrForbidden    = errors.New("권한 없음")
+)
+
+// 필드별 유효성 검사 실패. Tag는 검사 규칙(required, category 등)
+type FieldError struct {
+	Field string
+	Tag   string
+	Value any
+}
+
+func (e FieldError) String() string {
+	if e.Tag == "required" {
+		return fmt.Sprintf("필수 필드 %s 누락", e.Field)
+	}
+	return fmt.Sprintf("%s 타입의 필드 %s가 올바르지 않음", e.Tag, e.Field)
+}
+
+// 요청 값 유효성 검사 실패. ErrInvalid로 분류
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.String()
+	}
+	return strings.Join(msgs, ", ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrInvalid
+}
@@ -0,0 +1,111 @@
+package model
+
+import "fmt"
+
+// 시장 지표 수집처
+type IndicatorProvider string
+
+const (
+	FearGreedProvider      IndicatorProvider = "fear_greed"    // CNN 공포 탐욕 지수. code 불필요
+	KisOverseasIdxProvider IndicatorProvider = "kis_overseas"  // KIS 해외 지수. code ex) COMP(나스닥), SPX(S&P500), VIX
+	KisOverseasFxProvider  IndicatorProvider = "kis_fx"        // KIS 해외 환율. code ex) FX@KRW
+	KisTreasuryProvider    IndicatorProvider = "kis_treasury"  // KIS 해외 국채 금리. code ex) Y0202(미국 10년물)
+	KisDomesticIdxProvider IndicatorProvider = "kis_domestic"  // KIS 국내 업종 지수. code ex) 0001(코스피), 1001(코스닥)
+	ExchangeRateProvider   IndicatorProvider = "exchange_rate" // 원/달러 환율 크롤링. code 불필요
+)
+
+func ToIndicatorProvider(s string) (IndicatorProvider, error) {
+	switch p := IndicatorProvider(s); p {
+	case FearGreedProvider, ExchangeRateProvider:
+		return p, nil
+	case KisOverseasIdxProvider, KisOverseasFxProvider, KisTreasuryProvider, KisDomesticIdxProvider:
+		return p, nil
+	}
+	return "", fmt.Errorf("올바르지 않은 지표 수집처. 입력 값 : %s", s)
+}
+
+// 수집처별 code 필요 여부
+func (p IndicatorProvider) NeedCode() bool {
+	return p != FearGreedProvider && p != ExchangeRateProvider
+}
+
+// 기존 DailyIndex 컬럼에 해당하는 기본 지표
+var DefaultIndicators = []Indicator{
+	{Name: "공포 탐욕 지수", Provider: FearGreedProvider, Spec: "0 3 9 * * 1-5", Active: true},
+	{Name: "Nasdaq", Provider: KisOverseasIdxProvider, Code: "COMP", Spec: "0 3 9 * * 1-5", Active: true},
+}
+
+/*
+지표 일별 등락
+  - Previous : 조회일 이전 가장 최근 값. 미존재 시 nil
+*/
+type IndicatorChange struct {
+	Indicator Indicator
+	Date      string
+	Value     float64
+	Previous  *float64
+}
+
+func (c IndicatorChange) Diff() float64 {
+	if c.Previous == nil {
+		return 0
+	}
+	return c.Value - *c.Previous
+}
+
+func (c IndicatorChange) Rate() float64 {
+	if c.Previous == nil || *c.Previous == 0 {
+		return 0
+	}
+	return (c.Value - *c.Previous) / *c.Previous * 100
+}
+
+// 지표 알림 조건
+type AlertKind string
+
+const (
+	AboveAlert      AlertKind = "above"       // 값 >= Threshold
+	BelowAlert      AlertKind = "below"       // 값 <= Threshold
+	ChangeAlert     AlertKind = "change"      // Days 영업일 전 대비 변동률(%). Threshold 음수면 하락, 양수면 상승
+	CrossAboveAlert AlertKind = "cross_above" // Period일 이동평균 상향 돌파
+	CrossBelowAlert AlertKind = "cross_below" // Period일 이동평균 하향 돌파
+)
+
+func ToAlertKind(s string) (AlertKind, error) {
+	switch k := AlertKind(s); k {
+	case AboveAlert, BelowAlert, ChangeAlert, CrossAboveAlert, CrossBelowAlert:
+		return k, nil
+	}
+	return "", fmt.Errorf("올바르지 않은 알림 조건. 입력 값 : %s", s)
+}
+
+// 조건 판단에 필요한 최근 값 개수 (현재 값 포함)
+func (a IndicatorAlert) Window() int {
+	switch a.Kind {
+	case ChangeAlert:
+		return a.Days + 1
+	case CrossAboveAlert, CrossBelowAlert:
+		return a.Period + 1
+	}
+	return 1
+}
+
+// 알림 조건 설명. ex) 20.00 이하, 1일 변동률 -3.00% 이하, 20일 이동평균 상향 돌파
+func (a IndicatorAlert) String() string {
+	switch a.Kind {
+	case AboveAlert:
+		return fmt.Sprintf("%.2f 이상", a.Threshold)
+	case BelowAlert:
+		return fmt.Sprintf("%.2f 이하", a.Threshold)
+	case ChangeAlert:
+		if a.Threshold < 0 {
+			return fmt.Sprintf("%d일 변동률 %.2f%% 이하", a.Days, a.Threshold)
+		}
+		return fmt.Sprintf("%d일 변동률 %+.2f%% 이상", a.Days, a.Threshold)
+	case CrossAboveAlert:
+		return fmt.Sprintf("%d일 이동평균 상향 돌파", a.Period)
+	case CrossBelowAlert:
+		return fmt.Sprintf("%d일 이동평균 하향 돌파", a.Period)
+	}
+	return string(a.Kind)
+}
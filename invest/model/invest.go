@@ -0,0 +1,80 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// 투자 이력 구분. 수량 양수면 매수, 음수면 매도
+type InvestSide string
+
+const (
+	BuySide  InvestSide = "buy"
+	SellSide InvestSide = "sell"
+)
+
+// 빈 값은 전체
+func ToInvestSide(s string) (InvestSide, error) {
+	switch side := InvestSide(s); side {
+	case "", BuySide, SellSide:
+		return side, nil
+	}
+	return "", fmt.Errorf("올바르지 않은 투자 구분. buy 혹은 sell. 입력 값 : %s", s)
+}
+
+// 투자 이력 정렬 기준
+type InvestSort string
+
+const (
+	SortCreatedAt InvestSort = "created_at"
+	SortPrice     InvestSort = "price"
+	SortAmount    InvestSort = "amount" // 거래 대금. 가격 * 수량 (매도는 음수)
+)
+
+// 빈 값은 created_at
+func ToInvestSort(s string) (InvestSort, error) {
+	switch sort := InvestSort(s); sort {
+	case "":
+		return SortCreatedAt, nil
+	case SortCreatedAt, SortPrice, SortAmount:
+		return sort, nil
+	}
+	return "", fmt.Errorf("올바르지 않은 정렬 기준. created_at, price, amount. 입력 값 : %s", s)
+}
+
+// 정렬 기준 값. created_at은 time.Time, 그 외 float64
+func (s InvestSort) Value(i Invest) any {
+	switch s {
+	case SortPrice:
+		return i.Price
+	case SortAmount:
+		return i.Price * i.Count
+	}
+	return i.CreatedAt
+}
+
+/*
+투자 이력 조회 조건
+  - 0, 빈 값인 조건은 미적용
+  - From 이상, To 미만
+  - After : 이전 페이지 마지막 이력의 정렬 값과 ID. 이후 이력부터 조회
+  - Limit : 최대 조회 건수
+*/
+type InvestQuery struct {
+	FundID   uint
+	AssetID  uint
+	Category Category
+	Side     InvestSide
+	From     time.Time
+	To       time.Time
+	Sort     InvestSort
+	Desc     bool
+	After    *InvestCursor
+	Limit    int
+}
+
+// 페이지 위치. 정렬 값이 같으면 ID 순
+type InvestCursor struct {
+	Value any
+	ID    uint
+}
@@ -0,0 +1,21 @@
+package model
+
+import "fmt"
+
+// 감시 대상 값 추출 방식
+type MonitorKind string
+
+const (
+	CssMonitor  MonitorKind = "css"  // HTML 페이지. CSS selector로 추출
+	JsonMonitor MonitorKind = "json" // JSON 응답. a.b[0].c 형태 경로로 추출
+)
+
+func ToMonitorKind(s string) (MonitorKind, error) {
+	switch k := MonitorKind(s); k {
+	case CssMonitor, JsonMonitor:
+		return k, nil
+	case "":
+		return CssMonitor, nil
+	}
+	return "", fmt.Errorf("올바르지 않은 감시 방식. 입력 값 : %s", s)
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+)
+
+// 매수/매도 우선순위 점수 산정 방식
+type ScoreStrategy string
+
+const (
+	Deviation ScoreStrategy = "deviation" // 이동평균/고점 대비 괴리율
+	RSI       ScoreStrategy = "rsi"       // 14일 RSI
+	ZScore    ScoreStrategy = "zscore"    // 20일 이동평균 대비 표준점수
+	Range52W  ScoreStrategy = "range52w"  // 52주 최저~최고 구간 내 위치
+)
+
+var ScoreStrategies = []ScoreStrategy{Deviation, RSI, ZScore, Range52W}
+
+// 자금별 가중치 설정이 없을 때 사용
+var DefaultScoreWeights = map[ScoreStrategy]float64{Deviation: 1}
+
+func ToScoreStrategy(s string) (ScoreStrategy, error) {
+	st := ScoreStrategy(s)
+	if !slices.Contains(ScoreStrategies, st) {
+		return "", fmt.Errorf("올바르지 않은 점수 산정 방식. 입력 값 : %s", s)
+	}
+	return st, nil
+}
+
+/*
+자산별 우선순위 점수. 점수가 클수록 고평가(매도 우선), 작을수록 저평가(매수 우선)
+Breakdown은 데이터가 있어 산정된 방식별 점수
+*/
+type AssetPriority struct {
+	Asset     Asset
+	Present   float64
+	Average   float64
+	Highest   float64
+	Score     float64
+	Breakdown map[ScoreStrategy]float64
+}
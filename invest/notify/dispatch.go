@@ -0,0 +1,253 @@
+package notify
+
+import (
+	"fmt"
+	"invest/bus"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 묶음 전송 이벤트 종류. 버스로 발행되지 않고 Dispatcher에서만 생성
+const KindBatch bus.Kind = "Batch"
+
+// 한 번에 전송되는 여러 이벤트. 심각도는 가장 높은 이벤트 기준
+type Batch []bus.Event
+
+func (b Batch) Kind() bus.Kind { return KindBatch }
+
+func (b Batch) Severity() bus.Severity {
+	var s bus.Severity
+	for _, e := range b {
+		s = max(s, e.Severity())
+	}
+	return s
+}
+
+func (b Batch) String() string {
+	texts := make([]string, len(b))
+	for i, e := range b {
+		texts[i] = e.String()
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+/*
+조용한 시간. 자정 기준 경과 시간
+  - Start > End 이면 자정을 넘는 구간. ex) 23:00-07:00
+*/
+type QuietHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// HH:MM-HH:MM 형식
+func ParseQuietHours(s string) (*QuietHours, error) {
+
+	start, end, ok := strings.Cut(s, "-")
+	if !ok {
+		return nil, fmt.Errorf("조용한 시간 형식 오류. HH:MM-HH:MM. 입력 값 : %s", s)
+	}
+
+	q := &QuietHours{}
+	for _, v := range []struct {
+		s string
+		d *time.Duration
+	}{{start, &q.Start}, {end, &q.End}} {
+		t, err := time.Parse("15:04", strings.TrimSpace(v.s))
+		if err != nil {
+			return nil, fmt.Errorf("조용한 시간 형식 오류. HH:MM-HH:MM. 입력 값 : %s", s)
+		}
+		*v.d = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	return q, nil
+}
+
+func (q QuietHours) Contains(t time.Time) bool {
+	d := sinceMidnight(t)
+	if q.Start <= q.End {
+		return q.Start <= d && d < q.End
+	}
+	return d >= q.Start || d < q.End
+}
+
+// 조용한 시간 종료까지 남은 시간
+func (q QuietHours) Until(t time.Time) time.Duration {
+	d := sinceMidnight(t)
+	if d < q.End {
+		return q.End - d
+	}
+	return 24*time.Hour - d + q.End
+}
+
+func sinceMidnight(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
+
+/*
+채널별 전송 정책
+  - Quiet : 조용한 시간. 해당 시간의 이벤트는 종료 후 묶어서 전송. nil이면 미적용
+  - Limit, Per : Per 동안 최대 Limit 건 전송. 초과분은 전송 가능 시점에 묶어서 전송. Limit 0이면 무제한
+  - Window : 첫 이벤트 후 대기 시간. 대기 중 들어온 이벤트를 한 메시지로 묶음. 0이면 즉시 전송
+  - Urgent : 일치하는 이벤트는 조용한 시간, 전송 한도, 묶음 대기 없이 즉시 전송. nil이면 미적용
+*/
+type Policy struct {
+	Quiet  *QuietHours
+	Limit  int
+	Per    time.Duration
+	Window time.Duration
+	Urgent *bus.Filter
+}
+
+func (p Policy) urgent(e bus.Event) bool {
+	return p.Urgent != nil && p.Urgent.Match(e)
+}
+
+/*
+Dispatcher
+정책에 따라 채널 전송 시점을 조정하는 Notifier
+  - 대기 후 전송 실패는 로그만 남김
+*/
+type Dispatcher struct {
+	n   Notifier
+	p   Policy
+	now func() time.Time
+
+	mu      sync.Mutex
+	pending []bus.Event
+	sent    []time.Time // Per 이내 전송 시각. Limit 설정 시에만 기록
+	timer   *time.Timer
+	closed  bool
+}
+
+func NewDispatcher(n Notifier, p Policy) *Dispatcher {
+	if p.Per == 0 {
+		p.Per = time.Minute
+	}
+	return &Dispatcher{n: n, p: p, now: time.Now}
+}
+
+func (d *Dispatcher) Name() string {
+	return d.n.Name()
+}
+
+func (d *Dispatcher) Notify(e bus.Event) error {
+
+	d.mu.Lock()
+	if d.closed || d.p.urgent(e) {
+		d.record(d.now())
+		d.mu.Unlock()
+		return d.n.Notify(e)
+	}
+
+	d.pending = append(d.pending, e)
+	if d.p.Window > 0 {
+		if d.timer == nil {
+			d.timer = time.AfterFunc(d.p.Window, d.flush)
+		}
+		d.mu.Unlock()
+		return nil
+	}
+	events := d.take()
+	d.mu.Unlock()
+
+	return d.send(events)
+}
+
+// 대기 중 이벤트 전송. 예약 시각 도래 시 호출
+func (d *Dispatcher) flush() {
+
+	d.mu.Lock()
+	d.timer = nil
+	events := d.take()
+	d.mu.Unlock()
+
+	err := d.send(events)
+	if err != nil {
+		log.Printf("[%s] 대기 알림 전송 실패. %s", d.Name(), err)
+	}
+}
+
+/*
+전송할 대기 이벤트. mu 잠금 상태에서 호출
+  - 조용한 시간, 전송 한도에 걸리면 가능 시점으로 재예약 후 nil
+*/
+func (d *Dispatcher) take() []bus.Event {
+
+	if len(d.pending) == 0 {
+		return nil
+	}
+
+	now := d.now()
+	wait := d.wait(now)
+	if wait > 0 {
+		if d.timer == nil {
+			d.timer = time.AfterFunc(wait, d.flush)
+		}
+		return nil
+	}
+
+	events := d.pending
+	d.pending = nil
+	d.record(now)
+	return events
+}
+
+// 전송 시각 기록. 전송 한도 판단에만 사용하므로 한도 미설정 시 미기록
+func (d *Dispatcher) record(at time.Time) {
+	if d.p.Limit > 0 {
+		d.sent = append(d.sent, at)
+	}
+}
+
+/*
+Close
+종료 시 대기 중 이벤트를 조용한 시간, 전송 한도와 무관하게 즉시 전송
+  - 이후 이벤트는 대기 없이 바로 전송
+*/
+func (d *Dispatcher) Close() error {
+
+	d.mu.Lock()
+	d.closed = true
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	events := d.pending
+	d.pending = nil
+	d.mu.Unlock()
+
+	return d.send(events)
+}
+
+// 전송 가능까지 남은 시간
+func (d *Dispatcher) wait(now time.Time) time.Duration {
+
+	if d.p.Quiet != nil && d.p.Quiet.Contains(now) {
+		return d.p.Quiet.Until(now)
+	}
+
+	if d.p.Limit > 0 {
+		i := 0
+		for i < len(d.sent) && !d.sent[i].After(now.Add(-d.p.Per)) {
+			i++
+		}
+		d.sent = d.sent[i:]
+		if len(d.sent) >= d.p.Limit {
+			return d.sent[len(d.sent)-d.p.Limit].Add(d.p.Per).Sub(now)
+		}
+	}
+	return 0
+}
+
+// 여러 건이면 한 메시지로 묶어서 전송
+func (d *Dispatcher) send(events []bus.Event) error {
+	switch len(events) {
+	case 0:
+		return nil
+	case 1:
+		return d.n.Notify(events[0])
+	}
+	return d.n.Notify(Batch(events))
+}
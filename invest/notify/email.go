@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"encoding/base64"
+	"fmt"
+	"invest/bus"
+	"invest/render"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTP 메일. 제목은 [invest][심각도] {이벤트 제목}
+type Email struct {
+	name string
+	addr string
+	auth smtp.Auth
+	from string
+	to   []string
+	v    View
+}
+
+/*
+NewEmail
+  - user 미설정 시 인증 없이 전송
+  - 인증 시 TLS(STARTTLS) 필요. localhost는 예외
+  - HTML 템플릿이 있으면 일반 텍스트와 HTML 본문을 함께 전송(multipart/alternative)
+*/
+func NewEmail(name string, host string, port string, user string, pwd string, from string, to []string, v View) *Email {
+
+	e := &Email{
+		name: name,
+		addr: net.JoinHostPort(host, port),
+		from: from,
+		to:   to,
+		v:    v,
+	}
+	if user != "" {
+		e.auth = smtp.PlainAuth("", user, pwd, host)
+	}
+	return e
+}
+
+func (e Email) Name() string {
+	return e.name
+}
+
+func (e Email) Notify(ev bus.Event) error {
+
+	err := smtp.SendMail(e.addr, e.auth, e.from, e.to, e.mail(ev))
+	if err != nil {
+		return fmt.Errorf("SendMail 시 오류 발생. %w", err)
+	}
+	return nil
+}
+
+// 본문은 UTF-8 base64 인코딩
+func (e Email) mail(ev bus.Event) []byte {
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("From: %s\r\n", e.from))
+	sb.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(e.to, ", ")))
+	sb.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", e.v.subject(ev))))
+	sb.WriteString("MIME-Version: 1.0\r\n")
+
+	html, ok := e.v.render(render.HTML, ev)
+	if !ok {
+		writePart(&sb, "text/plain", e.v.plain(ev))
+		return []byte(sb.String())
+	}
+
+	boundary := fmt.Sprintf("invest-%d", time.Now().UnixNano())
+	sb.WriteString(fmt.Sprintf("Content-Type: multipart/alternative; boundary=\"%s\"\r\n\r\n", boundary))
+	sb.WriteString("--" + boundary + "\r\n")
+	writePart(&sb, "text/plain", e.v.plain(ev))
+	sb.WriteString("--" + boundary + "\r\n")
+	writePart(&sb, "text/html", html)
+	sb.WriteString("--" + boundary + "--\r\n")
+
+	return []byte(sb.String())
+}
+
+// Content-Type 헤더와 76자 단위 base64 본문
+func writePart(sb *strings.Builder, contentType string, body string) {
+
+	sb.WriteString(fmt.Sprintf("Content-Type: %s; charset=UTF-8\r\n", contentType))
+	sb.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	enc := base64.StdEncoding.EncodeToString([]byte(body))
+	for len(enc) > 76 {
+		sb.WriteString(enc[:76] + "\r\n")
+		enc = enc[76:]
+	}
+	sb.WriteString(enc + "\r\n")
+}
@@ -0,0 +1,45 @@
+package notify
+
+import "invest/bus"
+
+type NotifierMock struct {
+	name string
+	sent *[]bus.Event
+	err  error
+}
+
+func (m NotifierMock) Name() string {
+	return m.name
+}
+
+func (m NotifierMock) Notify(e bus.Event) error {
+	if m.err != nil {
+		return m.err
+	}
+	*m.sent = append(*m.sent, e)
+	return nil
+}
+
+type SenderMock struct {
+	sent     *[]string
+	markdown *[]string
+	photos   *[]string // 사진 설명
+	err      error     // MarkdownV2 전송 오류
+}
+
+func (m SenderMock) SendMessage(msg string) error {
+	*m.sent = append(*m.sent, msg)
+	return nil
+}
+
+func (m SenderMock) SendMarkdown(msg string) error {
+	if m.err != nil {
+		return m.err
+	}
+	*m.markdown = append(*m.markdown, msg)
+	return nil
+}
+
+func (m SenderMock) SendPhoto(img []byte, caption string) {
+	*m.photos = append(*m.photos, caption)
+}
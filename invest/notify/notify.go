@@ -0,0 +1,9 @@
+package notify
+
+import "invest/bus"
+
+// 알림 채널. 채널별 렌더러로 이벤트를 변환하여 전송
+type Notifier interface {
+	Name() string
+	Notify(e bus.Event) error
+}
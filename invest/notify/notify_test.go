@@ -0,0 +1,511 @@
+package notify
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"invest/bus"
+	m "invest/model"
+	"invest/render"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	netmail "net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	buy      = bus.PriceAlert{Asset: m.Asset{ID: 1, Name: "삼성전자"}, Bound: 70000, Price: 69000}
+	failure  = bus.JobError{Job: "AssetEvent", Step: "RetrieveAssetList", Message: "timeout"}
+	digest   = bus.Digest{Report: &m.DigestReport{Spec: m.DigestSpec{Kind: m.DailyDigest}}}
+	monitor  = bus.MonitorChange{Monitor: m.Monitor{Name: "공지", Url: "https://example.com"}, Value: "<B>", Previous: "A", Changed: true, Diff: "- A\n+ <B>"}
+	balanced = bus.PortfolioImbalance{FundID: 1} // 범위 내. 매수 우선순위 안내
+)
+
+func TestRouter(t *testing.T) {
+
+	tg, slack, mail := &[]bus.Event{}, &[]bus.Event{}, &[]bus.Event{}
+	channels := []Notifier{
+		NotifierMock{name: "telegram", sent: tg},
+		NotifierMock{name: "slack", sent: slack},
+		NotifierMock{name: "mail", sent: mail},
+	}
+	reset := func() { *tg, *slack, *mail = nil, nil, nil }
+
+	t.Run("규칙 일치 채널 전송", func(t *testing.T) {
+		reset()
+		r, err := NewRouter(channels, []Rule{
+			{Filter: bus.Filter{Kinds: []bus.Kind{bus.KindPriceAlert, bus.KindJobError}}, Channels: []string{"telegram", "slack"}},
+			{Filter: bus.Filter{Kinds: []bus.Kind{bus.KindPriceAlert, bus.KindDigest}}, Channels: []string{"mail", "slack"}},
+		}, []string{"telegram"})
+		assert.NoError(t, err)
+
+		assert.NoError(t, r.Send(buy))
+		assert.Len(t, *tg, 1)
+		assert.Len(t, *slack, 1) // 중복 규칙이어도 한 번만 전송
+		assert.Len(t, *mail, 1)
+
+		assert.NoError(t, r.Send(digest))
+		assert.Len(t, *tg, 1)
+		assert.Len(t, *mail, 2)
+	})
+
+	t.Run("심각도 기준 전송", func(t *testing.T) {
+		reset()
+		r, _ := NewRouter(channels, []Rule{{Filter: bus.Filter{Severity: bus.Warning}, Channels: []string{"slack"}}}, []string{"telegram"})
+
+		assert.NoError(t, r.Send(failure))
+		assert.NoError(t, r.Send(buy))
+		assert.NoError(t, r.Send(balanced))
+		assert.Len(t, *slack, 2)
+		assert.Equal(t, []bus.Event{balanced}, *tg)
+	})
+
+	t.Run("일치 규칙 없으면 기본 채널", func(t *testing.T) {
+		reset()
+		r, _ := NewRouter(channels, []Rule{{Filter: bus.Filter{Kinds: []bus.Kind{bus.KindPriceAlert}}, Channels: []string{"slack"}}}, []string{"mail"})
+
+		assert.NoError(t, r.Send(monitor))
+		assert.Empty(t, *tg)
+		assert.Empty(t, *slack)
+		assert.Len(t, *mail, 1)
+	})
+
+	t.Run("기본 채널 미설정 시 전체 채널", func(t *testing.T) {
+		reset()
+		r, _ := NewRouter(channels, nil, nil)
+
+		assert.NoError(t, r.Send(monitor))
+		assert.Len(t, *tg, 1)
+		assert.Len(t, *slack, 1)
+		assert.Len(t, *mail, 1)
+	})
+
+	t.Run("일부 채널 실패 시 나머지 채널 전송", func(t *testing.T) {
+		reset()
+		r, _ := NewRouter([]Notifier{
+			NotifierMock{name: "telegram", sent: tg},
+			NotifierMock{name: "slack", err: errors.New("timeout")},
+			NotifierMock{name: "mail", sent: mail},
+		}, nil, nil)
+
+		err := r.Send(buy)
+		assert.ErrorContains(t, err, "slack 채널 전송 실패. timeout")
+		assert.Len(t, *tg, 1)
+		assert.Len(t, *mail, 1)
+	})
+
+	t.Run("종료 시 대기 중 알림 전송", func(t *testing.T) {
+		reset()
+		d := NewDispatcher(NotifierMock{name: "telegram", sent: tg}, Policy{Window: time.Hour})
+		r, _ := NewRouter([]Notifier{d, NotifierMock{name: "slack", sent: slack}}, nil, nil)
+
+		assert.NoError(t, r.Send(buy))
+		assert.Empty(t, *tg)
+		assert.Len(t, *slack, 1)
+
+		assert.NoError(t, r.Close())
+		assert.Equal(t, []bus.Event{buy}, *tg)
+	})
+
+	t.Run("설정 오류", func(t *testing.T) {
+		_, err := NewRouter(channels, []Rule{{Channels: []string{"discord"}}}, nil)
+		assert.ErrorContains(t, err, "존재하지 않는 알림 채널. discord")
+
+		_, err = NewRouter(channels, nil, []string{"sms"})
+		assert.ErrorContains(t, err, "존재하지 않는 알림 채널. sms")
+
+		_, err = NewRouter(append(channels, NotifierMock{name: "slack"}), nil, nil)
+		assert.ErrorContains(t, err, "알림 채널 이름 중복. slack")
+	})
+}
+
+func TestRender(t *testing.T) {
+
+	t.Run("채널별 렌더링", func(t *testing.T) {
+		v := View{}
+		assert.Equal(t, "매수 삼성전자. ID : 1. 하한 : 70000.00. 현재가 : 69000.00", v.plain(buy))
+		assert.Equal(t, "*감시 대상 변동. 공지*\n[공지] 변동 사항 존재.\n- A\n+ &lt;B&gt;\nhttps://example.com", v.slackText(monitor))
+		assert.Equal(t, "**작업 오류 (AssetEvent)**\n[AssetEvent] RetrieveAssetList 시, 에러 발생. timeout", v.discordText(failure))
+		assert.Equal(t, "[invest][주의] 매수 기준가 도달", v.subject(buy))
+		assert.Equal(t, "[invest][안내] 정기 리포트", v.subject(digest))
+	})
+
+	t.Run("채널 언어", func(t *testing.T) {
+		v := View{Locale: render.English}
+		assert.Equal(t, "BUY 삼성전자. ID : 1. LOWER BOUND : 70000.00. CURRENT PRICE : 69000.00", v.plain(buy))
+		assert.Equal(t, "[invest][Warning] Buy price reached", v.subject(buy))
+		assert.Equal(t, "[invest][Error] Job failed (AssetEvent)", v.subject(failure))
+		assert.Equal(t, "[AssetEvent] RetrieveAssetList failed. timeout", v.plain(failure))
+	})
+}
+
+func TestTelegram(t *testing.T) {
+
+	t.Run("일반 텍스트", func(t *testing.T) {
+		sent, markdown := &[]string{}, &[]string{}
+		n := NewTelegram(SenderMock{sent: sent, markdown: markdown}, View{}, false)
+
+		assert.NoError(t, n.Notify(failure))
+		assert.Equal(t, []string{"[AssetEvent] RetrieveAssetList 시, 에러 발생. timeout"}, *sent)
+		assert.Empty(t, *markdown)
+	})
+
+	t.Run("MarkdownV2", func(t *testing.T) {
+		sent, markdown := &[]string{}, &[]string{}
+		n := NewTelegram(SenderMock{sent: sent, markdown: markdown}, View{}, true)
+
+		assert.NoError(t, n.Notify(failure))
+		assert.Equal(t, []string{"*작업 오류* \\(AssetEvent\\)\nRetrieveAssetList 시, 에러 발생\\. timeout"}, *markdown)
+		assert.Empty(t, *sent)
+	})
+
+	t.Run("MarkdownV2 전송 실패 시 일반 텍스트 재전송", func(t *testing.T) {
+		sent, markdown := &[]string{}, &[]string{}
+		n := NewTelegram(SenderMock{sent: sent, markdown: markdown, err: errors.New("can't parse entities")}, View{}, true)
+
+		assert.NoError(t, n.Notify(buy))
+		assert.Equal(t, []string{"매수 삼성전자. ID : 1. 하한 : 70000.00. 현재가 : 69000.00"}, *sent)
+	})
+
+	t.Run("묶음 대기 후 리포트 문구 다음 차트 전송", func(t *testing.T) {
+		sent, markdown, photos := &[]string{}, &[]string{}, &[]string{}
+		n := NewTelegram(SenderMock{sent: sent, markdown: markdown, photos: photos}, View{}, false)
+		d := NewDispatcher(n, Policy{Window: time.Hour})
+
+		withCharts := digest
+		withCharts.Charts = []m.Chart{{Caption: "변동 자산 비율"}, {Caption: "자금 1 비중"}}
+		assert.NoError(t, d.Notify(buy))
+		assert.NoError(t, d.Notify(withCharts))
+		assert.Empty(t, *sent)
+		assert.Empty(t, *photos) // 차트도 리포트와 함께 대기
+
+		assert.NoError(t, d.Close())
+		assert.Len(t, *sent, 1)
+		assert.Contains(t, (*sent)[0], "일간 리포트")
+		assert.Equal(t, []string{"변동 자산 비율", "자금 1 비중"}, *photos)
+	})
+}
+
+func TestWebhook(t *testing.T) {
+
+	var body map[string]any
+	var header http.Header
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.WriteHeader(status)
+		io.WriteString(w, "invalid_payload")
+	}))
+	defer server.Close()
+
+	t.Run("Slack", func(t *testing.T) {
+		assert.NoError(t, NewSlack("slack", server.URL, View{}).Notify(buy))
+		assert.Equal(t, map[string]any{"text": "*매수 기준가 도달*\n매수 삼성전자. ID : 1. 하한 : 70000.00. 현재가 : 69000.00"}, body)
+		assert.Equal(t, "application/json", header.Get("Content-Type"))
+	})
+
+	t.Run("Discord 최대 길이 초과분 생략", func(t *testing.T) {
+		assert.NoError(t, NewDiscord("discord", server.URL, View{}).Notify(bus.Digest{Report: &m.DigestReport{Errors: []m.DigestError{{Step: "RetrieveLatestDigestHist", Message: strings.Repeat("가", discordLimit+10)}}}}))
+		assert.Equal(t, discordLimit, len([]rune(body["content"].(string))))
+	})
+
+	t.Run("JSON 웹훅", func(t *testing.T) {
+		n := NewWebhook("hook", server.URL, map[string]string{"Authorization": "Bearer token"}, View{})
+		assert.Equal(t, "hook", n.Name())
+		assert.NoError(t, n.Notify(failure))
+		assert.Equal(t, "JobError", body["kind"])
+		assert.Equal(t, "error", body["severity"])
+		assert.Equal(t, "[AssetEvent] RetrieveAssetList 시, 에러 발생. timeout", body["text"])
+		assert.Equal(t, map[string]any{"Job": "AssetEvent", "Step": "RetrieveAssetList", "Target": "", "Message": "timeout"}, body["data"])
+		assert.NotEmpty(t, body["time"])
+		assert.Equal(t, "Bearer token", header.Get("Authorization"))
+	})
+
+	t.Run("응답 코드 오류", func(t *testing.T) {
+		status = http.StatusBadRequest
+		defer func() { status = http.StatusOK }()
+
+		err := NewSlack("slack", server.URL, View{}).Notify(buy)
+		assert.ErrorContains(t, err, "응답 코드 400. invalid_payload")
+	})
+}
+
+func TestEmail(t *testing.T) {
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	received := make(chan smtpMail, 1)
+	go serveSMTP(l, received)
+
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	n := NewEmail("mail", host, port, "", "", "invest@example.com", []string{"a@example.com", "b@example.com"}, View{})
+
+	t.Run("메일 전송", func(t *testing.T) {
+		err := n.Notify(monitor)
+		assert.NoError(t, err)
+
+		mail := <-received
+		assert.Equal(t, "invest@example.com", mail.from)
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, mail.to)
+		assert.Contains(t, mail.data, "To: a@example.com, b@example.com")
+
+		msg, err := netmail.ReadMessage(strings.NewReader(mail.data))
+		assert.NoError(t, err)
+		subj, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		assert.NoError(t, err)
+		assert.Equal(t, "[invest][주의] 감시 대상 변동. 공지", subj)
+
+		// 일반 텍스트, HTML 본문
+		mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		assert.NoError(t, err)
+		assert.Equal(t, "multipart/alternative", mediaType)
+
+		parts := map[string]string{}
+		mr := multipart.NewReader(msg.Body, params["boundary"])
+		for {
+			p, err := mr.NextRawPart()
+			if err != nil {
+				break
+			}
+			b, _ := io.ReadAll(p)
+			text, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(string(b), "\r\n", ""))
+			assert.NoError(t, err)
+			ct, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+			parts[ct] = string(text)
+		}
+		assert.Equal(t, "[공지] 변동 사항 존재.\n- A\n+ <B>\nhttps://example.com", parts["text/plain"])
+		assert.Contains(t, parts["text/html"], "&lt;B&gt;</pre>") // 값은 HTML escape
+		assert.Contains(t, parts["text/html"], `<a href="https://example.com">`)
+	})
+
+	t.Run("서버 연결 실패", func(t *testing.T) {
+		err := NewEmail("mail", "127.0.0.1", "1", "", "", "invest@example.com", []string{"a@example.com"}, View{}).Notify(buy)
+		assert.ErrorContains(t, err, "SendMail 시 오류 발생")
+	})
+}
+
+func TestDispatcher(t *testing.T) {
+
+	at := func(h, m int) func() time.Time {
+		return func() time.Time { return time.Date(2024, 12, 2, h, m, 0, 0, time.Local) }
+	}
+	dispatcher := func(p Policy, now func() time.Time) (*Dispatcher, *[]bus.Event) {
+		sent := &[]bus.Event{}
+		d := NewDispatcher(NotifierMock{name: "telegram", sent: sent}, p)
+		d.now = now
+		t.Cleanup(func() {
+			if d.timer != nil {
+				d.timer.Stop()
+			}
+		})
+		return d, sent
+	}
+
+	t.Run("정책 미설정 시 즉시 전송", func(t *testing.T) {
+		d, sent := dispatcher(Policy{}, at(12, 0))
+		assert.Equal(t, "telegram", d.Name())
+		assert.NoError(t, d.Notify(buy))
+		assert.Equal(t, []bus.Event{buy}, *sent)
+	})
+
+	t.Run("대기 중 이벤트 묶음 전송", func(t *testing.T) {
+		d, sent := dispatcher(Policy{Window: time.Hour}, at(12, 0))
+		assert.NoError(t, d.Notify(buy))
+		assert.NoError(t, d.Notify(monitor))
+		assert.Empty(t, *sent)
+
+		d.flush()
+		assert.Equal(t, []bus.Event{Batch{buy, monitor}}, *sent)
+		assert.Equal(t, bus.Warning, (*sent)[0].Severity())
+		assert.Empty(t, d.sent) // 전송 한도 미설정 시 전송 시각 미기록
+	})
+
+	t.Run("조용한 시간 보류, 긴급 이벤트 즉시 전송", func(t *testing.T) {
+		quiet, _ := ParseQuietHours("23:00-07:00")
+		d, sent := dispatcher(Policy{Quiet: quiet, Urgent: &bus.Filter{Severity: bus.Error}}, at(23, 30))
+
+		assert.NoError(t, d.Notify(buy))
+		assert.NoError(t, d.Notify(failure))
+		assert.Equal(t, []bus.Event{failure}, *sent)
+		assert.NotNil(t, d.timer) // 조용한 시간 종료 시 전송 예약
+
+		d.now = at(7, 0)
+		d.flush()
+		assert.Equal(t, []bus.Event{failure, buy}, *sent)
+	})
+
+	t.Run("전송 한도 초과분은 가능 시점에 묶음 전송", func(t *testing.T) {
+		d, sent := dispatcher(Policy{Limit: 2, Per: time.Minute}, at(12, 0))
+		for _, e := range []bus.Event{buy, monitor, digest, balanced} {
+			assert.NoError(t, d.Notify(e))
+		}
+		assert.Equal(t, []bus.Event{buy, monitor}, *sent)
+		assert.Equal(t, time.Minute, d.wait(d.now()))
+
+		d.now = at(12, 1)
+		d.flush()
+		assert.Equal(t, []bus.Event{buy, monitor, Batch{digest, balanced}}, *sent)
+	})
+
+	t.Run("종료 시 보류 이벤트 즉시 전송", func(t *testing.T) {
+		quiet, _ := ParseQuietHours("23:00-07:00")
+		d, sent := dispatcher(Policy{Quiet: quiet, Window: time.Hour}, at(23, 30))
+		assert.NoError(t, d.Notify(buy))
+		assert.NoError(t, d.Notify(monitor))
+		assert.Empty(t, *sent)
+
+		assert.NoError(t, d.Close())
+		assert.Equal(t, []bus.Event{Batch{buy, monitor}}, *sent)
+		assert.Nil(t, d.timer)
+
+		assert.NoError(t, d.Notify(digest)) // 종료 후 이벤트는 대기 없이 전송
+		assert.Equal(t, []bus.Event{Batch{buy, monitor}, digest}, *sent)
+	})
+}
+
+func TestQuietHours(t *testing.T) {
+
+	at := func(h, m int) time.Time { return time.Date(2024, 12, 2, h, m, 0, 0, time.Local) }
+
+	t.Run("자정을 넘는 구간", func(t *testing.T) {
+		q, err := ParseQuietHours("23:00-07:00")
+		assert.NoError(t, err)
+		assert.True(t, q.Contains(at(23, 0)))
+		assert.True(t, q.Contains(at(3, 0)))
+		assert.False(t, q.Contains(at(7, 0)))
+		assert.False(t, q.Contains(at(12, 0)))
+		assert.Equal(t, 7*time.Hour+30*time.Minute, q.Until(at(23, 30)))
+		assert.Equal(t, time.Hour, q.Until(at(6, 0)))
+	})
+
+	t.Run("같은 날 구간", func(t *testing.T) {
+		q, _ := ParseQuietHours("12:00-13:00")
+		assert.True(t, q.Contains(at(12, 30)))
+		assert.False(t, q.Contains(at(13, 0)))
+		assert.Equal(t, 30*time.Minute, q.Until(at(12, 30)))
+	})
+
+	t.Run("형식 오류", func(t *testing.T) {
+		_, err := ParseQuietHours("23:00")
+		assert.ErrorContains(t, err, "조용한 시간 형식 오류")
+		_, err = ParseQuietHours("25:00-07:00")
+		assert.ErrorContains(t, err, "조용한 시간 형식 오류")
+	})
+}
+
+func TestBatch(t *testing.T) {
+
+	b := Batch{buy, failure}
+	v := View{}
+
+	t.Run("묶음 렌더링", func(t *testing.T) {
+		assert.Equal(t, "[invest][오류] 알림 2건", v.subject(b))
+		assert.Equal(t, "매수 삼성전자. ID : 1. 하한 : 70000.00. 현재가 : 69000.00\n\n[AssetEvent] RetrieveAssetList 시, 에러 발생. timeout", v.plain(b))
+
+		html, ok := v.render(render.HTML, b)
+		assert.True(t, ok)
+		assert.Contains(t, html, "<hr>")
+	})
+
+	t.Run("텔레그램 최대 길이 초과 시 나눠서 전송", func(t *testing.T) {
+		long := make(Batch, 100)
+		for i := range long {
+			long[i] = failure
+		}
+
+		sent, markdown := &[]string{}, &[]string{}
+		assert.NoError(t, NewTelegram(SenderMock{sent: sent, markdown: markdown}, v, false).Notify(long))
+		assert.Greater(t, len(*sent), 1)
+		count := 0
+		for _, msg := range *sent {
+			assert.LessOrEqual(t, len([]rune(msg)), telegramLimit)
+			count += strings.Count(msg, "[AssetEvent]")
+		}
+		assert.Equal(t, len(long), count) // 누락 없음
+
+		sent, markdown = &[]string{}, &[]string{}
+		assert.NoError(t, NewTelegram(SenderMock{sent: sent, markdown: markdown}, v, true).Notify(long))
+		assert.Greater(t, len(*markdown), 1)
+		for _, msg := range *markdown {
+			assert.LessOrEqual(t, len([]rune(msg)), telegramLimit)
+		}
+		assert.Empty(t, *sent)
+	})
+}
+
+type smtpMail struct {
+	from string
+	to   []string
+	data string
+}
+
+// 테스트용 SMTP 서버. 연결마다 메일 한 건 수신
+func serveSMTP(l net.Listener, received chan<- smtpMail) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer conn.Close()
+			r := bufio.NewReader(conn)
+			reply := func(s string) { io.WriteString(conn, s+"\r\n") }
+
+			var mail smtpMail
+			reply("220 localhost ESMTP")
+			for {
+				line, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				line = strings.TrimRight(line, "\r\n")
+				cmd := strings.ToUpper(line)
+
+				switch {
+				case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+					reply("250 localhost")
+				case strings.HasPrefix(cmd, "MAIL FROM:"):
+					mail.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+					reply("250 OK")
+				case strings.HasPrefix(cmd, "RCPT TO:"):
+					mail.to = append(mail.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+					reply("250 OK")
+				case cmd == "DATA":
+					reply("354 End data with <CR><LF>.<CR><LF>")
+					var sb strings.Builder
+					for {
+						l, err := r.ReadString('\n')
+						if err != nil || l == ".\r\n" {
+							break
+						}
+						sb.WriteString(l)
+					}
+					mail.data = sb.String()
+					received <- mail
+					reply("250 OK")
+				case cmd == "QUIT":
+					reply("221 Bye")
+					return
+				default:
+					reply("250 OK")
+				}
+			}
+		}()
+	}
+}
@@ -0,0 +1,119 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"invest/bus"
+	"io"
+	"slices"
+)
+
+// 이벤트가 Filter에 일치하면 Channels로 전송
+type Rule struct {
+	Filter   bus.Filter
+	Channels []string
+}
+
+/*
+라우터
+  - 일치하는 규칙의 채널 모두에 한 번씩 전송
+  - 일치하는 규칙이 없으면 기본 채널로 전송. 기본 채널 미설정 시 전체 채널
+*/
+type Router struct {
+	channels map[string]Notifier
+	order    []string
+	rules    []Rule
+	fallback []string
+}
+
+func NewRouter(channels []Notifier, rules []Rule, fallback []string) (*Router, error) {
+
+	r := &Router{
+		channels: make(map[string]Notifier, len(channels)),
+		rules:    rules,
+		fallback: fallback,
+	}
+	for _, n := range channels {
+		if _, ok := r.channels[n.Name()]; ok {
+			return nil, fmt.Errorf("알림 채널 이름 중복. %s", n.Name())
+		}
+		r.channels[n.Name()] = n
+		r.order = append(r.order, n.Name())
+	}
+
+	names := slices.Clone(fallback)
+	for _, rule := range rules {
+		names = append(names, rule.Channels...)
+	}
+	for _, name := range names {
+		if _, ok := r.channels[name]; !ok {
+			return nil, fmt.Errorf("존재하지 않는 알림 채널. %s", name)
+		}
+	}
+
+	return r, nil
+}
+
+// 채널별 전송. 일부 채널 실패 시에도 나머지 채널 전송 후 실패 내역 반환
+func (r *Router) Send(e bus.Event) error {
+
+	errs := make([]error, 0)
+	for _, name := range r.targets(e) {
+		err := r.channels[name].Notify(e)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s 채널 전송 실패. %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// 전송 대상 채널. 등록 순서 유지
+func (r *Router) targets(e bus.Event) []string {
+
+	matched := make(map[string]bool)
+	for _, rule := range r.rules {
+		if rule.Filter.Match(e) {
+			for _, c := range rule.Channels {
+				matched[c] = true
+			}
+		}
+	}
+
+	if len(matched) == 0 {
+		if len(r.fallback) == 0 {
+			return r.order
+		}
+		for _, c := range r.fallback {
+			matched[c] = true
+		}
+	}
+
+	rtn := make([]string, 0, len(matched))
+	for _, name := range r.order {
+		if matched[name] {
+			rtn = append(rtn, name)
+		}
+	}
+	return rtn
+}
+
+/*
+Close
+대기 중 알림이 있는 채널(Dispatcher 등) 전송 마무리
+  - 일부 채널 실패 시에도 나머지 채널 처리 후 실패 내역 반환
+*/
+func (r *Router) Close() error {
+
+	errs := make([]error, 0)
+	for _, name := range r.order {
+		c, ok := r.channels[name].(io.Closer)
+		if !ok {
+			continue
+		}
+		err := c.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s 채널 종료 실패. %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
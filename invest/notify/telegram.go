@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"errors"
+	"invest/bus"
+	"invest/render"
+	"log"
+)
+
+const telegramLimit = 4096 // 텔레그램 메시지 최대 길이
+
+type messageSender interface {
+	SendMessage(msg string) error
+	SendMarkdown(msg string) error
+	SendPhoto(img []byte, caption string)
+}
+
+// 텔레그램. 봇 설정 chatId로 전송
+type Telegram struct {
+	s        messageSender
+	v        View
+	markdown bool
+}
+
+/*
+NewTelegram
+  - markdown : MarkdownV2 템플릿으로 전송. 템플릿이 없거나 전송 실패 시 일반 텍스트로 재전송
+*/
+func NewTelegram(s messageSender, v View, markdown bool) *Telegram {
+	return &Telegram{s: s, v: v, markdown: markdown}
+}
+
+func (t Telegram) Name() string {
+	return "telegram"
+}
+
+/*
+Notify
+  - 묶음(Batch)이 최대 길이를 넘으면 길이 이내로 나눠서 여러 메시지로 전송
+  - 리포트 차트는 문구 전송 후 사진으로 전송
+*/
+func (t Telegram) Notify(e bus.Event) error {
+
+	b, ok := e.(Batch)
+	if !ok {
+		err := t.send(e)
+		t.photos(Batch{e})
+		return err
+	}
+
+	var errs []error
+	for _, part := range t.split(b) {
+		err := t.send(part)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	t.photos(b)
+	return errors.Join(errs...)
+}
+
+func (t Telegram) photos(b Batch) {
+	for _, e := range b {
+		if d, ok := e.(bus.Digest); ok {
+			for _, ct := range d.Charts {
+				t.s.SendPhoto(ct.Image, ct.Caption)
+			}
+		}
+	}
+}
+
+// 최대 길이 이내 묶음으로 분할. 한 건만 남으면 묶지 않음
+func (t Telegram) split(b Batch) []bus.Event {
+
+	parts := make([]bus.Event, 0)
+	var cur Batch
+	for _, e := range b {
+		next := append(cur[:len(cur):len(cur)], e)
+		if len(cur) > 0 && !t.fits(next) {
+			parts = append(parts, single(cur))
+			next = Batch{e}
+		}
+		cur = next
+	}
+	if len(cur) > 0 {
+		parts = append(parts, single(cur))
+	}
+	return parts
+}
+
+func single(b Batch) bus.Event {
+	if len(b) == 1 {
+		return b[0]
+	}
+	return b
+}
+
+// 전송할 서식(MarkdownV2, 재전송 시 일반 텍스트) 모두 최대 길이 이내
+func (t Telegram) fits(e bus.Event) bool {
+	if len([]rune(t.v.plain(e))) > telegramLimit {
+		return false
+	}
+	if t.markdown {
+		if msg, ok := t.v.render(render.Markdown, e); ok && len([]rune(msg)) > telegramLimit {
+			return false
+		}
+	}
+	return true
+}
+
+func (t Telegram) send(e bus.Event) error {
+
+	if t.markdown {
+		if msg, ok := t.v.render(render.Markdown, e); ok {
+			err := t.s.SendMarkdown(msg)
+			if err == nil {
+				return nil
+			}
+			log.Printf("[telegram] MarkdownV2 전송 실패. 일반 텍스트로 재전송. %s", err)
+		}
+	}
+
+	// 한 건이 최대 길이 초과 시 뒷부분 생략
+	text := []rune(t.v.plain(e))
+	if len(text) > telegramLimit {
+		text = text[:telegramLimit]
+	}
+	return t.s.SendMessage(string(text))
+}
@@ -0,0 +1,109 @@
+package notify
+
+import (
+	"fmt"
+	"invest/bus"
+	"invest/render"
+	"log"
+	"strings"
+)
+
+/*
+View
+채널별 문구 템플릿과 언어
+  - Templates 미설정 시 기본 템플릿(render.Default)
+  - Locale 미설정 시 한국어
+*/
+type View struct {
+	Templates *render.Templates
+	Locale    render.Locale
+}
+
+func (v View) templates() *render.Templates {
+	if v.Templates == nil {
+		return render.Default()
+	}
+	return v.Templates
+}
+
+func (v View) locale() render.Locale {
+	if v.Locale == "" {
+		return render.Korean
+	}
+	return v.Locale
+}
+
+/*
+서식별 본문
+  - ok : 해당 서식 템플릿으로 작성 여부. 템플릿이 없거나 실패하면 false
+*/
+func (v View) render(variant render.Variant, e bus.Event) (string, bool) {
+
+	if b, ok := e.(Batch); ok {
+		texts := make([]string, len(b))
+		for i, e := range b {
+			texts[i], ok = v.render(variant, e)
+			if !ok {
+				return "", false
+			}
+		}
+		return strings.Join(texts, batchSeparators[variant]), true
+	}
+
+	s, err := v.templates().Render(v.locale(), variant, string(e.Kind()), e)
+	if err != nil {
+		if variant == render.Plain {
+			log.Println(err)
+		}
+		return "", false
+	}
+	return s, true
+}
+
+// 묶음 전송 시 이벤트 구분
+var batchSeparators = map[render.Variant]string{
+	render.Plain:    "\n\n",
+	render.Markdown: "\n\n",
+	render.HTML:     "\n<hr>\n",
+}
+
+// 일반 텍스트 본문. 템플릿 실패 시 기본 문구
+func (v View) plain(e bus.Event) string {
+	if s, ok := v.render(render.Plain, e); ok {
+		return s
+	}
+	return e.String()
+}
+
+// 메일 제목, Slack/Discord 강조 줄
+func (v View) title(e bus.Event) string {
+	s, err := v.templates().Render(v.locale(), render.Plain, string(e.Kind())+".title", e)
+	if err != nil {
+		return string(e.Kind())
+	}
+	return s
+}
+
+func (v View) severity(e bus.Event) string {
+	s, err := v.templates().Render(v.locale(), render.Plain, "severity", e.Severity().String())
+	if err != nil {
+		return e.Severity().String()
+	}
+	return s
+}
+
+// Slack mrkdwn. 제목 굵게, 특수 문자 escape
+func (v View) slackText(e bus.Event) string {
+	escape := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	return fmt.Sprintf("*%s*\n%s", escape.Replace(v.title(e)), escape.Replace(v.plain(e)))
+}
+
+// Discord markdown. 제목 굵게
+func (v View) discordText(e bus.Event) string {
+	return fmt.Sprintf("**%s**\n%s", v.title(e), v.plain(e))
+}
+
+// 메일 제목. [invest][심각도] 제목
+func (v View) subject(e bus.Event) string {
+	return fmt.Sprintf("[invest][%s] %s", v.severity(e), v.title(e))
+}
@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"invest/bus"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	hookTimeout  = 10 * time.Second
+	discordLimit = 2000 // Discord 메시지 최대 길이
+)
+
+// HTTP POST로 JSON body를 전송하는 채널
+type Webhook struct {
+	name   string
+	url    string
+	header map[string]string
+	body   func(e bus.Event) any
+	client *http.Client
+}
+
+/*
+NewWebhook
+일반 JSON 웹훅. {"kind": , "severity": , "text": , "time": , "data": } 전송
+  - text : 일반 텍스트 템플릿 본문
+  - data : 이벤트 필드 그대로
+  - header : 인증 토큰 등 추가 헤더
+*/
+func NewWebhook(name string, url string, header map[string]string, v View) *Webhook {
+	return newWebhook(name, url, header, func(e bus.Event) any {
+		return struct {
+			Kind     bus.Kind  `json:"kind"`
+			Severity string    `json:"severity"`
+			Text     string    `json:"text"`
+			Time     time.Time `json:"time"`
+			Data     bus.Event `json:"data"`
+		}{e.Kind(), e.Severity().String(), v.plain(e), time.Now(), e}
+	})
+}
+
+// Slack incoming webhook
+func NewSlack(name string, url string, v View) *Webhook {
+	return newWebhook(name, url, nil, func(e bus.Event) any {
+		return map[string]string{"text": v.slackText(e)}
+	})
+}
+
+// Discord webhook. 최대 길이 초과분은 생략
+func NewDiscord(name string, url string, v View) *Webhook {
+	return newWebhook(name, url, nil, func(e bus.Event) any {
+		text := []rune(v.discordText(e))
+		if len(text) > discordLimit {
+			text = text[:discordLimit]
+		}
+		return map[string]string{"content": string(text)}
+	})
+}
+
+func newWebhook(name string, url string, header map[string]string, body func(e bus.Event) any) *Webhook {
+	return &Webhook{
+		name:   name,
+		url:    url,
+		header: header,
+		body:   body,
+		client: &http.Client{Timeout: hookTimeout},
+	}
+}
+
+func (w Webhook) Name() string {
+	return w.name
+}
+
+func (w Webhook) Notify(e bus.Event) error {
+
+	b, err := json.Marshal(w.body(e))
+	if err != nil {
+		return fmt.Errorf("body 변환 시 오류 발생. %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("요청 생성 시 오류 발생. %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range w.header {
+		req.Header.Set(k, v)
+	}
+
+	res, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("요청 전송 시 오류 발생. %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(res.Body, 200))
+		return fmt.Errorf("응답 코드 %d. %s", res.StatusCode, detail)
+	}
+	return nil
+}
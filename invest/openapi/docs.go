@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+)
+
+var docsTemplate = template.Must(template.New("docs").Funcs(template.FuncMap{
+	"upper":    strings.ToUpper,
+	"join":     strings.Join,
+	"contains": slices.Contains[[]string, string],
+	"anchor":   func(name string) string { return "schema-" + name },
+	"response": func(o *Operation) *Schema { s, _ := o.ResponseSchema(); return s },
+	"text":     func(o *Operation) bool { _, text := o.ResponseSchema(); return text },
+}).Parse(`<!DOCTYPE html>
+<html lang="ko">
+<head>
+<meta charset="utf-8">
+<title>{{.Info.Title}} {{.Info.Version}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em auto; max-width: 960px; color: #222; }
+h2 { border-bottom: 1px solid #ccc; padding-bottom: .2em; margin-top: 2em; }
+.op { border: 1px solid #ddd; border-radius: 4px; margin: 1em 0; padding: .5em 1em; }
+.method { display: inline-block; min-width: 4em; font-weight: bold; }
+.get { color: #1b6ac9; } .post { color: #2a8a3e; } .put { color: #b7791f; } .delete { color: #c53030; }
+.scope { float: right; font-size: .85em; color: #666; }
+table { border-collapse: collapse; margin: .5em 0; }
+td, th { border: 1px solid #ddd; padding: .2em .6em; text-align: left; font-size: .9em; }
+code { background: #f4f4f4; padding: 0 .2em; }
+</style>
+</head>
+<body>
+<h1>{{.Info.Title}} <small>{{.Info.Version}}</small></h1>
+<p>{{.Info.Description}}</p>
+<p>명세 : <a href="openapi.json">openapi.json</a>. 인증 : <code>X-API-Key</code> 혹은 <code>Authorization: Bearer</code> 헤더</p>
+
+<h2>API</h2>
+{{range .Operations}}
+<div class="op" id="{{.OperationID}}">
+  <span class="method {{.Method}}">{{upper .Method}}</span> <code>{{.Path}}</code>
+  <span class="scope">{{if .Security}}인증 불필요{{else if .Scope}}{{.Scope}}{{end}}</span>
+  <div>{{.Summary}} <small>({{.OperationID}})</small></div>
+  {{- if .Parameters}}
+  <table>
+    <tr><th>파라미터</th><th>위치</th><th>타입</th><th>필수</th><th>설명</th></tr>
+    {{- range .Parameters}}
+    <tr><td>{{.Name}}</td><td>{{.In}}</td><td>{{.Schema.TypeName}}</td><td>{{if .Required}}O{{end}}</td><td>{{.Description}}</td></tr>
+    {{- end}}
+  </table>
+  {{- end}}
+  {{- with .BodySchema}}
+  <div>요청 : {{template "type" .}}</div>
+  {{- end}}
+  <div>응답 : {{with response .Operation}}{{template "type" .}}{{else}}없음{{end}}{{if text .Operation}} (text/plain){{end}}</div>
+</div>
+{{end}}
+
+<h2>스키마</h2>
+{{range $name := .SchemaNames}}{{with index $.Components.Schemas $name}}{{$props := .Properties}}
+<h3 id="{{anchor $name}}">{{$name}}</h3>
+<table>
+  <tr><th>속성</th><th>타입</th><th>필수</th></tr>
+  {{- $required := .Required}}
+  {{- range $prop := .Order}}
+  <tr><td>{{$prop}}</td><td>{{template "type" index $props $prop}}</td><td>{{if contains $required $prop}}O{{end}}</td></tr>
+  {{- end}}
+</table>
+{{end}}{{end}}
+</body>
+</html>
+
+{{define "type"}}{{if .Ref}}<a href="#{{anchor .RefName}}">{{.RefName}}</a>{{else if .Items}}array&lt;{{template "type" .Items}}&gt;{{else if .AdditionalProperties}}map&lt;string, {{template "type" .AdditionalProperties}}&gt;{{else}}{{.TypeName}}{{end}}{{end}}
+`))
+
+// 문서 페이지 HTML
+func (d *Document) HTML(w io.Writer) error {
+	err := docsTemplate.Execute(w, d)
+	if err != nil {
+		return fmt.Errorf("API 문서 작성 시 오류 발생. %w", err)
+	}
+	return nil
+}
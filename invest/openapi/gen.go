@@ -0,0 +1,301 @@
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+/*
+Client
+문서의 스키마, 작업으로 Go 클라이언트 코드 생성
+  - 스키마별 구조체, 작업별 Client 메서드. 메서드 이름은 operationId
+  - 요청 처리(c.do)와 Client 정의는 생성 코드 밖에서 구현
+*/
+func (d *Document) Client(pkg string) ([]byte, error) {
+
+	g := &generator{doc: d, imports: map[string]bool{"context": true}}
+	data := struct {
+		Package string
+		Imports []string
+		Types   []genType
+		Methods []genMethod
+	}{Package: pkg}
+
+	for _, name := range d.SchemaNames() {
+		data.Types = append(data.Types, g.structType(name, d.Components.Schemas[name]))
+	}
+	for _, e := range d.Operations() {
+		m, err := g.method(e)
+		if err != nil {
+			return nil, err
+		}
+		data.Methods = append(data.Methods, m)
+	}
+	for imp := range g.imports {
+		data.Imports = append(data.Imports, imp)
+	}
+	slices.Sort(data.Imports)
+
+	var buf bytes.Buffer
+	err := clientTemplate.Execute(&buf, data)
+	if err != nil {
+		return nil, fmt.Errorf("클라이언트 코드 작성 시 오류 발생. %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("클라이언트 코드 정렬 시 오류 발생. %w", err)
+	}
+	return src, nil
+}
+
+type genType struct {
+	Name   string
+	Fields []genField
+}
+
+type genField struct {
+	Name string
+	Type string
+	Tag  string
+}
+
+/*
+작업별 메서드
+  - Args : ctx 이후 인자. 경로 파라미터, 쿼리 파라미터 구조체, 요청 본문 순
+  - Params : 쿼리 파라미터 구조체. 쿼리 파라미터가 없으면 nil
+  - Result : 응답 타입. 빈 값이면 오류만 반환
+*/
+type genMethod struct {
+	Name    string
+	Summary string
+	Method  string
+	Path    string
+	PathFmt string
+	PathArg []string
+	Args    []string
+	Params  *genParams
+	Body    bool
+	Result  string
+	Pointer bool
+}
+
+type genParams struct {
+	Name   string
+	Fields []genQuery
+}
+
+type genQuery struct {
+	Name  string
+	Field string
+	Type  string
+}
+
+type generator struct {
+	doc     *Document
+	imports map[string]bool
+}
+
+func (g *generator) structType(name string, s *Schema) genType {
+	t := genType{Name: name}
+	for _, prop := range s.Order {
+		tag := prop
+		if !slices.Contains(s.Required, prop) {
+			tag += ",omitempty"
+		}
+		t.Fields = append(t.Fields, genField{
+			Name: goName(prop),
+			Type: g.goType(s.Properties[prop]),
+			Tag:  fmt.Sprintf("`json:%q`", tag),
+		})
+	}
+	return t
+}
+
+func (g *generator) method(e Entry) (genMethod, error) {
+
+	m := genMethod{
+		Name:    goName(e.OperationID),
+		Summary: e.Summary,
+		Method:  strings.ToUpper(e.Method),
+		Path:    e.Path,
+	}
+	if e.OperationID == "" {
+		return m, fmt.Errorf("operationId 미존재. %s %s", m.Method, e.Path)
+	}
+
+	path := e.Path
+	var query []Parameter
+	for _, p := range e.Parameters {
+		if p.In == "query" {
+			query = append(query, p)
+			continue
+		}
+
+		arg := lowerFirst(goName(p.Name))
+		typ := g.goType(p.Schema)
+		m.Args = append(m.Args, arg+" "+typ)
+		if typ == "string" {
+			g.imports["net/url"] = true
+			path = strings.Replace(path, "{"+p.Name+"}", "%s", 1)
+			m.PathArg = append(m.PathArg, "url.PathEscape("+arg+")")
+		} else {
+			path = strings.Replace(path, "{"+p.Name+"}", "%d", 1)
+			m.PathArg = append(m.PathArg, arg)
+		}
+	}
+	m.PathFmt = path
+	if len(m.PathArg) > 0 {
+		g.imports["fmt"] = true
+	}
+
+	if len(query) > 0 {
+		g.imports["net/url"] = true
+		m.Params = &genParams{Name: m.Name + "Params"}
+		for _, p := range query {
+			q := genQuery{Name: p.Name, Field: goName(p.Name), Type: g.goType(p.Schema)}
+			if q.Type != "string" {
+				g.imports["strconv"] = true
+			}
+			m.Params.Fields = append(m.Params.Fields, q)
+		}
+		m.Args = append(m.Args, "params "+m.Params.Name)
+	}
+
+	if s := e.BodySchema(); s != nil {
+		m.Body = true
+		m.Args = append(m.Args, "body "+g.goType(s))
+	}
+
+	s, text := e.ResponseSchema()
+	switch {
+	case text:
+		m.Result = "string"
+	case s != nil && s.Ref != "":
+		m.Result, m.Pointer = s.RefName(), true
+	case s != nil:
+		m.Result = g.goType(s)
+	}
+	return m, nil
+}
+
+func (g *generator) goType(s *Schema) string {
+
+	var t string
+	switch {
+	case s.Ref != "":
+		t = s.RefName()
+	case s.Type == "array":
+		return "[]" + g.goType(s.Items)
+	case s.AdditionalProperties != nil:
+		return "map[string]" + g.goType(s.AdditionalProperties)
+	case s.Type == "integer":
+		t = "int64"
+	case s.Type == "number":
+		t = "float64"
+	case s.Type == "boolean":
+		t = "bool"
+	case s.Type == "string" && s.Format == "date-time":
+		g.imports["time"] = true
+		t = "time.Time"
+	case s.Type == "string":
+		t = "string"
+	default:
+		g.imports["encoding/json"] = true
+		return "json.RawMessage"
+	}
+
+	if s.Nullable {
+		return "*" + t
+	}
+	return t
+}
+
+// snake_case, camelCase 이름을 공개 Go 이름으로. ex) sel_price -> SelPrice, alertId -> AlertId
+func goName(s string) string {
+	parts := strings.FieldsFunc(s, func(r rune) bool { return r == '_' || r == '-' || r == ' ' })
+	for i, p := range parts {
+		r := []rune(p)
+		r[0] = unicode.ToUpper(r[0])
+		parts[i] = string(r)
+	}
+	return strings.Join(parts, "")
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+var clientTemplate = template.Must(template.New("client").Funcs(template.FuncMap{
+	"join": strings.Join,
+}).Parse(`// Code generated by cmd/genclient. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{range .Types}}
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+{{end}}
+{{- range .Methods}}
+{{- with .Params}}
+// 쿼리 파라미터. 빈 값은 생략
+type {{.Name}} struct {
+{{- range .Fields}}
+	{{.Field}} {{.Type}}
+{{- end}}
+}
+
+func (p {{.Name}}) values() url.Values {
+	q := url.Values{}
+{{- range .Fields}}
+{{- if eq .Type "string"}}
+	if p.{{.Field}} != "" {
+		q.Set("{{.Name}}", p.{{.Field}})
+	}
+{{- else}}
+	if p.{{.Field}} != 0 {
+		q.Set("{{.Name}}", strconv.FormatInt(p.{{.Field}}, 10))
+	}
+{{- end}}
+{{- end}}
+	return q
+}
+{{end}}
+// {{.Name}} {{.Summary}}
+//
+//	{{.Method}} {{.Path}}
+func (c *Client) {{.Name}}(ctx context.Context{{range .Args}}, {{.}}{{end}}) {{if .Pointer}}(*{{.Result}}, error){{else if .Result}}({{.Result}}, error){{else}}error{{end}} {
+{{- $path := printf "%q" .PathFmt}}
+{{- if .PathArg}}{{$path = printf "fmt.Sprintf(%q, %s)" .PathFmt (join .PathArg ", ")}}{{end}}
+{{- $query := "nil"}}{{if .Params}}{{$query = "params.values()"}}{{end}}
+{{- $body := "nil"}}{{if .Body}}{{$body = "body"}}{{end}}
+{{- if .Pointer}}
+	out := new({{.Result}})
+	err := c.do(ctx, "{{.Method}}", {{$path}}, {{$query}}, {{$body}}, out)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+{{- else if .Result}}
+	var out {{.Result}}
+	err := c.do(ctx, "{{.Method}}", {{$path}}, {{$query}}, {{$body}}, &out)
+	return out, err
+{{- else}}
+	return c.do(ctx, "{{.Method}}", {{$path}}, {{$query}}, {{$body}}, nil)
+{{- end}}
+}
+{{end}}`))
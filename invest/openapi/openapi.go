@@ -0,0 +1,473 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/datatypes"
+)
+
+// OpenAPI 3 문서. 사용하는 항목만 정의
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Paths      map[string]*PathItem  `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []map[string][]string `json:"security,omitempty"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// 요청 방식(get, post 등)별 작업
+type PathItem map[string]*Operation
+
+/*
+작업
+  - Scope : 필요 권한 범위(x-scope). read, write, admin
+  - Public : 인증 불필요 여부. 문서에는 빈 security로 표기
+*/
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]Response   `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Scope       string                `json:"x-scope,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Required    bool    `json:"required,omitempty"`
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Order                []string           `json:"x-order,omitempty"` // 속성 선언 순서. 생성 코드 필드 순서 유지용
+}
+
+// 참조 스키마 이름. 참조가 아니면 빈 값
+func (s *Schema) RefName() string {
+	return strings.TrimPrefix(s.Ref, "#/components/schemas/")
+}
+
+/*
+Route
+문서화할 API 경로 하나
+  - Path : OpenAPI 형식. ex) /assets/{id}
+  - ID : operationId. 생성 클라이언트의 메서드 이름
+  - Body : 요청 본문 구조체 값. nil이면 본문 없음
+  - Response : 응답 본문 값. string이면 text/plain 응답
+*/
+type Route struct {
+	Method   string
+	Path     string
+	ID       string
+	Summary  string
+	Tag      string
+	Scope    string
+	Public   bool
+	Query    []Param
+	Body     any
+	Response any
+}
+
+/*
+쿼리 파라미터
+  - Type : 값 예시(0, "" 등)로 스키마 결정
+*/
+type Param struct {
+	Name        string
+	Type        any
+	Required    bool
+	Description string
+}
+
+const (
+	jsonType = "application/json"
+	textType = "text/plain"
+)
+
+// 오류 응답 스키마 이름. 모든 작업의 default 응답
+const ErrorSchema = "ErrorBody"
+
+type Builder struct {
+	doc *Document
+}
+
+func New(info Info) *Builder {
+	return &Builder{doc: &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				"apiKey": {Type: "apiKey", In: "header", Name: "X-API-Key"},
+				"bearer": {Type: "http", Scheme: "bearer", BearerFormat: "API 키 혹은 JWT"},
+			},
+		},
+		Security: []map[string][]string{{"apiKey": {}}, {"bearer": {}}},
+	}}
+}
+
+// 오류 응답 본문 등록. 모든 작업의 default 응답으로 참조
+func (b *Builder) Error(body any) *Builder {
+	name := b.schema(reflect.TypeOf(body)).RefName()
+	if name != ErrorSchema {
+		b.doc.Components.Schemas[ErrorSchema] = b.doc.Components.Schemas[name]
+		delete(b.doc.Components.Schemas, name)
+	}
+	return b
+}
+
+func (b *Builder) Add(routes ...Route) *Builder {
+	for _, r := range routes {
+		b.add(r)
+	}
+	return b
+}
+
+func (b *Builder) Document() *Document {
+	return b.doc
+}
+
+func (b *Builder) add(r Route) {
+
+	op := &Operation{
+		OperationID: r.ID,
+		Summary:     r.Summary,
+		Parameters:  pathParams(r.Path),
+		Responses:   map[string]Response{},
+		Scope:       r.Scope,
+	}
+	if r.Tag != "" {
+		op.Tags = []string{r.Tag}
+	}
+	if r.Public {
+		op.Security = []map[string][]string{{}}
+	}
+
+	for _, q := range r.Query {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name: q.Name, In: "query", Required: q.Required, Description: q.Description,
+			Schema: b.schema(reflect.TypeOf(q.Type)),
+		})
+	}
+
+	if r.Body != nil {
+		op.RequestBody = &RequestBody{Required: true, Content: map[string]MediaType{
+			jsonType: {Schema: b.schema(reflect.TypeOf(r.Body))},
+		}}
+	}
+
+	switch r.Response.(type) {
+	case nil:
+		op.Responses["200"] = Response{Description: "성공"}
+	case string:
+		op.Responses["200"] = Response{Description: "성공", Content: map[string]MediaType{
+			textType: {Schema: &Schema{Type: "string"}},
+		}}
+	default:
+		op.Responses["200"] = Response{Description: "성공", Content: map[string]MediaType{
+			jsonType: {Schema: b.schema(reflect.TypeOf(r.Response))},
+		}}
+	}
+	op.Responses["default"] = Response{Description: "오류", Content: map[string]MediaType{
+		jsonType: {Schema: &Schema{Ref: "#/components/schemas/" + ErrorSchema}},
+	}}
+
+	item, ok := b.doc.Paths[r.Path]
+	if !ok {
+		item = &PathItem{}
+		b.doc.Paths[r.Path] = item
+	}
+	(*item)[strings.ToLower(r.Method)] = op
+}
+
+/*
+경로 파라미터. {name} 형식
+  - id, ~Id : 정수. 그 외 문자열
+*/
+func pathParams(path string) []Parameter {
+
+	params := make([]Parameter, 0)
+	for _, seg := range strings.Split(path, "/") {
+		if !strings.HasPrefix(seg, "{") || !strings.HasSuffix(seg, "}") {
+			continue
+		}
+
+		name := seg[1 : len(seg)-1]
+		s := &Schema{Type: "string"}
+		if name == "id" || strings.HasSuffix(name, "Id") {
+			s = &Schema{Type: "integer", Format: "int64"}
+		}
+		params = append(params, Parameter{Name: name, In: "path", Required: true, Schema: s})
+	}
+	return params
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	dateType = reflect.TypeOf(datatypes.Date{})
+	rawType  = reflect.TypeOf(json.RawMessage{})
+)
+
+/*
+Go 타입의 스키마
+  - 이름 있는 구조체는 components에 등록 후 참조. 이름은 첫 글자 대문자
+  - json 태그 이름 사용. omitempty 혹은 포인터는 선택, validate:"required"는 필수
+*/
+func (b *Builder) schema(t reflect.Type) *Schema {
+
+	if t == nil {
+		return &Schema{}
+	}
+
+	switch t {
+	case timeType, dateType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := b.schema(t.Elem())
+		s.Nullable = true
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.schema(t.Elem())}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Struct:
+		return b.object(t)
+	}
+	return &Schema{}
+}
+
+func (b *Builder) object(t reflect.Type) *Schema {
+
+	name := schemaName(t)
+	if name != "" {
+		if _, ok := b.doc.Components.Schemas[name]; ok {
+			return &Schema{Ref: "#/components/schemas/" + name}
+		}
+		b.doc.Components.Schemas[name] = &Schema{} // 재귀 참조 대비 선등록
+	}
+
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	b.fields(t, s, validated(t))
+	sort.Strings(s.Required)
+
+	if name == "" {
+		return s
+	}
+	b.doc.Components.Schemas[name] = s
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (b *Builder) fields(t reflect.Type, s *Schema, request bool) {
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			b.fields(f.Type, s, request)
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		s.Properties[name] = b.schema(f.Type)
+		s.Order = append(s.Order, name)
+
+		if required(f, opts, request) {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+/*
+필수 속성 여부
+  - 요청 구조체(validate 태그 사용) : validate required 포함 여부
+  - 응답 구조체 : omitempty, 포인터가 아닌 속성. 항상 값이 채워짐
+*/
+func required(f reflect.StructField, opts string, request bool) bool {
+	if request {
+		return strings.Contains(f.Tag.Get("validate"), "required")
+	}
+	return !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer
+}
+
+// validate 태그 사용 여부. 요청 구조체 구분
+func validated(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("validate"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// 패키지 내 비공개 타입도 공개 이름으로 등록
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	if name == "" {
+		return ""
+	}
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	r := []rune(name)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+// 경로, 요청 방식이 포함된 작업
+type Entry struct {
+	Method string
+	Path   string
+	*Operation
+}
+
+var methodOrder = map[string]int{"get": 0, "post": 1, "put": 2, "patch": 3, "delete": 4}
+
+// 경로, 요청 방식(get, post, put, delete) 순 작업 목록
+func (d *Document) Operations() []Entry {
+
+	entries := make([]Entry, 0)
+	for path, item := range d.Paths {
+		for method, op := range *item {
+			entries = append(entries, Entry{Method: method, Path: path, Operation: op})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Path != entries[j].Path {
+			return entries[i].Path < entries[j].Path
+		}
+		return methodOrder[entries[i].Method] < methodOrder[entries[j].Method]
+	})
+	return entries
+}
+
+// 이름 순 스키마 목록
+func (d *Document) SchemaNames() []string {
+	names := make([]string, 0, len(d.Components.Schemas))
+	for name := range d.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 요청/응답 본문 스키마. 본문이 없으면 nil
+func (o *Operation) BodySchema() *Schema {
+	if o.RequestBody == nil {
+		return nil
+	}
+	return o.RequestBody.Content[jsonType].Schema
+}
+
+/*
+성공 응답 스키마
+  - text : text/plain 응답 여부
+  - 응답 본문이 없으면 nil
+*/
+func (o *Operation) ResponseSchema() (s *Schema, text bool) {
+	resp := o.Responses["200"]
+	if mt, ok := resp.Content[textType]; ok {
+		return mt.Schema, true
+	}
+	if mt, ok := resp.Content[jsonType]; ok {
+		return mt.Schema, false
+	}
+	return nil, false
+}
+
+// 문서 표기용 타입 이름. ex) InvestResponse, array<HistResponse>, map<string, number>
+func (s *Schema) TypeName() string {
+	switch {
+	case s == nil:
+		return ""
+	case s.Ref != "":
+		return s.RefName()
+	case s.Type == "array":
+		return "array<" + s.Items.TypeName() + ">"
+	case s.AdditionalProperties != nil:
+		return "map<string, " + s.AdditionalProperties.TypeName() + ">"
+	case s.Type == "":
+		return "any"
+	case s.Format == "date-time":
+		return "string(date-time)"
+	}
+	return s.Type
+}
@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type itemReq struct {
+	Name   string  `json:"name" validate:"required"`
+	Price  float64 `json:"price"`
+	Active *bool   `json:"active"`
+}
+
+type itemResponse struct {
+	ID        uint      `json:"id"`
+	Tags      []string  `json:"tags"`
+	Memo      string    `json:"memo,omitempty"`
+	Parent    *itemRef  `json:"parent"`
+	CreatedAt time.Time `json:"created_at"`
+	Extra     any       `json:"-"`
+	Scores    map[string]float64
+	RefItems  []itemRef `json:"refs"`
+}
+
+type itemRef struct {
+	ID uint `json:"id"`
+}
+
+func document() *Document {
+	return New(Info{Title: "test", Version: "1"}).
+		Error(errorBody{}).
+		Add(
+			Route{Method: "GET", Path: "/items/{id}", ID: "getItem", Summary: "조회", Tag: "items", Scope: "read", Response: itemResponse{}},
+			Route{Method: "POST", Path: "/items", ID: "addItem", Tag: "items", Scope: "write", Body: itemReq{}, Response: ""},
+			Route{Method: "GET", Path: "/items", ID: "listItems", Tag: "items", Scope: "read",
+				Query: []Param{{Name: "limit", Type: 0}, {Name: "from", Type: ""}}, Response: []itemRef{}},
+			Route{Method: "DELETE", Path: "/items/{id}/tags/{tagId}", ID: "deleteTag", Public: true},
+		).
+		Document()
+}
+
+func TestSchema(t *testing.T) {
+
+	doc := document()
+	schemas := doc.Components.Schemas
+
+	t.Run("구조체는 공개 이름으로 등록 후 참조", func(t *testing.T) {
+		assert.Equal(t, []string{"ErrorBody", "ItemRef", "ItemReq", "ItemResponse"}, doc.SchemaNames())
+		assert.Equal(t, "#/components/schemas/ItemResponse", doc.Paths["/items/{id}"].get().Responses["200"].Content[jsonType].Schema.Ref)
+		assert.Equal(t, "#/components/schemas/ErrorBody", doc.Paths["/items/{id}"].get().Responses["default"].Content[jsonType].Schema.Ref)
+	})
+
+	t.Run("속성 타입", func(t *testing.T) {
+		s := schemas["ItemResponse"]
+		assert.Equal(t, []string{"id", "tags", "memo", "parent", "created_at", "Scores", "refs"}, s.Order)
+		assert.Equal(t, "integer", s.Properties["id"].Type)
+		assert.Equal(t, "array<string>", s.Properties["tags"].TypeName())
+		assert.Equal(t, "string(date-time)", s.Properties["created_at"].TypeName())
+		assert.Equal(t, "map<string, number>", s.Properties["Scores"].TypeName())
+		assert.Equal(t, "array<ItemRef>", s.Properties["refs"].TypeName())
+		assert.True(t, s.Properties["parent"].Nullable)
+		assert.Equal(t, "ItemRef", s.Properties["parent"].RefName())
+	})
+
+	t.Run("필수 속성", func(t *testing.T) {
+		assert.Equal(t, []string{"Scores", "created_at", "id", "refs", "tags"}, schemas["ItemResponse"].Required)
+		assert.Equal(t, []string{"name"}, schemas["ItemReq"].Required) // 요청 구조체는 validate required만
+	})
+
+	t.Run("경로, 쿼리 파라미터", func(t *testing.T) {
+		op := (*doc.Paths["/items/{id}/tags/{tagId}"])["delete"]
+		assert.Len(t, op.Parameters, 2)
+		assert.Equal(t, "tagId", op.Parameters[1].Name)
+		assert.Equal(t, "integer", op.Parameters[1].Schema.Type)
+		assert.Equal(t, []map[string][]string{{}}, op.Security)
+
+		op = doc.Paths["/items"].get()
+		assert.Equal(t, "query", op.Parameters[0].In)
+		assert.Equal(t, "integer", op.Parameters[0].Schema.Type)
+		assert.Equal(t, "string", op.Parameters[1].Schema.Type)
+	})
+
+	t.Run("경로, 요청 방식 순 작업 목록", func(t *testing.T) {
+		ids := make([]string, 0)
+		for _, e := range doc.Operations() {
+			ids = append(ids, e.OperationID)
+		}
+		assert.Equal(t, []string{"listItems", "addItem", "getItem", "deleteTag"}, ids)
+	})
+
+	t.Run("JSON 문서", func(t *testing.T) {
+		data, err := json.Marshal(doc)
+		assert.NoError(t, err)
+		assert.Contains(t, string(data), `"openapi":"3.0.3"`)
+		assert.Contains(t, string(data), `"x-scope":"write"`)
+		assert.Contains(t, string(data), `"text/plain"`)
+	})
+}
+
+func (p *PathItem) get() *Operation {
+	return (*p)["get"]
+}
+
+func TestDocs(t *testing.T) {
+
+	var buf bytes.Buffer
+	err := document().HTML(&buf)
+	assert.NoError(t, err)
+
+	page := buf.String()
+	assert.Contains(t, page, `<code>/items/{id}</code>`)
+	assert.Contains(t, page, `<a href="#schema-ItemResponse">ItemResponse</a>`)
+	assert.Contains(t, page, `array&lt;<a href="#schema-ItemRef">ItemRef</a>&gt;`)
+	assert.Contains(t, page, "인증 불필요")
+	assert.Contains(t, page, `<h3 id="schema-ItemReq">ItemReq</h3>`)
+}
+
+func TestClient(t *testing.T) {
+
+	src, err := document().Client("api")
+	assert.NoError(t, err)
+
+	code := string(src)
+	assert.Contains(t, code, "package api")
+	assert.Contains(t, code, "Parent    *ItemRef           `json:\"parent,omitempty\"`")
+	assert.Contains(t, code, "Active *bool   `json:\"active,omitempty\"`")
+	assert.Contains(t, code, "func (c *Client) GetItem(ctx context.Context, id int64) (*ItemResponse, error)")
+	assert.Contains(t, code, "func (c *Client) AddItem(ctx context.Context, body ItemReq) (string, error)")
+	assert.Contains(t, code, "func (c *Client) ListItems(ctx context.Context, params ListItemsParams) ([]ItemRef, error)")
+	assert.Contains(t, code, `q.Set("limit", strconv.FormatInt(p.Limit, 10))`)
+	assert.Contains(t, code, `func (c *Client) DeleteTag(ctx context.Context, id int64, tagId int64) error`)
+	assert.Contains(t, code, `fmt.Sprintf("/items/%d/tags/%d", id, tagId)`)
+
+	_, err = New(Info{}).Add(Route{Method: "GET", Path: "/none"}).Document().Client("api")
+	assert.ErrorContains(t, err, "operationId 미존재. GET /none")
+}
@@ -0,0 +1,344 @@
+package render
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+//go:embed templates
+var defaults embed.FS
+
+// 문구 언어
+type Locale string
+
+const (
+	Korean  Locale = "ko"
+	English Locale = "en"
+)
+
+var Locales = []Locale{Korean, English}
+
+// 빈 값은 한국어
+func ToLocale(s string) (Locale, error) {
+	if s == "" {
+		return Korean, nil
+	}
+	l := Locale(strings.ToLower(s))
+	if !slices.Contains(Locales, l) {
+		return "", fmt.Errorf("지원하지 않는 언어. ko, en. 입력 값 : %s", s)
+	}
+	return l, nil
+}
+
+// 채널별 서식
+type Variant string
+
+const (
+	Plain    Variant = "plain"    // 일반 텍스트. 기본 문구, Slack/Discord/웹훅 본문
+	Markdown Variant = "markdown" // 텔레그램 MarkdownV2
+	HTML     Variant = "html"     // 메일 HTML 본문
+)
+
+/*
+Templates
+{locale}/{variant}.tmpl 파일의 문구 템플릿. 이벤트 종류 이름으로 define
+  - {종류} : 본문, {종류}.title : 제목
+  - 내장 기본 템플릿 위에 dir의 같은 경로 파일로 재정의
+*/
+type Templates struct {
+	dir    string
+	locale Locale
+
+	mu   sync.RWMutex
+	text map[Locale]map[Variant]*template.Template
+	html map[Locale]*htmltemplate.Template
+}
+
+/*
+New
+  - dir : 재정의 템플릿 디렉토리. 빈 값이면 내장 템플릿만 사용
+  - locale : 기본 언어. 요청 언어에 템플릿이 없을 때 사용
+*/
+func New(dir string, locale Locale) (*Templates, error) {
+
+	t := &Templates{dir: dir, locale: locale}
+	err := t.Reload()
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+/*
+Reload
+템플릿 파일 재적재. 재배포 없이 문구 변경 반영
+  - 파싱 실패 시 기존 템플릿 유지
+*/
+func (t *Templates) Reload() error {
+
+	if t.dir != "" {
+		_, err := os.Stat(t.dir)
+		if err != nil {
+			return fmt.Errorf("템플릿 디렉토리 조회 시 오류 발생. %w", err)
+		}
+	}
+
+	text := make(map[Locale]map[Variant]*template.Template)
+	html := make(map[Locale]*htmltemplate.Template)
+	for _, locale := range Locales {
+		text[locale] = make(map[Variant]*template.Template)
+		for _, v := range []Variant{Plain, Markdown} {
+			tpl := template.New(string(v)).Funcs(funcs)
+			err := t.parse(locale, v, func(src string) (err error) {
+				tpl, err = tpl.Parse(src)
+				return
+			})
+			if err != nil {
+				return err
+			}
+			text[locale][v] = tpl
+		}
+
+		tpl := htmltemplate.New(string(HTML)).Funcs(htmltemplate.FuncMap(funcs))
+		err := t.parse(locale, HTML, func(src string) (err error) {
+			tpl, err = tpl.Parse(src)
+			return
+		})
+		if err != nil {
+			return err
+		}
+		html[locale] = tpl
+	}
+
+	t.mu.Lock()
+	t.text, t.html = text, html
+	t.mu.Unlock()
+	return nil
+}
+
+// 내장 파일, 재정의 파일 순으로 파싱. 같은 이름의 define은 나중 것이 우선
+func (t *Templates) parse(locale Locale, v Variant, parse func(src string) error) error {
+
+	name := fmt.Sprintf("%s/%s.tmpl", locale, v)
+
+	src, err := defaults.ReadFile("templates/" + name)
+	if err == nil {
+		err = parse(string(src))
+		if err != nil {
+			return fmt.Errorf("내장 템플릿 %s 파싱 시 오류 발생. %w", name, err)
+		}
+	}
+
+	if t.dir == "" {
+		return nil
+	}
+	src, err = os.ReadFile(filepath.Join(t.dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("템플릿 %s 조회 시 오류 발생. %w", name, err)
+	}
+	err = parse(string(src))
+	if err != nil {
+		return fmt.Errorf("템플릿 %s 파싱 시 오류 발생. %w", name, err)
+	}
+	return nil
+}
+
+/*
+Render
+name 템플릿 실행
+  - 요청 언어에 name이 없으면 기본 언어, 한국어 순으로 대체
+  - 어느 언어에도 없으면 오류
+*/
+func (t *Templates) Render(locale Locale, v Variant, name string, data any) (string, error) {
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, l := range []Locale{locale, t.locale, Korean} {
+		var buf bytes.Buffer
+		var err error
+		if v == HTML {
+			tpl, ok := t.html[l]
+			if !ok || tpl.Lookup(name) == nil {
+				continue
+			}
+			err = tpl.ExecuteTemplate(&buf, name, data)
+		} else {
+			tpl, ok := t.text[l][v]
+			if !ok || tpl.Lookup(name) == nil {
+				continue
+			}
+			err = tpl.ExecuteTemplate(&buf, name, data)
+		}
+		if err != nil {
+			return "", fmt.Errorf("템플릿 %s/%s/%s 실행 시 오류 발생. %w", l, v, name, err)
+		}
+		return buf.String(), nil
+	}
+	return "", fmt.Errorf("템플릿 미존재. %s/%s/%s", locale, v, name)
+}
+
+// 기본 언어
+func (t *Templates) Locale() Locale {
+	return t.locale
+}
+
+// 기본 언어 일반 텍스트
+func (t *Templates) Text(name string, data any) (string, error) {
+	return t.Render(t.locale, Plain, name, data)
+}
+
+var (
+	std   *Templates
+	stdMu sync.RWMutex
+)
+
+func init() {
+	t, err := New("", Korean)
+	if err != nil {
+		panic(err)
+	}
+	std = t
+}
+
+// 이벤트 기본 문구(String)에 사용하는 템플릿
+func Default() *Templates {
+	stdMu.RLock()
+	defer stdMu.RUnlock()
+	return std
+}
+
+// 설정 파일의 템플릿 디렉토리, 기본 언어 적용
+func SetDefault(t *Templates) {
+	stdMu.Lock()
+	std = t
+	stdMu.Unlock()
+}
+
+/*
+Text
+기본 템플릿의 기본 언어 일반 텍스트. 이벤트 String 구현용
+  - 실패 시 템플릿 이름과 오류를 문구로 반환. 알림 누락 방지
+*/
+func Text(name string, data any) string {
+	s, err := Default().Text(name, data)
+	if err != nil {
+		return fmt.Sprintf("[%s] %s", name, err)
+	}
+	return s
+}
+
+var funcs = template.FuncMap{
+	"md":        Escape,
+	"code":      code,
+	"date":      date,
+	"deref":     deref,
+	"comma":     comma,
+	"change":    change,
+	"rate":      rate,
+	"percent":   percent,
+	"firstLine": firstLine,
+}
+
+var mdEscaper = strings.NewReplacer(
+	`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`, "~", `\~`, "`", "\\`",
+	">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// 텔레그램 MarkdownV2 특수 문자 escape
+func Escape(v any) string {
+	return mdEscaper.Replace(fmt.Sprint(v))
+}
+
+// MarkdownV2 코드 블록 내부. ` 와 \ 만 escape
+func code(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "`", "\\`").Replace(s)
+}
+
+// time.Time, datatypes.Date 형식 변환
+func date(layout string, v any) string {
+	switch d := v.(type) {
+	case time.Time:
+		return d.Format(layout)
+	case datatypes.Date:
+		return time.Time(d).Format(layout)
+	case *time.Time:
+		if d != nil {
+			return d.Format(layout)
+		}
+	}
+	return ""
+}
+
+func deref(p *float64) float64 {
+	if p == nil {
+		return 0
+	}
+	return *p
+}
+
+// 천 단위 구분. 정수가 아니면 소수점 둘째 자리까지
+func comma(v float64) string {
+
+	s := strconv.FormatFloat(math.Abs(v), 'f', 2, 64)
+	s = strings.TrimSuffix(s, ".00")
+
+	intPart, frac, _ := strings.Cut(s, ".")
+	var sb strings.Builder
+	if v < 0 {
+		sb.WriteByte('-')
+	}
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteRune(r)
+	}
+	if frac != "" {
+		sb.WriteString("." + frac)
+	}
+	return sb.String()
+}
+
+// 비교 기준 대비 증감. 부호 포함 천 단위 구분
+func change(v float64, prev float64) string {
+	if v >= prev {
+		return "+" + comma(v-prev)
+	}
+	return comma(v - prev)
+}
+
+// 비교 기준 대비 변동률. 기준 0이면 -
+func rate(v float64, prev float64) string {
+	if prev == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.2f%%", (v-prev)/prev*100)
+}
+
+// 비율(0~1)의 백분율 표기
+func percent(prec int, v float64) string {
+	return strconv.FormatFloat(v*100, 'f', prec, 64) + "%"
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
@@ -0,0 +1,160 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+type priceAlert struct {
+	Asset struct {
+		ID   uint
+		Name string
+	}
+	Sell  bool
+	Bound float64
+	Price float64
+}
+
+func TestTemplates(t *testing.T) {
+
+	var buy priceAlert
+	buy.Asset.ID, buy.Asset.Name = 1, "삼성전자"
+	buy.Bound, buy.Price = 70000, 69000.5
+
+	t.Run("언어별 일반 텍스트", func(t *testing.T) {
+		tpl, err := New("", Korean)
+		assert.NoError(t, err)
+
+		s, err := tpl.Render(Korean, Plain, "PriceAlert", buy)
+		assert.NoError(t, err)
+		assert.Equal(t, "매수 삼성전자. ID : 1. 하한 : 70000.00. 현재가 : 69000.50", s)
+
+		s, err = tpl.Render(English, Plain, "PriceAlert", buy)
+		assert.NoError(t, err)
+		assert.Equal(t, "BUY 삼성전자. ID : 1. LOWER BOUND : 70000.00. CURRENT PRICE : 69000.50", s)
+
+		s, err = tpl.Render(English, Plain, "PriceAlert.title", buy)
+		assert.NoError(t, err)
+		assert.Equal(t, "Buy price reached", s)
+	})
+
+	t.Run("MarkdownV2 escape", func(t *testing.T) {
+		tpl, _ := New("", Korean)
+
+		s, err := tpl.Render(Korean, Markdown, "PriceAlert", buy)
+		assert.NoError(t, err)
+		assert.Equal(t, "*매수 기준가 도달*\n삼성전자 \\(ID 1\\)\n하한 : 70000\\.00\n현재가 : *69000\\.50*", s)
+
+		assert.Equal(t, `a\_b\*c\[d\]\(e\)\.f\!\-g`, Escape("a_b*c[d](e).f!-g"))
+	})
+
+	t.Run("HTML escape", func(t *testing.T) {
+		tpl, _ := New("", Korean)
+		buy := buy
+		buy.Asset.Name = "<script>"
+
+		s, err := tpl.Render(Korean, HTML, "PriceAlert", buy)
+		assert.NoError(t, err)
+		assert.Contains(t, s, "&lt;script&gt; (ID 1)")
+		assert.NotContains(t, s, "<script>")
+	})
+
+	t.Run("요청 언어에 없으면 기본 언어", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "ko/plain.tmpl", `{{define "Custom"}}사용자 정의{{end}}`)
+		tpl, err := New(dir, Korean)
+		assert.NoError(t, err)
+
+		s, err := tpl.Render(English, Plain, "Custom", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "사용자 정의", s)
+
+		_, err = tpl.Render(English, Plain, "Unknown", nil)
+		assert.ErrorContains(t, err, "템플릿 미존재. en/plain/Unknown")
+	})
+
+	t.Run("파일 재정의와 재적재", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "ko/plain.tmpl", `{{define "PriceAlert"}}{{.Asset.Name}} 매수 시점{{end}}`)
+		tpl, err := New(dir, Korean)
+		assert.NoError(t, err)
+
+		s, _ := tpl.Text("PriceAlert", buy)
+		assert.Equal(t, "삼성전자 매수 시점", s)
+		s, _ = tpl.Text("PriceAlert.title", buy)
+		assert.Equal(t, "매수 기준가 도달", s) // 재정의하지 않은 define은 기본 템플릿
+
+		write(t, dir, "ko/plain.tmpl", `{{define "PriceAlert"}}{{.Asset.Name}} 하한 도달{{end}}`)
+		assert.NoError(t, tpl.Reload())
+		s, _ = tpl.Text("PriceAlert", buy)
+		assert.Equal(t, "삼성전자 하한 도달", s)
+
+		write(t, dir, "ko/plain.tmpl", `{{define "PriceAlert"}}{{.Asset.Name{{end}}`)
+		assert.ErrorContains(t, tpl.Reload(), "템플릿 ko/plain.tmpl 파싱 시 오류 발생")
+		s, _ = tpl.Text("PriceAlert", buy)
+		assert.Equal(t, "삼성전자 하한 도달", s) // 실패 시 기존 템플릿 유지
+	})
+
+	t.Run("디렉토리 미존재", func(t *testing.T) {
+		_, err := New(filepath.Join(t.TempDir(), "none"), Korean)
+		assert.ErrorContains(t, err, "템플릿 디렉토리 조회 시 오류 발생")
+	})
+
+	t.Run("실행 실패 시 기본 문구에 오류 표기", func(t *testing.T) {
+		assert.Contains(t, Text("Unknown", nil), "[Unknown] 템플릿 미존재")
+	})
+}
+
+func TestFuncs(t *testing.T) {
+
+	t.Run("날짜 형식", func(t *testing.T) {
+		at := time.Date(2024, 12, 1, 0, 0, 0, 0, time.Local)
+		assert.Equal(t, "2024-12", date("2006-01", at))
+		assert.Equal(t, "2024-12", date("2006-01", datatypes.Date(at)))
+		assert.Equal(t, "", date("2006-01", "2024-12"))
+	})
+
+	t.Run("코드 블록 escape", func(t *testing.T) {
+		assert.Equal(t, "a\\`b\\\\c.d", code("a`b\\c.d"))
+	})
+
+	t.Run("숫자 표기", func(t *testing.T) {
+		assert.Equal(t, "1,234,567", comma(1234567))
+		assert.Equal(t, "-1,000.50", comma(-1000.5))
+		assert.Equal(t, "999.25", comma(999.25))
+		assert.Equal(t, "+500,000", change(10000000, 9500000))
+		assert.Equal(t, "-95", change(5, 100))
+		assert.Equal(t, "+5.26%", rate(10000000, 9500000))
+		assert.Equal(t, "-", rate(1, 0))
+		assert.Equal(t, "60.0%", percent(1, 0.6))
+	})
+
+	t.Run("언어 변환", func(t *testing.T) {
+		l, err := ToLocale("")
+		assert.NoError(t, err)
+		assert.Equal(t, Korean, l)
+
+		l, err = ToLocale("EN")
+		assert.NoError(t, err)
+		assert.Equal(t, English, l)
+
+		_, err = ToLocale("ja")
+		assert.ErrorContains(t, err, "지원하지 않는 언어")
+	})
+}
+
+func write(t *testing.T, dir string, name string, src string) {
+	path := filepath.Join(dir, name)
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = os.WriteFile(path, []byte(src), 0o644)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
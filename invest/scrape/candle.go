@@ -0,0 +1,324 @@
+package scrape
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	m "invest/model"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// config에 url 미존재 시 기본 url 사용
+const (
+	kisDailyDefaultUrl    = "https://openapi.koreainvestment.com:9443/uapi/domestic-stock/v1/quotations/inquire-daily-itemchartprice?FID_COND_MRKT_DIV_CODE=J&FID_INPUT_ISCD=%s&FID_INPUT_DATE_1=%s&FID_INPUT_DATE_2=%s&FID_PERIOD_DIV_CODE=D&FID_ORG_ADJ_PRC=0"
+	kisForDailyDefaultUrl = "https://openapi.koreainvestment.com:9443/uapi/overseas-price/v1/quotations/dailyprice?AUTH=&EXCD=%s&SYMB=%s&GUBN=0&BYMD=%s&MODP=1"
+	upbitDayDefaultUrl    = "https://api.upbit.com/v1/candles/days?market=%s&to=%s&count=200"
+)
+
+// 페이지 반복 조회 상한. (일봉 100~200건 * 50회)
+const candleMaxPage = 50
+
+func (s *Scraper) apiUrl(target string, defaultUrl string) string {
+	url := s.t.ApiBaseUrl(target)
+	if url == "" {
+		return defaultUrl
+	}
+	return url
+}
+
+/*
+DailyCandles
+from ~ to 기간의 일봉 조회. 날짜 오름차순 반환, AssetID는 미지정
+  - 국내주식/ETF/금 : 국내주식기간별시세(일/주/월/년)[v1_국내주식-016]
+  - 해외주식/ETF : 해외주식 기간별시세[v1_해외주식-010]
+  - 코인 : upbit 일(Day) 캔들
+*/
+func (s *Scraper) DailyCandles(category m.Category, code string, from time.Time, to time.Time) ([]m.DailyPrice, error) {
+
+	var candles []m.DailyPrice
+	var err error
+
+	if !category.HasDailyCandles() {
+		return nil, errors.New("일봉 조회 미지원 종목")
+	}
+
+	switch category {
+	case m.DomesticStock, m.DomesticETF, m.Gold:
+		candles, err = s.kisDomesticCandles(code, from, to)
+	case m.ForeignStock, m.ForeignETF:
+		candles, err = s.kisForeignCandles(code, from, to)
+	case m.DomesticCoin:
+		candles, err = s.upbitCandles(code, from, to)
+	default:
+		return nil, errors.New("일봉 조회 미지원 종목")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return sortCandles(candles, from, to), nil
+}
+
+// 기간 내 데이터만 남기고, 중복 일자 제거 후 오름차순 정렬
+func sortCandles(candles []m.DailyPrice, from time.Time, to time.Time) []m.DailyPrice {
+
+	start, end := dateOf(from), dateOf(to)
+
+	seen := make(map[time.Time]bool)
+	rtn := make([]m.DailyPrice, 0, len(candles))
+	for _, c := range candles {
+		d := time.Time(c.Date)
+		if d.Before(start) || d.After(end) || seen[d] {
+			continue
+		}
+		seen[d] = true
+		rtn = append(rtn, c)
+	}
+
+	sort.Slice(rtn, func(i, j int) bool {
+		return time.Time(rtn[i].Date).Before(time.Time(rtn[j].Date))
+	})
+	return rtn
+}
+
+func dateOf(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, time.Local)
+}
+
+func parseDate(layout string, v string) (datatypes.Date, error) {
+	t, err := time.ParseInLocation(layout, v, time.Local)
+	if err != nil {
+		return datatypes.Date{}, err
+	}
+	return datatypes.Date(dateOf(t)), nil
+}
+
+// 응답 값이 문자열로 넘어오므로 필드별 파싱
+func parseCandle(date string, layout string, open, high, low, close, volume string) (m.DailyPrice, error) {
+
+	d, err := parseDate(layout, date)
+	if err != nil {
+		return m.DailyPrice{}, err
+	}
+
+	values := make([]float64, 5)
+	for i, v := range []string{open, high, low, close, volume} {
+		if v == "" {
+			continue
+		}
+		values[i], err = strconv.ParseFloat(v, 64)
+		if err != nil {
+			return m.DailyPrice{}, err
+		}
+	}
+
+	return m.DailyPrice{
+		Date:   d,
+		Open:   values[0],
+		High:   values[1],
+		Low:    values[2],
+		Close:  values[3],
+		Volume: values[4],
+	}, nil
+}
+
+func (s *Scraper) kisHeader(trId string) (map[string]string, error) {
+
+	token, err := s.KisToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{
+		"Content-Type":  "application/json",
+		"authorization": "Bearer " + token,
+		"appkey":        s.kis.appKey,
+		"appsecret":     s.kis.appSecret,
+		"tr_id":         trId,
+	}, nil
+}
+
+// 1회 최대 100건 반환. 가장 오래된 일자 이전으로 범위를 옮기며 반복 조회
+func (s *Scraper) kisDomesticCandles(code string, from time.Time, to time.Time) ([]m.DailyPrice, error) {
+
+	type dailyResp struct {
+		Msg    string              `json:"msg1"`
+		RtCd   string              `json:"rt_cd"`
+		Output []map[string]string `json:"output2"`
+	}
+
+	baseUrl := s.apiUrl("KIS_DAILY", kisDailyDefaultUrl)
+
+	var rtn []m.DailyPrice
+	end := to
+	for range candleMaxPage {
+		header, err := s.kisHeader("FHKST03010100")
+		if err != nil {
+			return nil, err
+		}
+
+		url := fmt.Sprintf(baseUrl, code, from.Format("20060102"), end.Format("20060102"))
+
+		var resp dailyResp
+		err = sendRequest(url, http.MethodGet, header, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.RtCd != "0" {
+			return nil, fmt.Errorf("국내주식 기간별 시세 API 실패 코드 반환. %s", resp.Msg)
+		}
+
+		oldest := end
+		for _, o := range resp.Output {
+			if o["stck_bsop_date"] == "" {
+				continue
+			}
+			c, err := parseCandle(o["stck_bsop_date"], "20060102", o["stck_oprc"], o["stck_hgpr"], o["stck_lwpr"], o["stck_clpr"], o["acml_vol"])
+			if err != nil {
+				return nil, err
+			}
+			rtn = append(rtn, c)
+			if d := time.Time(c.Date); d.Before(oldest) {
+				oldest = d
+			}
+		}
+
+		if len(resp.Output) < 100 || !oldest.After(from) {
+			break
+		}
+		end = oldest.AddDate(0, 0, -1)
+	}
+
+	return rtn, nil
+}
+
+// 기준일(BYMD)로부터 과거 100건 반환. 가장 오래된 일자 이전으로 기준일을 옮기며 반복 조회
+func (s *Scraper) kisForeignCandles(code string, from time.Time, to time.Time) ([]m.DailyPrice, error) {
+
+	params := strings.Split(code, "-")
+	if len(params) != 2 {
+		return nil, fmt.Errorf("해외 종목 코드 형식 오류. %s", code)
+	}
+
+	type dailyResp struct {
+		Msg    string              `json:"msg1"`
+		RtCd   string              `json:"rt_cd"`
+		Output []map[string]string `json:"output2"`
+	}
+
+	baseUrl := s.apiUrl("KIS_FOR_DAILY", kisForDailyDefaultUrl)
+
+	var rtn []m.DailyPrice
+	end := to
+	for range candleMaxPage {
+		header, err := s.kisHeader("HHDFS76240000")
+		if err != nil {
+			return nil, err
+		}
+
+		url := fmt.Sprintf(baseUrl, params[0], params[1], end.Format("20060102"))
+
+		var resp dailyResp
+		err = sendRequest(url, http.MethodGet, header, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		if resp.RtCd != "0" {
+			return nil, fmt.Errorf("해외주식 기간별 시세 API 실패 코드 반환. %s", resp.Msg)
+		}
+
+		oldest := end
+		for _, o := range resp.Output {
+			if o["xymd"] == "" {
+				continue
+			}
+			c, err := parseCandle(o["xymd"], "20060102", o["open"], o["high"], o["low"], o["clos"], o["tvol"])
+			if err != nil {
+				return nil, err
+			}
+			rtn = append(rtn, c)
+			if d := time.Time(c.Date); d.Before(oldest) {
+				oldest = d
+			}
+		}
+
+		if len(resp.Output) < 100 || !oldest.After(from) {
+			break
+		}
+		end = oldest.AddDate(0, 0, -1)
+	}
+
+	return rtn, nil
+}
+
+// to 이전 최대 200건 반환. 가장 오래된 캔들 시각으로 to를 옮기며 반복 조회
+func (s *Scraper) upbitCandles(code string, from time.Time, to time.Time) ([]m.DailyPrice, error) {
+
+	type dayCandle struct {
+		DateKst string  `json:"candle_date_time_kst"`
+		Open    float64 `json:"opening_price"`
+		High    float64 `json:"high_price"`
+		Low     float64 `json:"low_price"`
+		Close   float64 `json:"trade_price"`
+		Volume  float64 `json:"candle_acc_trade_volume"`
+	}
+
+	baseUrl := s.apiUrl("upbit_candle", upbitDayDefaultUrl)
+
+	var rtn []m.DailyPrice
+	end := dateOf(to).AddDate(0, 0, 1)
+	for range candleMaxPage {
+
+		url := fmt.Sprintf(baseUrl, code, end.In(kst).Format("2006-01-02T15:04:05")+"%2B09:00")
+
+		var raw json.RawMessage
+		err := sendRequest(url, http.MethodGet, nil, nil, &raw)
+		if err != nil {
+			return nil, err
+		}
+
+		var e upbitError
+		if json.Unmarshal(raw, &e) == nil && e.Error != nil {
+			return nil, fmt.Errorf("upbit 캔들 API 오류 반환. %s %s", e.Error.Name, e.Error.Message)
+		}
+
+		var candles []dayCandle
+		err = json.Unmarshal(raw, &candles)
+		if err != nil {
+			return nil, fmt.Errorf("upbit 캔들 API 응답 파싱 실패. %w", err)
+		}
+
+		oldest := end
+		for _, c := range candles {
+			d, err := parseDate("2006-01-02T15:04:05", c.DateKst)
+			if err != nil {
+				return nil, err
+			}
+			rtn = append(rtn, m.DailyPrice{
+				Date:   d,
+				Open:   c.Open,
+				High:   c.High,
+				Low:    c.Low,
+				Close:  c.Close,
+				Volume: c.Volume,
+			})
+			if t := time.Time(d); t.Before(oldest) {
+				oldest = t
+			}
+		}
+
+		if len(candles) < 200 || !oldest.After(from) {
+			break
+		}
+		end = oldest
+	}
+
+	return rtn, nil
+}
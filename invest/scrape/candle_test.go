@@ -0,0 +1,83 @@
+package scrape
+
+import (
+	"encoding/json"
+	m "invest/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyCandles(t *testing.T) {
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	to := from.AddDate(0, 0, 149)
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch r.URL.Path {
+		case "/domestic":
+			// FID_INPUT_DATE_2 기준 과거 100건
+			end, _ := time.ParseInLocation("20060102", r.URL.Query().Get("FID_INPUT_DATE_2"), time.Local)
+			out := []map[string]string{}
+			for d := end; !d.Before(from) && len(out) < 100; d = d.AddDate(0, 0, -1) {
+				out = append(out, map[string]string{
+					"stck_bsop_date": d.Format("20060102"),
+					"stck_oprc":      "100", "stck_hgpr": "110", "stck_lwpr": "90", "stck_clpr": "105", "acml_vol": "1000",
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]any{"rt_cd": "0", "output2": out})
+		case "/upbit":
+			w.Write([]byte(`[{"candle_date_time_kst":"2024-01-02T09:00:00","opening_price":1,"high_price":3,"low_price":1,"trade_price":2,"candle_acc_trade_volume":10},
+				{"candle_date_time_kst":"2024-01-01T09:00:00","opening_price":1,"high_price":2,"low_price":1,"trade_price":1,"candle_acc_trade_volume":5}]`))
+		case "/upbit-error":
+			w.Write([]byte(`{"error":{"name":"404","message":"Code not found"}}`))
+		}
+	}))
+	defer server.Close()
+
+	s := NewScraper(transmitterMock{urls: map[string]string{
+		"KIS_DAILY":    server.URL + "/domestic?FID_INPUT_ISCD=%s&FID_INPUT_DATE_1=%s&FID_INPUT_DATE_2=%s",
+		"upbit_candle": server.URL + "/upbit?market=%s&to=%s",
+	}}, WithToken("token"))
+
+	t.Run("국내주식 반복 조회", func(t *testing.T) {
+		candles, err := s.DailyCandles(m.DomesticStock, "005930", from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Len(t, candles, 150)
+		assert.Equal(t, from, time.Time(candles[0].Date))
+		assert.Equal(t, 105.0, candles[0].Close)
+	})
+
+	t.Run("코인 일봉 오름차순 정렬", func(t *testing.T) {
+		candles, err := s.DailyCandles(m.DomesticCoin, "KRW-BTC", from, to)
+		assert.NoError(t, err)
+		assert.Len(t, candles, 2)
+		assert.Equal(t, 1.0, candles[0].Close)
+		assert.Equal(t, 2.0, candles[1].Close)
+	})
+
+	t.Run("코인 오류 응답", func(t *testing.T) {
+		s := NewScraper(transmitterMock{urls: map[string]string{
+			"upbit_candle": server.URL + "/upbit-error?market=%s&to=%s",
+		}})
+		_, err := s.DailyCandles(m.DomesticCoin, "KRW-XXX", from, to)
+		assert.ErrorContains(t, err, "Code not found")
+	})
+
+	t.Run("미지원 종목", func(t *testing.T) {
+		_, err := s.DailyCandles(m.Won, "", from, to)
+		assert.Error(t, err)
+	})
+
+	t.Run("전 영업일 종가", func(t *testing.T) {
+		cp, err := s.ClosingPrice(m.DomesticStock, "005930")
+		assert.NoError(t, err)
+		assert.Equal(t, 105.0, cp) // 시가(100)가 아닌 종가
+	})
+}
@@ -2,42 +2,40 @@ package scrape
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"time"
 
-	"github.com/chromedp/cdproto/dom"
 	"github.com/chromedp/chromedp"
 )
 
-func crwalByChromedp() {
-	ctx, cancel := chromedp.NewContext(
-		context.Background(),
-	)
+/*
+renderPage
+headless 브라우저로 JavaScript 렌더링이 필요한 페이지 조회.
+waitSelector 요소가 준비될 때까지 대기 후 전체 HTML 반환. timeout 초과 시 오류
+*/
+func renderPage(url string, waitSelector string, timeout time.Duration) (string, error) {
+
+	ctx, cancel := chromedp.NewContext(context.Background())
 	// to release the browser resources when
 	// it is no longer needed
 	defer cancel()
 
+	ctx, cancelTimeout := context.WithTimeout(ctx, timeout)
+	defer cancelTimeout()
+
 	var html string
 	err := chromedp.Run(ctx,
-		// visit the target page
-		chromedp.Navigate("https://www.oecd.org/en/data/indicators/composite-leading-indicator-cli.html?oecdcontrol-b2a0dbca4d-var3=2008-01&oecdcontrol-b2a0dbca4d-var4=2024-12"),
-		// wait for the page to load
-		chromedp.Sleep(20000*time.Millisecond),
-		// extract the raw HTML from the page
-		chromedp.ActionFunc(func(ctx context.Context) error {
-			// select the root node on the page
-			rootNode, err := dom.GetDocument().Do(ctx)
-			if err != nil {
-				return err
-			}
-			html, err = dom.GetOuterHTML().WithNodeID(rootNode.NodeID).Do(ctx)
-			return err
-		}),
+		chromedp.Navigate(url),
+		chromedp.WaitReady(waitSelector, chromedp.ByQuery),
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
 	)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "", fmt.Errorf("페이지 렌더링 대기 시간 초과. %s (%s)", waitSelector, timeout)
+	}
 	if err != nil {
-		log.Fatal("Error while performing the automation logic:", err)
+		return "", fmt.Errorf("headless 브라우저 조회 실패. %w", err)
 	}
 
-	fmt.Println(html)
+	return html, nil
 }
@@ -0,0 +1,134 @@
+package scrape
+
+import (
+	"errors"
+	"fmt"
+	m "invest/model"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"gorm.io/datatypes"
+)
+
+// config에 crawl.cli 미존재 시 기본 값 사용
+const (
+	oecdCliDefaultUrl  = "https://www.oecd.org/en/data/indicators/composite-leading-indicator-cli.html"
+	oecdCliDefaultWait = ".highcharts-series-group"
+)
+
+var cliRenderTimeout = 60 * time.Second
+
+// 차트 접근성 라벨. ex) "Jan 2024, 100.35. OECD - Total."
+var cliPointLabel = regexp.MustCompile(`^\s*(.+?),\s*(-?\d+(?:\.\d+)?)`)
+
+// 차트 라벨 기간 형식. Highcharts 월 단위 기본 형식
+var cliPeriodLayouts = []string{"Jan 2006", "January 2006"}
+
+/*
+CliSeries
+OECD 경기선행지수(CLI) 월별 시계열. 월 초일 기준 오름차순 반환
+*/
+func (s *Scraper) CliSeries() ([]m.CliIndex, error) {
+
+	url, wait := s.t.CrawlUrlCasspath("cli")
+	if url == "" {
+		url = oecdCliDefaultUrl
+	}
+	if wait == "" {
+		wait = oecdCliDefaultWait
+	}
+
+	html, err := renderPage(url, wait, cliRenderTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCli(html)
+}
+
+// 최근 월 CLI
+func (s *Scraper) CliIdx() (float64, error) {
+
+	series, err := s.CliSeries()
+	if err != nil {
+		return 0, err
+	}
+
+	return series[len(series)-1].Index, nil
+}
+
+/*
+렌더링된 OECD CLI 페이지 파싱
+  - 첫 번째 차트 계열(OECD - Total)의 포인트 접근성 라벨(aria-label) 사용
+  - 데이터 표는 사용자 조작 시에만 생성되어 미사용
+*/
+func parseCli(html string) ([]m.CliIndex, error) {
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		return nil, fmt.Errorf("error creating document\n%w", err)
+	}
+
+	rtn := parseCliChart(doc)
+	if len(rtn) == 0 {
+		return nil, errors.New("CLI 데이터 미존재")
+	}
+
+	// 월 중복 제거 후 오름차순 정렬
+	seen := make(map[time.Time]bool)
+	uniq := make([]m.CliIndex, 0, len(rtn))
+	for _, c := range rtn {
+		d := time.Time(c.CreatedAt)
+		if seen[d] {
+			continue
+		}
+		seen[d] = true
+		uniq = append(uniq, c)
+	}
+	sort.Slice(uniq, func(i, j int) bool {
+		return time.Time(uniq[i].CreatedAt).Before(time.Time(uniq[j].CreatedAt))
+	})
+
+	return uniq, nil
+}
+
+func parseCliChart(doc *goquery.Document) []m.CliIndex {
+
+	var rtn []m.CliIndex
+	doc.Find(".highcharts-series-0 [aria-label]").Each(func(_ int, p *goquery.Selection) {
+		label, _ := p.Attr("aria-label")
+		match := cliPointLabel.FindStringSubmatch(label)
+		if match == nil {
+			return
+		}
+
+		period, ok := parseCliPeriod(match[1])
+		if !ok {
+			return
+		}
+		v, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			return
+		}
+		rtn = append(rtn, m.CliIndex{CreatedAt: period, Index: v})
+	})
+
+	return rtn
+}
+
+// 월 초일로 변환
+func parseCliPeriod(s string) (datatypes.Date, bool) {
+
+	s = strings.TrimSpace(s)
+	for _, layout := range cliPeriodLayouts {
+		t, err := time.ParseInLocation(layout, s, time.Local)
+		if err == nil {
+			return datatypes.Date(time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.Local)), true
+		}
+	}
+	return datatypes.Date{}, false
+}
@@ -0,0 +1,85 @@
+package scrape
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// 렌더링된 OECD CLI 페이지 저장 경로. -update 실행 시 갱신
+const cliFixture = "testdata/oecd_cli.html"
+
+var updateCli = flag.Bool("update", false, "OECD CLI 페이지를 렌더링하여 "+cliFixture+"에 저장 (네트워크, Chrome 필요)")
+
+// 실제 페이지 조회는 TestCliIndex(네트워크 필요). 여기서는 저장된 렌더링 결과와 접근성 라벨 파싱 확인
+func TestParseCli(t *testing.T) {
+
+	month := func(y int, mo time.Month) time.Time {
+		return time.Date(y, mo, 1, 0, 0, 0, 0, time.Local)
+	}
+	series := func(labels ...string) string {
+		html := `<g class="highcharts-series highcharts-series-0">`
+		for _, l := range labels {
+			html += `<path class="highcharts-point" aria-label="` + l + `"></path>`
+		}
+		return html + `</g>`
+	}
+
+	t.Run("포인트 접근성 라벨", func(t *testing.T) {
+		html := series("Oct 2024, 100.45. OECD - Total.", "Sep 2024, 100.38. OECD - Total.", "Oct 2024, 100.45. OECD - Total.", "December 2024, 100.6. OECD - Total.") +
+			`<g class="highcharts-series highcharts-series-1"><path aria-label="Nov 2024, 99.91. United States."></path></g>`
+
+		rtn, err := parseCli(html)
+		assert.NoError(t, err)
+		assert.Len(t, rtn, 3) // 첫 번째 계열만, 중복 제거 후 오름차순
+		assert.Equal(t, month(2024, time.September), time.Time(rtn[0].CreatedAt))
+		assert.Equal(t, 100.38, rtn[0].Index)
+		assert.Equal(t, month(2024, time.December), time.Time(rtn[2].CreatedAt))
+		assert.Equal(t, 100.6, rtn[2].Index)
+	})
+
+	t.Run("렌더링된 OECD 페이지", func(t *testing.T) {
+		if *updateCli {
+			html, err := renderPage(oecdCliDefaultUrl, oecdCliDefaultWait, cliRenderTimeout)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = os.MkdirAll(filepath.Dir(cliFixture), 0o755)
+			if err == nil {
+				err = os.WriteFile(cliFixture, []byte(html), 0o644)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		b, err := os.ReadFile(cliFixture)
+		if os.IsNotExist(err) {
+			t.Skipf("%s 미존재. go test ./scrape -run TestParseCli -update 로 생성", cliFixture)
+		}
+		assert.NoError(t, err)
+
+		rtn, err := parseCli(string(b))
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(rtn), 12) // 페이지 기본 표시 기간은 1년 이상
+		for i, c := range rtn {
+			assert.InDelta(t, 100, c.Index, 10, "기준 100 부근 값. %s", time.Time(c.CreatedAt).Format("2006-01"))
+			if i > 0 {
+				prev := time.Time(rtn[i-1].CreatedAt)
+				assert.Equal(t, prev.AddDate(0, 1, 0), time.Time(c.CreatedAt), "연속된 월")
+			}
+		}
+	})
+
+	t.Run("데이터 미존재", func(t *testing.T) {
+		_, err := parseCli(series("Interactive chart"))
+		assert.Error(t, err)
+
+		_, err = parseCli(`<div class="highcharts-container"></div>`)
+		assert.Error(t, err)
+	})
+}
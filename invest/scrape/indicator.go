@@ -0,0 +1,108 @@
+package scrape
+
+import (
+	"errors"
+	"fmt"
+	m "invest/model"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	kisOverseasIdxDefaultUrl = "https://openapi.koreainvestment.com:9443/uapi/overseas-price/v1/quotations/inquire-daily-chartprice?FID_COND_MRKT_DIV_CODE=%s&FID_INPUT_ISCD=%s&FID_INPUT_DATE_1=%s&FID_INPUT_DATE_2=%s&FID_PERIOD_DIV_CODE=D"
+	kisDomesticIdxDefaultUrl = "https://openapi.koreainvestment.com:9443/uapi/domestic-stock/v1/quotations/inquire-index-price?FID_COND_MRKT_DIV_CODE=U&FID_INPUT_ISCD=%s"
+)
+
+// 지표 수집처별 현재 값 조회
+func (s *Scraper) IndicatorValue(provider m.IndicatorProvider, code string) (float64, error) {
+
+	switch provider {
+	case m.FearGreedProvider:
+		fgi, err := s.FearGreedIndex()
+		return float64(fgi), err
+	case m.ExchangeRateProvider:
+		rate := s.ExchageRate()
+		if rate == 0 {
+			return 0, errors.New("환율 조회 실패")
+		}
+		return rate, nil
+	case m.KisOverseasIdxProvider:
+		return s.kisOverseasIndex("N", code)
+	case m.KisOverseasFxProvider:
+		return s.kisOverseasIndex("X", code)
+	case m.KisTreasuryProvider:
+		return s.kisOverseasIndex("I", code)
+	case m.KisDomesticIdxProvider:
+		return s.kisDomesticIndex(code)
+	}
+
+	return 0, fmt.Errorf("미지원 지표 수집처. %s", provider)
+}
+
+// 해외주식 종목/지수/환율기간별시세(일/주/월/년)[v1_해외주식-012]
+/*
+시장 구분 N : 해외지수, X : 환율, I : 국채
+해당 API로 미국주식 조회 시, 다우30, 나스닥100, S&P500 종목만 조회 가능합니다.
+*/
+func (s *Scraper) kisOverseasIndex(div string, code string) (float64, error) {
+
+	today := time.Now().Format("20060102")
+	url := fmt.Sprintf(s.apiUrl("KIS_OVRS_IDX", kisOverseasIdxDefaultUrl), div, code, today, today)
+
+	header, err := s.kisHeader("FHKST03030100")
+	if err != nil {
+		return 0, err
+	}
+
+	type overseasIdxResp struct {
+		Msg    string `json:"msg1"`
+		RtCd   string `json:"rt_cd"`
+		Output struct {
+			PresentPrice string `json:"ovrs_nmix_prpr"`
+		} `json:"output1"` // value가 string 타입으로 넘어오기에 바로 파싱 X
+	}
+	var rtn overseasIdxResp
+
+	err = sendRequest(url, http.MethodGet, header, nil, &rtn)
+	if err != nil {
+		return 0, err
+	}
+
+	if rtn.RtCd != "0" {
+		return 0, fmt.Errorf("해외 지수 API 조회 실패 코드 반환. %s", rtn.Msg)
+	}
+
+	return strconv.ParseFloat(rtn.Output.PresentPrice, 64)
+}
+
+// 국내업종 현재지수[v1_국내주식-063]
+func (s *Scraper) kisDomesticIndex(code string) (float64, error) {
+
+	url := fmt.Sprintf(s.apiUrl("KIS_DMST_IDX", kisDomesticIdxDefaultUrl), code)
+
+	header, err := s.kisHeader("FHPUP02100000")
+	if err != nil {
+		return 0, err
+	}
+
+	type domesticIdxResp struct {
+		Msg    string `json:"msg1"`
+		RtCd   string `json:"rt_cd"`
+		Output struct {
+			PresentPrice string `json:"bstp_nmix_prpr"`
+		} `json:"output"`
+	}
+	var rtn domesticIdxResp
+
+	err = sendRequest(url, http.MethodGet, header, nil, &rtn)
+	if err != nil {
+		return 0, err
+	}
+
+	if rtn.RtCd != "0" {
+		return 0, fmt.Errorf("국내 지수 API 조회 실패 코드 반환. %s", rtn.Msg)
+	}
+
+	return strconv.ParseFloat(rtn.Output.PresentPrice, 64)
+}
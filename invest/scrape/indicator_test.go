@@ -0,0 +1,70 @@
+package scrape
+
+import (
+	m "invest/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndicatorValue(t *testing.T) {
+
+	var query map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{
+			"div":  r.URL.Query().Get("FID_COND_MRKT_DIV_CODE"),
+			"code": r.URL.Query().Get("FID_INPUT_ISCD"),
+			"tr":   r.Header.Get("tr_id"),
+		}
+		switch r.URL.Path {
+		case "/overseas":
+			w.Write([]byte(`{"rt_cd":"0","output1":{"ovrs_nmix_prpr":"17556.03"}}`))
+		case "/domestic":
+			w.Write([]byte(`{"rt_cd":"0","output":{"bstp_nmix_prpr":"2583.27"}}`))
+		case "/fail":
+			w.Write([]byte(`{"rt_cd":"1","msg1":"조회할 자료가 없습니다"}`))
+		}
+	}))
+	defer server.Close()
+
+	s := NewScraper(transmitterMock{urls: map[string]string{
+		"KIS_OVRS_IDX": server.URL + "/overseas?FID_COND_MRKT_DIV_CODE=%s&FID_INPUT_ISCD=%s&FID_INPUT_DATE_1=%s&FID_INPUT_DATE_2=%s",
+		"KIS_DMST_IDX": server.URL + "/domestic?FID_INPUT_ISCD=%s",
+	}}, WithToken("token"))
+
+	t.Run("해외 지수", func(t *testing.T) {
+		v, err := s.IndicatorValue(m.KisOverseasIdxProvider, "COMP")
+		assert.NoError(t, err)
+		assert.Equal(t, 17556.03, v)
+		assert.Equal(t, "N", query["div"])
+		assert.Equal(t, "COMP", query["code"])
+	})
+
+	t.Run("국채 금리는 시장 구분 I", func(t *testing.T) {
+		_, err := s.IndicatorValue(m.KisTreasuryProvider, "Y0202")
+		assert.NoError(t, err)
+		assert.Equal(t, "I", query["div"])
+	})
+
+	t.Run("국내 지수", func(t *testing.T) {
+		v, err := s.IndicatorValue(m.KisDomesticIdxProvider, "0001")
+		assert.NoError(t, err)
+		assert.Equal(t, 2583.27, v)
+		assert.Equal(t, "FHPUP02100000", query["tr"])
+	})
+
+	t.Run("실패 코드 반환", func(t *testing.T) {
+		s := NewScraper(transmitterMock{urls: map[string]string{
+			"KIS_DMST_IDX": server.URL + "/fail?FID_INPUT_ISCD=%s",
+		}}, WithToken("token"))
+		_, err := s.IndicatorValue(m.KisDomesticIdxProvider, "0001")
+		assert.ErrorContains(t, err, "조회할 자료가 없습니다")
+	})
+
+	t.Run("미지원 수집처", func(t *testing.T) {
+		_, err := s.IndicatorValue("unknown", "")
+		assert.Error(t, err)
+	})
+}
@@ -10,22 +10,46 @@ import (
 	"time"
 )
 
+var kisTokenUrl = "https://openapi.koreainvestment.com:9443/oauth2/tokenP"
+
 type TokenResponse struct {
 	AccessToken string `json:"access_token"`
 	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
 	Expired     string `json:"access_token_token_expired"`
+	ErrorCode   string `json:"error_code"`
+	ErrorDesc   string `json:"error_description"`
 }
 
+/*
+KIS는 토큰 발급 횟수를 제한하므로, 발급받은 토큰은 store에 보관하여 재기동 후에도 재사용.
+만료 tokenRefreshMargin 전에 미리 갱신하며, mutex로 동시 호출 시에도 한 번만 발급 요청.
+*/
 func (s *Scraper) KisToken() (string, error) {
 
-	if s.kis.accessToken != "" && strings.Compare(s.kis.tokenExpired, time.Now().Format("2006-01-02 15:04:05")) == 1 {
-		return s.kis.accessToken, nil
+	k := s.kis.token
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := time.Now()
+	if k.valid(now) {
+		return k.accessToken, nil
 	}
 
-	url := "https://openapi.koreainvestment.com:9443/oauth2/tokenP"
+	// 메모리에 없으면 저장된 토큰 사용
+	if k.store != nil {
+		token, expired, err := k.store.LoadToken()
+		if err == nil {
+			k.accessToken = token
+			k.expired = expired
+			if k.valid(now) {
+				return k.accessToken, nil
+			}
+		}
+	}
 
 	var token TokenResponse
-	err := sendRequest(url, http.MethodPost, nil, map[string]string{
+	err := sendRequest(kisTokenUrl, http.MethodPost, nil, map[string]string{
 		"grant_type": "client_credentials",
 		"appkey":     s.kis.appKey,
 		"appsecret":  s.kis.appSecret,
@@ -34,12 +58,27 @@ func (s *Scraper) KisToken() (string, error) {
 		return "", err
 	}
 
-	log.Println(token.AccessToken)
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("KIS 토큰 발급 실패. %s %s", token.ErrorCode, token.ErrorDesc)
+	}
+
+	expired, err := time.ParseInLocation(kisTimeFormat, token.Expired, kst)
+	if err != nil {
+		expired = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+
+	k.accessToken = token.AccessToken
+	k.expired = expired
 
-	s.kis.accessToken = token.AccessToken
-	s.kis.tokenExpired = token.Expired
+	if k.store != nil {
+		err = k.store.SaveToken(k.accessToken, k.expired)
+		if err != nil {
+			log.Printf("KIS 토큰 저장 시 오류 발생. %s", err)
+		}
+	}
+	log.Printf("KIS 토큰 발급 완료. 만료 시각 : %s", k.expired.In(kst).Format(kisTimeFormat))
 
-	return token.AccessToken, nil
+	return k.accessToken, nil
 }
 
 type KIsResp struct {
@@ -175,56 +214,6 @@ func (s *Scraper) kisForeignPrice(code string) (pp, cp float64, err error) {
 	return pp, cp, nil
 }
 
-// 해외주식 종목/지수/환율기간별시세(일/주/월/년)[v1_해외주식-012]
-/*
-해당 API로 미국주식 조회 시, 다우30, 나스닥100, S&P500 종목만 조회 가능합니다.
-더 많은 미국주식 종목 시세를 이용할 시에는, 해외주식기간별시세 API
-*/
-func (s *Scraper) kisNasdaqIndex() (float64, error) {
-
-	today := time.Now().Format("20060102")
-	url := fmt.Sprintf(s.t.ApiBaseUrl("KIS_IDX"), today, today)
-
-	token, err := s.KisToken()
-	if err != nil {
-		return 0, err
-	}
-
-	header := map[string]string{
-		"Content-Type":  "application/json",
-		"authorization": "Bearer " + token,
-		"appkey":        s.kis.appKey,
-		"appsecret":     s.kis.appSecret,
-		"tr_id":         "FHKST03030100",
-	}
-
-	type NasdaqResp struct {
-		Msg    string `json:"msg1"`
-		MsgCd  string `json:"msg_cd"`
-		RtCd   string `json:"rt_cd"`
-		Output struct {
-			PresentPrice string `json:"ovrs_nmix_prpr"`
-		} `json:"output1"` // value가 string 타입으로 넘어오기에 바로 파싱 X
-	}
-	var rtn NasdaqResp //TempResp
-
-	err = sendRequest(url, http.MethodGet, header, nil, &rtn)
-	if err != nil {
-		return 0, err
-	}
-
-	if rtn.RtCd != "0" {
-		return 0, errors.New("나스닥 인덱스 API 조회 실패 코드 반환")
-	}
-
-	pp, err := strconv.ParseFloat(rtn.Output.PresentPrice, 64)
-	if err != nil {
-		return 0, err
-	}
-
-	return pp, nil
-}
-
 func (s *Scraper) kisDomesticEtfPrice(code string) (StockPrice, error) {
 
 	url := s.t.ApiBaseUrl("KIS_ETF")
@@ -0,0 +1,391 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	m "invest/model"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+)
+
+var kisApprovalUrl = "https://openapi.koreainvestment.com:9443/oauth2/Approval"
+
+const kisWsDefaultUrl = "ws://ops.koreainvestment.com:21000"
+
+// 실시간 시세 TR
+const (
+	kisDomesticTr = "H0STCNT0" // 국내주식 실시간체결가
+	kisForeignTr  = "HDFSCNT0" // 해외주식 실시간지연체결가
+)
+
+type subscription struct {
+	category m.Category
+	trId     string
+	trKey    string
+}
+
+/*
+KisStream
+KIS 실시간 시세 WebSocket 클라이언트.
+구독 목록을 보관하여, 재접속 시 전체 재구독
+*/
+type KisStream struct {
+	s      *Scraper
+	url    string
+	onTick func(Tick)
+
+	mu          sync.Mutex // subs, conn, approvalKey 보호
+	subs        map[string]subscription
+	conn        *lockedWriter
+	approvalKey string
+}
+
+func (s *Scraper) KisStream(onTick func(Tick)) *KisStream {
+
+	url := s.t.ApiBaseUrl("KIS_WS")
+	if url == "" {
+		url = kisWsDefaultUrl
+	}
+
+	return &KisStream{
+		s:      s,
+		url:    url,
+		onTick: onTick,
+		subs:   make(map[string]subscription),
+	}
+}
+
+// 실시간 시세 접속키 발급
+func (s *Scraper) KisApprovalKey() (string, error) {
+
+	type approvalResp struct {
+		ApprovalKey string `json:"approval_key"`
+		ErrorCode   string `json:"error_code"`
+		ErrorDesc   string `json:"error_description"`
+	}
+	var rtn approvalResp
+
+	err := sendRequest(kisApprovalUrl, http.MethodPost, nil, map[string]string{
+		"grant_type": "client_credentials",
+		"appkey":     s.kis.appKey,
+		"secretkey":  s.kis.appSecret,
+	}, &rtn)
+	if err != nil {
+		return "", err
+	}
+
+	if rtn.ApprovalKey == "" {
+		return "", fmt.Errorf("KIS 실시간 접속키 발급 실패. %s %s", rtn.ErrorCode, rtn.ErrorDesc)
+	}
+
+	return rtn.ApprovalKey, nil
+}
+
+func (k *KisStream) Supports(category m.Category) bool {
+	return category == m.DomesticStock || category == m.DomesticETF || category == m.ForeignStock || category == m.ForeignETF
+}
+
+func (k *KisStream) Subscribe(category m.Category, code string) error {
+
+	sub, err := newSubscription(category, code)
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if _, ok := k.subs[code]; ok {
+		return nil
+	}
+	k.subs[code] = sub
+
+	// 미접속 상태라면 접속 시 일괄 구독
+	if k.conn == nil {
+		return nil
+	}
+	return k.send(sub, true)
+}
+
+func (k *KisStream) Unsubscribe(code string) error {
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	sub, ok := k.subs[code]
+	if !ok {
+		return nil
+	}
+	delete(k.subs, code)
+
+	if k.conn == nil {
+		return nil
+	}
+	return k.send(sub, false)
+}
+
+// 구독 중인 종목 코드 목록
+func (k *KisStream) Codes() []string {
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	codes := make([]string, 0, len(k.subs))
+	for code := range k.subs {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// ctx 종료 전까지 접속 유지. 연결이 끊기면 재접속하여 구독 목록 재등록
+func (k *KisStream) Run(ctx context.Context) {
+	keepAlive(ctx, "KisStream", k.connect)
+}
+
+// 접속 후 연결이 끊길 때까지 수신. 접속 성공 여부와 종료 사유 반환
+func (k *KisStream) connect(ctx context.Context) (bool, error) {
+
+	k.mu.Lock()
+	key := k.approvalKey
+	k.mu.Unlock()
+
+	if key == "" {
+		var err error
+		key, err = k.s.KisApprovalKey()
+		if err != nil {
+			return false, err
+		}
+		k.mu.Lock()
+		k.approvalKey = key
+		k.mu.Unlock()
+	}
+
+	conn, err := dial(ctx, k.url)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	w := &lockedWriter{w: conn}
+
+	k.mu.Lock()
+	k.conn = w
+	for _, sub := range k.subs {
+		err = k.send(sub, true)
+		if err != nil {
+			break
+		}
+	}
+	k.mu.Unlock()
+
+	defer func() {
+		k.mu.Lock()
+		k.conn = nil
+		k.mu.Unlock()
+	}()
+
+	if err != nil {
+		return true, err
+	}
+
+	rw := readWriter{conn.r, w}
+	for {
+		data, op, err := wsutil.ReadServerData(rw)
+		if err != nil {
+			return true, err
+		}
+		if op != ws.OpText {
+			continue
+		}
+
+		err = k.handle(w, data)
+		if err != nil {
+			return true, err
+		}
+	}
+}
+
+/*
+수신 메시지 처리
+  - JSON : 구독 응답 혹은 PINGPONG
+  - 0|TR_ID|건수|데이터 : 실시간 체결 데이터 (^ 구분)
+*/
+func (k *KisStream) handle(w *lockedWriter, data []byte) error {
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	if data[0] == '{' {
+		var resp struct {
+			Header struct {
+				TrId  string `json:"tr_id"`
+				TrKey string `json:"tr_key"`
+			} `json:"header"`
+			Body struct {
+				RtCd  string `json:"rt_cd"`
+				MsgCd string `json:"msg_cd"`
+				Msg   string `json:"msg1"`
+			} `json:"body"`
+		}
+		err := json.Unmarshal(data, &resp)
+		if err != nil {
+			return nil
+		}
+
+		if resp.Header.TrId == "PINGPONG" {
+			return w.writeText(data)
+		}
+		if resp.Body.RtCd != "" && resp.Body.RtCd != "0" {
+			log.Printf("[KisStream] 구독 실패. %s %s %s", resp.Header.TrKey, resp.Body.MsgCd, resp.Body.Msg)
+
+			// 접속키 만료 시 재발급을 위해 연결 종료
+			if resp.Body.MsgCd == "OPSP0011" {
+				k.mu.Lock()
+				k.approvalKey = ""
+				k.mu.Unlock()
+				return errors.New("실시간 접속키 만료")
+			}
+		}
+		return nil
+	}
+
+	// 암호화된 데이터(1)는 체결 통보 용도로 미사용
+	if data[0] != '0' {
+		return nil
+	}
+
+	tick, err := parseTick(string(data))
+	if err != nil {
+		log.Printf("[KisStream] 실시간 데이터 파싱 실패. %s", err)
+		return nil
+	}
+
+	k.mu.Lock()
+	sub, ok := k.subs[tick.Code]
+	k.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	tick.Category = sub.category
+
+	if k.onTick != nil {
+		k.onTick(tick)
+	}
+	return nil
+}
+
+// 호출 전 k.mu 잠금 필요
+func (k *KisStream) send(sub subscription, subscribe bool) error {
+
+	trType := "1"
+	if !subscribe {
+		trType = "2"
+	}
+
+	msg := map[string]any{
+		"header": map[string]string{
+			"approval_key": k.approvalKey,
+			"custtype":     "P",
+			"tr_type":      trType,
+			"content-type": "utf-8",
+		},
+		"body": map[string]any{
+			"input": map[string]string{
+				"tr_id":  sub.trId,
+				"tr_key": sub.trKey,
+			},
+		},
+	}
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return k.conn.writeText(b)
+}
+
+func newSubscription(category m.Category, code string) (subscription, error) {
+
+	switch category {
+	case m.DomesticStock, m.DomesticETF:
+		return subscription{category: category, trId: kisDomesticTr, trKey: code}, nil
+	case m.ForeignStock, m.ForeignETF:
+		// NAS-MSFT => DNASMSFT
+		params := strings.Split(code, "-")
+		if len(params) != 2 {
+			return subscription{}, fmt.Errorf("해외 종목 코드 형식 오류. %s", code)
+		}
+		return subscription{category: category, trId: kisForeignTr, trKey: "D" + params[0] + params[1]}, nil
+	}
+
+	return subscription{}, fmt.Errorf("실시간 시세 미지원 종목. %s", category)
+}
+
+/*
+0|H0STCNT0|001|005930^093354^71900^...
+0|HDFSCNT0|001|DNASAAPL^AAPL^4^20240101^...
+데이터 건수가 여러 건이면 마지막 건 사용
+*/
+func parseTick(data string) (Tick, error) {
+
+	parts := strings.SplitN(data, "|", 4)
+	if len(parts) != 4 {
+		return Tick{}, errors.New("실시간 데이터 형식 오류")
+	}
+
+	cnt, err := strconv.Atoi(parts[2])
+	if err != nil || cnt < 1 {
+		return Tick{}, errors.New("실시간 데이터 건수 오류")
+	}
+
+	fields := strings.Split(parts[3], "^")
+	size := len(fields) / cnt
+	last := fields[(cnt-1)*size:]
+
+	var code string
+	var priceIdx int
+	switch parts[1] {
+	case kisDomesticTr:
+		code, priceIdx = last[0], 2 // MKSC_SHRN_ISCD, STCK_PRPR
+	case kisForeignTr:
+		code, priceIdx = foreignCode(last[0]), 11 // RSYM, LAST
+	default:
+		return Tick{}, fmt.Errorf("미지원 TR. %s", parts[1])
+	}
+
+	if len(last) <= priceIdx {
+		return Tick{}, errors.New("실시간 데이터 필드 부족")
+	}
+
+	price, err := strconv.ParseFloat(last[priceIdx], 64)
+	if err != nil {
+		return Tick{}, err
+	}
+
+	return Tick{
+		Code:  code,
+		Price: price,
+		Time:  time.Now(),
+	}, nil
+}
+
+// DNASMSFT => NAS-MSFT
+func foreignCode(rsym string) string {
+	if len(rsym) < 4 {
+		return rsym
+	}
+	return rsym[1:4] + "-" + rsym[4:]
+}
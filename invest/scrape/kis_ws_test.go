@@ -0,0 +1,165 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	m "invest/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/stretchr/testify/assert"
+)
+
+type transmitterMock struct {
+	urls map[string]string
+}
+
+func (t transmitterMock) ApiBaseUrl(target string) string {
+	return t.urls[target]
+}
+func (t transmitterMock) ApiHeader(target string) map[string]string {
+	return nil
+}
+func (t transmitterMock) CrawlUrlCasspath(target string) (url string, cssPath string) {
+	return "", ""
+}
+
+func TestParseTick(t *testing.T) {
+
+	t.Run("국내 주식", func(t *testing.T) {
+		tick, err := parseTick("0|H0STCNT0|001|005930^093354^71900^5^-100")
+		assert.NoError(t, err)
+		assert.Equal(t, "005930", tick.Code)
+		assert.Equal(t, 71900.0, tick.Price)
+	})
+
+	t.Run("여러 건 중 마지막 건 사용", func(t *testing.T) {
+		tick, err := parseTick("0|H0STCNT0|002|005930^093354^71900^5^-100^005930^093355^72000^5^0")
+		assert.NoError(t, err)
+		assert.Equal(t, 72000.0, tick.Price)
+	})
+
+	t.Run("해외 주식", func(t *testing.T) {
+		tick, err := parseTick("0|HDFSCNT0|001|DNASMSFT^MSFT^4^20240101^20240101^093000^20240101^233000^410^412^409^411.25^2")
+		assert.NoError(t, err)
+		assert.Equal(t, "NAS-MSFT", tick.Code)
+		assert.Equal(t, 411.25, tick.Price)
+	})
+
+	t.Run("형식 오류", func(t *testing.T) {
+		_, err := parseTick("0|H0STCNT0|001")
+		assert.Error(t, err)
+	})
+}
+
+func TestKisStream(t *testing.T) {
+
+	approval := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"approval_key": "approval"})
+	}))
+	defer approval.Close()
+
+	origin := kisApprovalUrl
+	kisApprovalUrl = approval.URL
+	defer func() { kisApprovalUrl = origin }()
+
+	originRetry := streamRetryMin
+	streamRetryMin = 10 * time.Millisecond
+	defer func() { streamRetryMin = originRetry }()
+
+	subscribed := make(chan string, 10)
+	pongs := make(chan string, 10)
+	conns := 0
+
+	// 구독 요청 수신 시 체결 데이터 전송. 첫 연결은 데이터 전송 후 끊어 재접속 유도
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _, err := ws.UpgradeHTTP(r, w)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conns++
+		first := conns == 1
+
+		for {
+			msg, err := wsutil.ReadClientText(conn)
+			if err != nil {
+				return
+			}
+			var req struct {
+				Header map[string]string `json:"header"`
+				Body   struct {
+					Input map[string]string `json:"input"`
+				} `json:"body"`
+			}
+			json.Unmarshal(msg, &req)
+			if req.Header["tr_type"] != "1" {
+				continue
+			}
+			subscribed <- req.Body.Input["tr_key"]
+
+			wsutil.WriteServerText(conn, []byte("0|H0STCNT0|001|"+req.Body.Input["tr_key"]+"^093354^71900^5"))
+			if first {
+				return
+			}
+
+			wsutil.WriteServerText(conn, []byte(`{"header":{"tr_id":"PINGPONG"}}`))
+			pong, err := wsutil.ReadClientText(conn)
+			if err == nil {
+				pongs <- string(pong)
+			}
+		}
+	}))
+	defer server.Close()
+
+	s := NewScraper(transmitterMock{urls: map[string]string{
+		"KIS_WS": "ws" + strings.TrimPrefix(server.URL, "http"),
+	}})
+
+	ticks := make(chan Tick, 10)
+	stream := s.KisStream(func(t Tick) { ticks <- t })
+
+	assert.NoError(t, stream.Subscribe(m.DomesticStock, "005930"))
+	assert.Error(t, stream.Subscribe(m.DomesticCoin, "KRW-BTC"))
+	assert.Equal(t, []string{"005930"}, stream.Codes())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stream.Run(ctx)
+
+	t.Run("구독 및 체결 수신", func(t *testing.T) {
+		assert.Equal(t, "005930", waitFor(t, subscribed))
+		tick := waitFor(t, ticks)
+		assert.Equal(t, "005930", tick.Code)
+		assert.Equal(t, m.DomesticStock, tick.Category)
+		assert.Equal(t, 71900.0, tick.Price)
+	})
+
+	t.Run("재접속 시 재구독", func(t *testing.T) {
+		assert.Equal(t, "005930", waitFor(t, subscribed))
+		waitFor(t, ticks)
+		assert.Contains(t, waitFor(t, pongs), "PINGPONG")
+	})
+
+	t.Run("구독 해제", func(t *testing.T) {
+		assert.NoError(t, stream.Unsubscribe("005930"))
+		assert.Empty(t, stream.Codes())
+	})
+}
+
+func waitFor[T any](t *testing.T, ch <-chan T) T {
+	t.Helper()
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(3 * time.Second):
+		t.Fatal("timeout")
+	}
+	var zero T
+	return zero
+}
@@ -3,6 +3,8 @@ package scrape
 import (
 	"invest/config"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestKis(t *testing.T) {
@@ -23,7 +25,7 @@ func TestKis(t *testing.T) {
 		if err != nil {
 			t.Error(err)
 		}
-		t.Log(token)
+		assert.NotEmpty(t, token) // 토큰 값은 로그에 남기지 않음
 	})
 
 	t.Run("Stock current Price", func(t *testing.T) {
@@ -43,7 +45,15 @@ func TestKis(t *testing.T) {
 	})
 
 	t.Run("Foreign Index", func(t *testing.T) {
-		pp, err := s.kisNasdaqIndex()
+		pp, err := s.kisOverseasIndex("N", "COMP")
+		if err != nil {
+			t.Error(err)
+		}
+		t.Log(pp)
+	})
+
+	t.Run("Domestic Index", func(t *testing.T) {
+		pp, err := s.kisDomesticIndex("0001")
 		if err != nil {
 			t.Error(err)
 		}
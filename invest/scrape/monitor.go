@@ -0,0 +1,119 @@
+package scrape
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	m "invest/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// 감시 대상 페이지 응답 대기 한도. 응답 없는 페이지가 감시 작업을 붙잡지 않도록 제한
+var monitorTimeout = 10 * time.Second
+
+/*
+Extract
+감시 대상 페이지에서 값 추출
+  - css : selector에 해당하는 요소들의 텍스트. 여러 건이면 줄바꿈으로 연결
+  - json : a.b[0].c 형태 경로의 값. 문자열이 아니면 JSON 문자열로 반환
+*/
+func (s *Scraper) Extract(url string, kind m.MonitorKind, selector string) (string, error) {
+
+	client := &http.Client{Timeout: monitorTimeout}
+	res, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("error making request\n%w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return "", fmt.Errorf("status code error: %d %s", res.StatusCode, res.Status)
+	}
+
+	switch kind {
+	case m.CssMonitor:
+		doc, err := goquery.NewDocumentFromReader(res.Body)
+		if err != nil {
+			return "", fmt.Errorf("error creating document\n%w", err)
+		}
+
+		sel := doc.Find(selector)
+		if sel.Length() == 0 {
+			return "", fmt.Errorf("selector에 해당하는 요소 미존재. %s", selector)
+		}
+
+		texts := make([]string, 0, sel.Length())
+		sel.Each(func(i int, s *goquery.Selection) {
+			texts = append(texts, strings.TrimSpace(s.Text()))
+		})
+		return strings.Join(texts, "\n"), nil
+
+	case m.JsonMonitor:
+		var data any
+		err = json.NewDecoder(res.Body).Decode(&data)
+		if err != nil {
+			return "", fmt.Errorf("JSON 응답 파싱 실패. %w", err)
+		}
+
+		v, err := jsonPath(data, selector)
+		if err != nil {
+			return "", err
+		}
+		if str, ok := v.(string); ok {
+			return str, nil
+		}
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+
+	return "", fmt.Errorf("미지원 감시 방식. %s", kind)
+}
+
+// $.data.items[0].name, data.items[0].name 형태 지원
+func jsonPath(data any, path string) (any, error) {
+
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "$"), ".")
+	if path == "" {
+		return data, nil
+	}
+
+	cur := data
+	for _, token := range strings.Split(path, ".") {
+		name, idx, _ := strings.Cut(token, "[")
+
+		if name != "" {
+			obj, ok := cur.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("JSON 경로 오류. 객체가 아님. %s", token)
+			}
+			if cur, ok = obj[name]; !ok {
+				return nil, fmt.Errorf("JSON 경로 오류. 필드 미존재. %s", name)
+			}
+		}
+
+		// a[0][1] 형태 연속 인덱스
+		for idx != "" {
+			n, rest, ok := strings.Cut(idx, "]")
+			if !ok {
+				return nil, errors.New("JSON 경로 오류. 괄호 불일치")
+			}
+			i, err := strconv.Atoi(n)
+			if err != nil {
+				return nil, fmt.Errorf("JSON 경로 오류. 인덱스 형식. %s", n)
+			}
+			arr, ok := cur.([]any)
+			if !ok || i < 0 || i >= len(arr) {
+				return nil, fmt.Errorf("JSON 경로 오류. 인덱스 범위. %s", token)
+			}
+			cur = arr[i]
+			idx = strings.TrimPrefix(rest, "[")
+		}
+	}
+
+	return cur, nil
+}
@@ -0,0 +1,75 @@
+package scrape
+
+import (
+	m "invest/model"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtract(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Write([]byte(`<html><body><div class="step"> 예정지구 지정 </div><ul><li>A</li><li>B</li></ul></body></html>`))
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+			w.Write([]byte(`<div>늦은 응답</div>`))
+		case "/json":
+			w.Write([]byte(`{"data":{"items":[{"name":"first","price":100},{"name":"second","tags":[["x","y"]]}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	s := NewScraper(nil)
+
+	t.Run("CSS selector", func(t *testing.T) {
+		v, err := s.Extract(server.URL+"/page", m.CssMonitor, "div.step")
+		assert.NoError(t, err)
+		assert.Equal(t, "예정지구 지정", v)
+
+		v, err = s.Extract(server.URL+"/page", m.CssMonitor, "li")
+		assert.NoError(t, err)
+		assert.Equal(t, "A\nB", v)
+
+		_, err = s.Extract(server.URL+"/page", m.CssMonitor, "span.none")
+		assert.Error(t, err)
+	})
+
+	t.Run("JSON 경로", func(t *testing.T) {
+		v, err := s.Extract(server.URL+"/json", m.JsonMonitor, "$.data.items[0].name")
+		assert.NoError(t, err)
+		assert.Equal(t, "first", v)
+
+		v, err = s.Extract(server.URL+"/json", m.JsonMonitor, "data.items[0].price")
+		assert.NoError(t, err)
+		assert.Equal(t, "100", v)
+
+		v, err = s.Extract(server.URL+"/json", m.JsonMonitor, "data.items[1].tags[0][1]")
+		assert.NoError(t, err)
+		assert.Equal(t, "y", v)
+
+		_, err = s.Extract(server.URL+"/json", m.JsonMonitor, "data.items[5]")
+		assert.Error(t, err)
+	})
+
+	t.Run("응답 오류", func(t *testing.T) {
+		_, err := s.Extract(server.URL+"/none", m.CssMonitor, "div")
+		assert.Error(t, err)
+	})
+
+	t.Run("응답 지연 시 시간 초과", func(t *testing.T) {
+		timeout := monitorTimeout
+		monitorTimeout = 50 * time.Millisecond
+		defer func() { monitorTimeout = timeout }()
+
+		_, err := s.Extract(server.URL+"/slow", m.CssMonitor, "div")
+		assert.ErrorContains(t, err, "Client.Timeout")
+	})
+}
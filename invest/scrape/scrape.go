@@ -19,10 +19,9 @@ type Scraper struct {
 		Date time.Time
 	}
 	kis struct {
-		appKey       string
-		appSecret    string
-		accessToken  string
-		tokenExpired string
+		appKey    string
+		appSecret string
+		token     *kisToken
 	}
 	t transmitter
 }
@@ -37,6 +36,7 @@ func NewScraper(t transmitter, options ...func(*Scraper)) *Scraper {
 	s := &Scraper{
 		t: t,
 	}
+	s.kis.token = &kisToken{}
 
 	for _, opt := range options {
 		opt(s)
@@ -55,8 +55,16 @@ func WithKIS(appKey string, appSecret string) func(*Scraper) {
 func WithToken(token string) func(*Scraper) {
 
 	return func(s *Scraper) {
-		s.kis.accessToken = token
-		s.kis.tokenExpired = time.Now().Add(time.Duration(1) * time.Hour).Format("2006-01-02 15:04:05")
+		s.kis.token.accessToken = token
+		s.kis.token.expired = time.Now().Add(time.Duration(1) * time.Hour).Add(tokenRefreshMargin)
+	}
+}
+
+// 발급받은 KIS 토큰을 store에 보관하여 재기동 시 재사용
+func WithTokenStore(store TokenStore) func(*Scraper) {
+
+	return func(s *Scraper) {
+		s.kis.token.store = store
 	}
 }
 
@@ -110,6 +118,7 @@ func (s *Scraper) TopBottomPrice(category m.Category, code string) (hp float64,
 	return 0, 0, errors.New("최고/최저 호출 API 미존재")
 }
 
+// 전 영업일 종가. 일봉 API의 당일 이전 마지막 종가 사용
 func (s *Scraper) ClosingPrice(category m.Category, code string) (cp float64, err error) {
 
 	switch category {
@@ -118,26 +127,18 @@ func (s *Scraper) ClosingPrice(category m.Category, code string) (cp float64, er
 	case m.Dollar:
 		r := s.ExchageRate()
 		return r, nil
-	case m.DomesticStock, m.Gold:
-		stock, err := s.kisDomesticStockPrice(code)
-		return stock.op, err
-	case m.DomesticCoin:
-		_, cp, err = s.upbitApi(code)
-		return cp, err
-	case m.DomesticETF:
-		stock, err := s.kisDomesticEtfPrice(code)
-		return stock.op, err
-	case m.ForeignStock, m.ForeignETF:
-		_, cp, err := s.kisForeignPrice(code)
-		return cp, err
 	}
 
-	return 0, errors.New("미분류된 종목")
-}
+	to := time.Now().AddDate(0, 0, -1)
+	candles, err := s.DailyCandles(category, code, to.AddDate(0, 0, -10), to)
+	if err != nil {
+		return 0, err
+	}
+	if len(candles) == 0 {
+		return 0, errors.New("종가 조회 결과 미존재")
+	}
 
-func (s *Scraper) RealEstateStatus() (string, error) {
-	url, cssPath := s.t.CrawlUrlCasspath("estate")
-	return s.crawl(url, cssPath)
+	return candles[len(candles)-1].Close, nil
 }
 
 func (s *Scraper) ExchageRate() float64 {
@@ -182,23 +183,12 @@ func (s *Scraper) FearGreedIndex() (uint, error) {
 
 	err := sendRequest(url, http.MethodGet, header, nil, &rtn)
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 
 	return rtn.Fgi.Now.Value, nil
 }
 
-func (s *Scraper) Nasdaq() (float64, error) {
-
-	return s.kisNasdaqIndex()
-}
-
-// todo. 현재로는 크롤링/API 못 찾음
-func (s *Scraper) CliIdx() (float64, error) {
-	// need Chromedp
-	return 0, nil
-}
-
 // depre
 func AlpacaCrypto(target string) (string, error) {
 
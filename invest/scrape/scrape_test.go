@@ -137,18 +137,14 @@ func TestFearGreedIndex(t *testing.T) {
 
 func TestCliIndex(t *testing.T) {
 
-	// s := Scraper{}
-
-	// url := "https://www.oecd.org/en/data/indicators/composite-leading-indicator-cli.html?oecdcontrol-b2a0dbca4d-var3=2008-01&oecdcontrol-b2a0dbca4d-var4=2024-12"
-	// cssPath := "#highcharts-p773b0p-798 > svg > g.highcharts-series-group > g.highcharts-series.highcharts-series-0.highcharts-spline-series > path.highcharts-graph"
-
-	// rtn, err := s.crawl(url, cssPath)
-	// if err != nil {
-	// 	t.Error(err)
-	// }
+	c, _ := config.NewConfig()
+	s := NewScraper(c)
 
-	// assert.NotEmpty(t, rtn)
+	rtn, err := s.CliSeries()
+	if err != nil {
+		t.Error(err)
+	}
 
-	// t.Log(rtn)
-	crwalByChromedp()
+	assert.NotEmpty(t, rtn)
+	t.Logf("%+v", rtn)
 }
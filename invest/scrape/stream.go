@@ -0,0 +1,126 @@
+package scrape
+
+import (
+	"bufio"
+	"context"
+	m "invest/model"
+	"io"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+)
+
+// 재접속 대기 시간
+var (
+	streamRetryMin = 5 * time.Second
+	streamRetryMax = time.Minute
+)
+
+// Tick 실시간 체결가
+type Tick struct {
+	Category m.Category
+	Code     string
+	Price    float64
+	Time     time.Time
+}
+
+/*
+keepAlive
+ctx 종료 전까지 connect 반복 수행. 접속 실패가 이어지면 대기 시간을 streamRetryMax까지 늘림
+connect는 연결이 끊길 때까지 반환하지 않으며, 접속 성공 여부와 종료 사유를 반환
+*/
+func keepAlive(ctx context.Context, name string, connect func(ctx context.Context) (bool, error)) {
+
+	retry := streamRetryMin
+	for {
+		connected, err := connect(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			retry = streamRetryMin
+		}
+		log.Printf("[%s] 연결 종료. %s 후 재접속. %s", name, retry, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+		}
+
+		retry = min(retry*2, streamRetryMax)
+	}
+}
+
+type wsConn struct {
+	net.Conn
+	r    io.Reader
+	br   *bufio.Reader
+	stop func() bool
+}
+
+// ctx 종료 감시 해제 후 연결 종료. 수신 버퍼 반환을 위해 수신 종료 후 한 번만 호출
+func (c *wsConn) Close() error {
+	c.stop()
+	err := c.Conn.Close()
+	if c.br != nil {
+		ws.PutReader(c.br)
+	}
+	return err
+}
+
+// WebSocket 접속. ctx 종료 시 연결을 닫아 read 대기 해제
+func dial(ctx context.Context, url string) (*wsConn, error) {
+
+	conn, br, _, err := ws.Dial(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	var r io.Reader = conn
+	if br != nil {
+		r = io.MultiReader(br, conn)
+	}
+
+	return &wsConn{
+		Conn: conn,
+		r:    r,
+		br:   br,
+		stop: context.AfterFunc(ctx, func() { conn.Close() }),
+	}, nil
+}
+
+type readWriter struct {
+	io.Reader
+	io.Writer
+}
+
+/*
+연결별 쓰기 직렬화. 수신 루프(ping 응답)와 구독 요청이 같은 연결에 쓰기
+  - Write : 수신 중 control frame 응답. 한 번의 Write로 frame 전체 전송
+  - writeText, writeMessage : header와 payload를 나눠 쓰므로 frame 단위로 잠금
+*/
+type lockedWriter struct {
+	mu sync.Mutex
+	w  net.Conn
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+func (l *lockedWriter) writeText(p []byte) error {
+	return l.writeMessage(ws.OpText, p)
+}
+
+func (l *lockedWriter) writeMessage(op ws.OpCode, p []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return wsutil.WriteClientMessage(l.w, op, p)
+}
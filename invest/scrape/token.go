@@ -0,0 +1,144 @@
+package scrape
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// KIS 토큰 만료 시각 포맷. (한국 시간 기준)
+const kisTimeFormat = "2006-01-02 15:04:05"
+
+// 만료 전 미리 갱신할 여유 시간
+const tokenRefreshMargin = time.Hour
+
+var kst = time.FixedZone("KST", 9*60*60)
+
+// TokenStore 발급받은 토큰을 재기동 후에도 재사용할 수 있도록 보관
+type TokenStore interface {
+	LoadToken() (token string, expired time.Time, err error)
+	SaveToken(token string, expired time.Time) error
+}
+
+type kisToken struct {
+	mu          sync.Mutex
+	accessToken string
+	expired     time.Time
+	store       TokenStore
+}
+
+// 만료 tokenRefreshMargin 전까지만 유효한 토큰으로 취급
+func (k *kisToken) valid(now time.Time) bool {
+	return k.accessToken != "" && now.Add(tokenRefreshMargin).Before(k.expired)
+}
+
+/*
+fileTokenStore
+토큰을 AES-GCM으로 암호화하여 파일로 저장. 토큰 값은 평문으로 디스크 및 로그에 남기지 않음
+*/
+type fileTokenStore struct {
+	path string
+	key  []byte
+}
+
+type storedToken struct {
+	Token   string    `json:"token"`
+	Expired time.Time `json:"expired"`
+}
+
+func NewFileTokenStore(path string, key []byte) TokenStore {
+	return &fileTokenStore{
+		path: path,
+		key:  key,
+	}
+}
+
+func (f *fileTokenStore) LoadToken() (string, time.Time, error) {
+
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	plain, err := f.open(b)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var st storedToken
+	err = json.Unmarshal(plain, &st)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return st.Token, st.Expired, nil
+}
+
+func (f *fileTokenStore) SaveToken(token string, expired time.Time) error {
+
+	plain, err := json.Marshal(storedToken{
+		Token:   token,
+		Expired: expired,
+	})
+	if err != nil {
+		return err
+	}
+
+	sealed, err := f.seal(plain)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(f.path, sealed, 0600)
+}
+
+func (f *fileTokenStore) seal(plain []byte) ([]byte, error) {
+
+	gcm, err := f.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, plain, nil)
+	return []byte(hex.EncodeToString(sealed)), nil
+}
+
+func (f *fileTokenStore) open(b []byte) ([]byte, error) {
+
+	sealed, err := hex.DecodeString(string(b))
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := f.gcm()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("저장된 토큰 길이 부족")
+	}
+
+	nonce, cipherText := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, cipherText, nil)
+}
+
+func (f *fileTokenStore) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(f.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
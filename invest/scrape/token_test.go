@@ -0,0 +1,103 @@
+package scrape
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileTokenStore(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "token")
+	store := NewFileTokenStore(path, []byte("0123456789abcdef"))
+
+	expired := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	err := store.SaveToken("secret-token", expired)
+	assert.NoError(t, err)
+
+	t.Run("평문 미저장", func(t *testing.T) {
+		b, err := os.ReadFile(path)
+		assert.NoError(t, err)
+		assert.False(t, strings.Contains(string(b), "secret-token"))
+	})
+
+	t.Run("복호화 조회", func(t *testing.T) {
+		token, exp, err := store.LoadToken()
+		assert.NoError(t, err)
+		assert.Equal(t, "secret-token", token)
+		assert.True(t, expired.Equal(exp))
+	})
+
+	t.Run("잘못된 키", func(t *testing.T) {
+		_, _, err := NewFileTokenStore(path, []byte("fedcba9876543210")).LoadToken()
+		assert.Error(t, err)
+	})
+}
+
+func TestKisTokenRefresh(t *testing.T) {
+
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&issued, 1)
+		time.Sleep(50 * time.Millisecond)
+		json.NewEncoder(w).Encode(TokenResponse{
+			AccessToken: fmt.Sprintf("token%d", n),
+			Expired:     time.Now().In(kst).Add(24 * time.Hour).Format(kisTimeFormat),
+		})
+	}))
+	defer server.Close()
+
+	origin := kisTokenUrl
+	kisTokenUrl = server.URL
+	defer func() { kisTokenUrl = origin }()
+
+	path := filepath.Join(t.TempDir(), "token")
+	key := []byte("0123456789abcdef")
+
+	t.Run("동시 호출 시 한 번만 발급", func(t *testing.T) {
+		s := NewScraper(nil, WithKIS("key", "secret"), WithTokenStore(NewFileTokenStore(path, key)))
+
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				token, err := s.KisToken()
+				assert.NoError(t, err)
+				assert.Equal(t, "token1", token)
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&issued))
+	})
+
+	t.Run("재기동 시 저장된 토큰 재사용", func(t *testing.T) {
+		s := NewScraper(nil, WithKIS("key", "secret"), WithTokenStore(NewFileTokenStore(path, key)))
+
+		token, err := s.KisToken()
+		assert.NoError(t, err)
+		assert.Equal(t, "token1", token)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&issued))
+	})
+
+	t.Run("만료 임박 시 미리 갱신", func(t *testing.T) {
+		store := NewFileTokenStore(path, key)
+		store.SaveToken("token1", time.Now().Add(tokenRefreshMargin/2))
+
+		s := NewScraper(nil, WithKIS("key", "secret"), WithTokenStore(store))
+
+		token, err := s.KisToken()
+		assert.NoError(t, err)
+		assert.Equal(t, "token2", token)
+	})
+}
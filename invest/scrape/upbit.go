@@ -1,11 +1,27 @@
 package scrape
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 )
 
+type upbitTicker struct {
+	Code         string  `json:"code"`
+	Market       string  `json:"market"`
+	TradePrice   float64 `json:"trade_price"`
+	OpeningPrice float64 `json:"opening_price"`
+	Timestamp    int64   `json:"timestamp"`
+}
+
+type upbitError struct {
+	Error *struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
 func (s Scraper) upbitApi(sym string) (float64, float64, error) {
 
 	url := s.t.ApiBaseUrl("upbit")
@@ -14,11 +30,26 @@ func (s Scraper) upbitApi(sym string) (float64, float64, error) {
 	}
 	url = fmt.Sprintf(url, sym)
 
-	var rtn []map[string]any
-	err := sendRequest(url, http.MethodGet, nil, nil, &rtn)
+	// 오류 시 배열이 아닌 {"error": {...}} 반환
+	var raw json.RawMessage
+	err := sendRequest(url, http.MethodGet, nil, nil, &raw)
 	if err != nil {
 		return 0, 0, err
 	}
 
-	return rtn[0]["trade_price"].(float64), rtn[0]["opening_price"].(float64), nil // 시가 = 전날 종가
+	var e upbitError
+	if json.Unmarshal(raw, &e) == nil && e.Error != nil {
+		return 0, 0, fmt.Errorf("upbit API 오류 반환. %s %s", e.Error.Name, e.Error.Message)
+	}
+
+	var rtn []upbitTicker
+	err = json.Unmarshal(raw, &rtn)
+	if err != nil {
+		return 0, 0, fmt.Errorf("upbit API 응답 파싱 실패. %w", err)
+	}
+	if len(rtn) == 0 || rtn[0].TradePrice == 0 {
+		return 0, 0, fmt.Errorf("upbit API 빈 결과값 반환. %s", sym)
+	}
+
+	return rtn[0].TradePrice, rtn[0].OpeningPrice, nil // 시가 = 전날 종가
 }
@@ -0,0 +1,243 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	m "invest/model"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+)
+
+const upbitWsDefaultUrl = "wss://api.upbit.com/websocket/v1"
+
+// upbit는 120초간 송수신이 없으면 연결 종료
+var upbitPingInterval = 60 * time.Second
+
+/*
+UpbitStream
+upbit 실시간 현재가(ticker) WebSocket 클라이언트.
+upbit는 구독 변경 시 전체 목록을 다시 요청해야 하므로, 구독/해제마다 전체 목록 재전송
+*/
+type UpbitStream struct {
+	url    string
+	onTick func(Tick)
+
+	mu    sync.Mutex // codes, conn 보호
+	codes map[string]bool
+	conn  *lockedWriter
+}
+
+func (s *Scraper) UpbitStream(onTick func(Tick)) *UpbitStream {
+
+	url := s.t.ApiBaseUrl("upbit_ws")
+	if url == "" {
+		url = upbitWsDefaultUrl
+	}
+
+	return &UpbitStream{
+		url:    url,
+		onTick: onTick,
+		codes:  make(map[string]bool),
+	}
+}
+
+func (u *UpbitStream) Supports(category m.Category) bool {
+	return category == m.DomesticCoin
+}
+
+func (u *UpbitStream) Subscribe(category m.Category, code string) error {
+
+	if !u.Supports(category) {
+		return fmt.Errorf("실시간 시세 미지원 종목. %s", category)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.codes[code] {
+		return nil
+	}
+	u.codes[code] = true
+
+	if u.conn == nil {
+		return nil
+	}
+	return u.send()
+}
+
+func (u *UpbitStream) Unsubscribe(code string) error {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if !u.codes[code] {
+		return nil
+	}
+	delete(u.codes, code)
+
+	if u.conn == nil {
+		return nil
+	}
+
+	// 빈 목록은 요청할 수 없으므로 연결 종료로 기존 구독 해제. 재접속 후 구독 추가 시 요청
+	if len(u.codes) == 0 {
+		err := u.conn.w.Close()
+		u.conn = nil
+		return err
+	}
+	return u.send()
+}
+
+func (u *UpbitStream) Codes() []string {
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.sortedCodes()
+}
+
+// ctx 종료 전까지 접속 유지. 연결이 끊기면 재접속하여 구독 목록 재등록
+func (u *UpbitStream) Run(ctx context.Context) {
+	keepAlive(ctx, "UpbitStream", u.connect)
+}
+
+func (u *UpbitStream) connect(ctx context.Context) (bool, error) {
+
+	conn, err := dial(ctx, u.url)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	// 구독 해제, ping 실패 시 연결만 종료. 수신 버퍼는 수신 종료 후 conn.Close에서 반환
+	w := &lockedWriter{w: conn.Conn}
+
+	u.mu.Lock()
+	u.conn = w
+	err = u.send()
+	u.mu.Unlock()
+
+	defer func() {
+		u.mu.Lock()
+		u.conn = nil
+		u.mu.Unlock()
+	}()
+
+	if err != nil {
+		return true, err
+	}
+
+	// 연결 유지용 ping
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(upbitPingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if w.writeMessage(ws.OpPing, nil) != nil {
+					conn.Conn.Close()
+					return
+				}
+			}
+		}
+	}()
+
+	rw := readWriter{conn.r, w}
+	for {
+		data, op, err := wsutil.ReadServerData(rw)
+		if err != nil {
+			return true, err
+		}
+		if op != ws.OpText && op != ws.OpBinary {
+			continue
+		}
+
+		u.handle(data)
+	}
+}
+
+/*
+수신 메시지 처리
+  - {"type":"ticker","code":"KRW-BTC","trade_price":...} : 현재가
+  - {"error":{"name":...,"message":...}} : 오류. 로그만 남기고 연결 유지
+  - {"status":"UP"} : 상태 응답
+*/
+func (u *UpbitStream) handle(data []byte) {
+
+	var e upbitError
+	if json.Unmarshal(data, &e) == nil && e.Error != nil {
+		log.Printf("[UpbitStream] 오류 수신. %s %s", e.Error.Name, e.Error.Message)
+		return
+	}
+
+	var t struct {
+		Type string `json:"type"`
+		upbitTicker
+	}
+	err := json.Unmarshal(data, &t)
+	if err != nil || t.Type != "ticker" || t.Code == "" || t.TradePrice == 0 {
+		return
+	}
+
+	u.mu.Lock()
+	ok := u.codes[t.Code]
+	u.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	at := time.Now()
+	if t.Timestamp != 0 {
+		at = time.UnixMilli(t.Timestamp)
+	}
+
+	if u.onTick != nil {
+		u.onTick(Tick{
+			Category: m.DomesticCoin,
+			Code:     t.Code,
+			Price:    t.TradePrice,
+			Time:     at,
+		})
+	}
+}
+
+// 전체 구독 목록 요청. 호출 전 u.mu 잠금 필요
+func (u *UpbitStream) send() error {
+
+	codes := u.sortedCodes()
+	if len(codes) == 0 {
+		return nil
+	}
+
+	req := []map[string]any{
+		{"ticket": "invest-" + strconv.FormatInt(time.Now().UnixNano(), 36)},
+		{"type": "ticker", "codes": codes},
+	}
+
+	b, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	return u.conn.writeText(b)
+}
+
+func (u *UpbitStream) sortedCodes() []string {
+	codes := make([]string, 0, len(u.codes))
+	for code := range u.codes {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
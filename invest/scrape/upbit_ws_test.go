@@ -0,0 +1,140 @@
+package scrape
+
+import (
+	"context"
+	"encoding/json"
+	m "invest/model"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpbitApi(t *testing.T) {
+
+	resp := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(resp))
+	}))
+	defer server.Close()
+
+	s := NewScraper(transmitterMock{urls: map[string]string{
+		"upbit": server.URL + "?markets=%s",
+	}})
+
+	t.Run("성공", func(t *testing.T) {
+		resp = `[{"market":"KRW-BTC","trade_price":98000000,"opening_price":97000000}]`
+		pp, op, err := s.upbitApi("KRW-BTC")
+		assert.NoError(t, err)
+		assert.Equal(t, 98000000.0, pp)
+		assert.Equal(t, 97000000.0, op)
+	})
+
+	t.Run("빈 응답", func(t *testing.T) {
+		resp = `[]`
+		_, _, err := s.upbitApi("KRW-BTC")
+		assert.Error(t, err)
+	})
+
+	t.Run("오류 응답", func(t *testing.T) {
+		resp = `{"error":{"name":"404","message":"Code not found"}}`
+		_, _, err := s.upbitApi("KRW-XXX")
+		assert.ErrorContains(t, err, "Code not found")
+	})
+}
+
+func TestUpbitStream(t *testing.T) {
+
+	originRetry := streamRetryMin
+	streamRetryMin = 10 * time.Millisecond
+	defer func() { streamRetryMin = originRetry }()
+
+	requests := make(chan []string, 10)
+	disconnects := make(chan int, 10)
+	conns := 0
+
+	// 구독 요청마다 오류 프레임과 현재가 전송. 첫 연결은 전송 후 끊어 재접속 유도
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, _, err := ws.UpgradeHTTP(r, w)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conns++
+		first := conns == 1
+		defer func(n int) { disconnects <- n }(conns)
+
+		for {
+			msg, err := wsutil.ReadClientText(conn)
+			if err != nil {
+				return
+			}
+			var req []map[string]any
+			json.Unmarshal(msg, &req)
+
+			var codes []string
+			for _, c := range req[1]["codes"].([]any) {
+				codes = append(codes, c.(string))
+			}
+			requests <- codes
+
+			wsutil.WriteServerBinary(conn, []byte(`{"error":{"name":"INVALID_PARAM","message":"잘못된 요청"}}`))
+			for _, code := range codes {
+				wsutil.WriteServerBinary(conn, []byte(`{"type":"ticker","code":"`+code+`","trade_price":98000000,"timestamp":1700000000000}`))
+			}
+			if first {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	s := NewScraper(transmitterMock{urls: map[string]string{
+		"upbit_ws": "ws" + strings.TrimPrefix(server.URL, "http"),
+	}})
+
+	ticks := make(chan Tick, 10)
+	stream := s.UpbitStream(func(t Tick) { ticks <- t })
+
+	assert.NoError(t, stream.Subscribe(m.DomesticCoin, "KRW-BTC"))
+	assert.Error(t, stream.Subscribe(m.DomesticStock, "005930"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go stream.Run(ctx)
+
+	t.Run("오류 프레임 무시 후 현재가 수신", func(t *testing.T) {
+		assert.Equal(t, []string{"KRW-BTC"}, waitFor(t, requests))
+		tick := waitFor(t, ticks)
+		assert.Equal(t, "KRW-BTC", tick.Code)
+		assert.Equal(t, m.DomesticCoin, tick.Category)
+		assert.Equal(t, 98000000.0, tick.Price)
+	})
+
+	t.Run("재접속 시 재구독", func(t *testing.T) {
+		assert.Equal(t, 1, waitFor(t, disconnects))
+		assert.Equal(t, []string{"KRW-BTC"}, waitFor(t, requests))
+		waitFor(t, ticks)
+	})
+
+	t.Run("구독 추가 시 전체 목록 재요청", func(t *testing.T) {
+		assert.NoError(t, stream.Subscribe(m.DomesticCoin, "KRW-ETH"))
+		assert.Equal(t, []string{"KRW-BTC", "KRW-ETH"}, waitFor(t, requests))
+	})
+
+	t.Run("전체 구독 해제 시 연결 종료 후 재접속", func(t *testing.T) {
+		assert.NoError(t, stream.Unsubscribe("KRW-BTC"))
+		assert.Equal(t, []string{"KRW-ETH"}, waitFor(t, requests))
+
+		assert.NoError(t, stream.Unsubscribe("KRW-ETH"))
+		assert.Equal(t, 2, waitFor(t, disconnects))
+
+		assert.NoError(t, stream.Subscribe(m.DomesticCoin, "KRW-XRP"))
+		assert.Equal(t, []string{"KRW-XRP"}, waitFor(t, requests))
+	})
+}